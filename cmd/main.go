@@ -6,9 +6,12 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/jaster-prj/canopenrest/external/cantransport"
+	"github.com/jaster-prj/canopenrest/external/configuration"
 	"github.com/jaster-prj/canopenrest/external/echoserver"
 	canopenrestimpl "github.com/jaster-prj/canopenrest/external/echoserver/implementation/canopenrest"
 	"github.com/jaster-prj/canopenrest/external/persistence/filestorage"
+	"github.com/jaster-prj/canopenrest/external/simulator"
 	"github.com/jaster-prj/canopenrest/usecases/canopenuc"
 	"github.com/rs/zerolog"
 	log "github.com/rs/zerolog/log"
@@ -16,23 +19,46 @@ import (
 	"github.com/labstack/echo/v4"
 )
 
-const canPort string = "can0"
-
 func main() {
 	zerolog.SetGlobalLevel(zerolog.DebugLevel)
 
+	config, err := configuration.LoadConfiguration(os.Args[1:])
+	if err != nil {
+		log.Fatal().Msg(err.Error())
+	}
 	fileStorage, err := filestorage.NewFilestorage()
 	if err != nil {
 		log.Fatal().Msg(err.Error())
 	}
+	err = fileStorage.MigrateNodes(config.Buses[0].Name)
+	if err != nil {
+		log.Fatal().Msg(err.Error())
+	}
 	canOpenUCConfig := canopenuc.CanOpenUCConfig{
-		Persistence: fileStorage,
-		CanPort:     canPort,
+		Persistence:      fileStorage,
+		FlashConcurrency: config.FlashConcurrency,
+	}
+	for _, busConfig := range config.Buses {
+		transport, err := busConfig.Can.CreateTransport()
+		if err != nil {
+			log.Fatal().Msg(err.Error())
+		}
+		log.Info().Msgf("bus %s: can transport %s on %s (bitrate %d)", busConfig.Name, busConfig.Can.Type, busConfig.Can.Interface, busConfig.Can.Bitrate)
+		canOpenUCConfig.Buses = append(canOpenUCConfig.Buses, canopenuc.BusConfig{
+			Name:      busConfig.Name,
+			Transport: transport,
+		})
 	}
 	canOpenUC, err := canOpenUCConfig.CreateCanOpenUC()
 	if err != nil {
 		log.Fatal().Msg(err.Error())
 	}
+	for _, busConfig := range config.Buses {
+		err = startSimulation(busConfig)
+		if err != nil {
+			log.Fatal().Msg(err.Error())
+		}
+	}
 	echoServer := echo.New()
 	canopenRestHandler, err := canopenrestimpl.NewHandler(canOpenUC)
 	if err != nil {
@@ -49,6 +75,7 @@ func main() {
 		//		WithDebug().
 		WithLogger().
 		WithCors().
+		WithRequestTimeout().
 		WithSwaggerUi("/canopenrest").
 		RegisterUrls()
 
@@ -70,3 +97,28 @@ func main() {
 		log.Fatal().Msg(echoServer.StartTLS(fmt.Sprintf(":%d", port), certPath, keyPath).Error())
 	}
 }
+
+// startSimulation starts the simulated nodes of a virtual bus
+func startSimulation(busConfig configuration.BusConfiguration) error {
+	for _, node := range busConfig.Simulate {
+		edsFile, err := os.ReadFile(node.EdsPath)
+		if err != nil {
+			return err
+		}
+		slaveConfig := simulator.SlaveConfig{
+			NodeId:    node.NodeId,
+			EdsFile:   edsFile,
+			Transport: cantransport.GetVirtualBus(busConfig.Can.Interface).NewTransport(),
+		}
+		slave, err := slaveConfig.CreateSlave()
+		if err != nil {
+			return fmt.Errorf("simulated node %d on bus %s: %w", node.NodeId, busConfig.Name, err)
+		}
+		err = slave.Start()
+		if err != nil {
+			return err
+		}
+		log.Info().Msgf("bus %s: simulated node %d started", busConfig.Name, node.NodeId)
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package entities
+
+import "time"
+
+// ParameterSnapshot is a backup of the readable objects of a node. Versions count up per node.
+type ParameterSnapshot struct {
+	Bus     string
+	Id      int
+	Version int
+	Created time.Time
+	Comment string
+	Values  []ParameterValue
+}
+
+// ParameterValue is the raw data of an object read for a snapshot, Value is the decoded data
+type ParameterValue struct {
+	Index    uint16
+	Subindex uint8
+	Name     string
+	DataType string
+	Data     []byte
+	Value    any
+}
+
+type ParameterChange string
+
+const (
+	ParameterChanged ParameterChange = "changed"
+	ParameterAdded   ParameterChange = "added"
+	ParameterRemoved ParameterChange = "removed"
+)
+
+// ParameterDiff is an object differing between two snapshots or a snapshot and the node
+type ParameterDiff struct {
+	Index    uint16
+	Subindex uint8
+	Name     string
+	DataType string
+	Change   ParameterChange
+	From     any
+	To       any
+}
@@ -0,0 +1,41 @@
+package entities
+
+type ConfigurationResultType string
+
+const (
+	ConfigurationWritten   ConfigurationResultType = "written"
+	ConfigurationUnchanged ConfigurationResultType = "unchanged"
+	ConfigurationFailed    ConfigurationResultType = "failed"
+)
+
+// ConfigurationValue overrides the value of an object of a configuration download
+type ConfigurationValue struct {
+	Index    uint16
+	Subindex uint8
+	Value    any
+}
+
+// ConfigurationResult reports the download of a single object, Previous is the value read before
+type ConfigurationResult struct {
+	Index    uint16
+	Subindex uint8
+	Name     string
+	DataType string
+	Value    any
+	Previous any
+	Result   ConfigurationResultType
+	Error    string
+}
+
+// ConfigurationReport is the result of a configuration download to a node
+type ConfigurationReport struct {
+	Bus       string
+	Id        int
+	Written   int
+	Unchanged int
+	Failed    int
+	// Stored is set if the parameters were stored in the node (0x1010) after the download
+	Stored     bool
+	StoreError string
+	Objects    []ConfigurationResult
+}
@@ -0,0 +1,42 @@
+package entities
+
+import (
+	"fmt"
+	"strings"
+)
+
+type EdsIssueSeverity string
+
+const (
+	EdsError   EdsIssueSeverity = "error"
+	EdsWarning EdsIssueSeverity = "warning"
+)
+
+// EdsIssue is a finding of the eds validation, Section names the ini section it refers to
+type EdsIssue struct {
+	Severity EdsIssueSeverity
+	Section  string
+	Message  string
+}
+
+func (i EdsIssue) String() string {
+	if i.Section == "" {
+		return fmt.Sprintf("%s: %s", i.Severity, i.Message)
+	}
+	return fmt.Sprintf("%s: [%s] %s", i.Severity, i.Section, i.Message)
+}
+
+// EdsValidationError rejects an eds or dcf file with errors, Issues also lists the warnings
+type EdsValidationError struct {
+	Issues []EdsIssue
+}
+
+func (e *EdsValidationError) Error() string {
+	messages := []string{}
+	for _, issue := range e.Issues {
+		if issue.Severity == EdsError {
+			messages = append(messages, issue.String())
+		}
+	}
+	return "invalid eds: " + strings.Join(messages, "; ")
+}
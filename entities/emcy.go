@@ -0,0 +1,59 @@
+package entities
+
+import "time"
+
+// Emcy is an emergency message received from a node
+type Emcy struct {
+	Timestamp        time.Time
+	ErrorCode        uint16
+	ErrorRegister    uint8
+	ManufacturerData []byte
+}
+
+var emcyErrorCodeNames = map[uint16]string{
+	0x0000: "Error reset or no error",
+	0x1000: "Generic error",
+	0x2000: "Current",
+	0x2100: "Current, device input side",
+	0x2200: "Current inside the device",
+	0x2300: "Current, device output side",
+	0x3000: "Voltage",
+	0x3100: "Mains voltage",
+	0x3200: "Voltage inside the device",
+	0x3300: "Output voltage",
+	0x4000: "Temperature",
+	0x4100: "Ambient temperature",
+	0x4200: "Device temperature",
+	0x5000: "Device hardware",
+	0x6000: "Device software",
+	0x6100: "Internal software",
+	0x6200: "User software",
+	0x6300: "Data set",
+	0x7000: "Additional modules",
+	0x8000: "Monitoring",
+	0x8100: "Communication",
+	0x8110: "CAN overrun (objects lost)",
+	0x8120: "CAN in error passive mode",
+	0x8130: "Life guard error or heartbeat error",
+	0x8140: "Recovered from bus off",
+	0x8150: "CAN-ID collision",
+	0x8200: "Protocol error",
+	0x8210: "PDO not processed due to length error",
+	0x8220: "PDO length exceeded",
+	0x8230: "DAM MPDO not processed, destination object not available",
+	0x8240: "Unexpected SYNC data length",
+	0x8250: "RPDO timeout",
+	0x9000: "External error",
+	0xF000: "Additional functions",
+	0xFF00: "Device specific",
+}
+
+// Description returns the CiA 301 meaning of the error code, falling back to its error class
+func (e Emcy) Description() string {
+	for _, mask := range []uint16{0xFFFF, 0xFFF0, 0xFF00, 0xF000} {
+		if name, ok := emcyErrorCodeNames[e.ErrorCode&mask]; ok {
+			return name
+		}
+	}
+	return "Unknown error"
+}
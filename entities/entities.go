@@ -8,17 +8,55 @@ import (
 
 type FlashOrder struct {
 	FlashOrderId uuid.UUID
+	Bus          string
 	Id           int
-	FlashFile    []byte
+	Size         int64
 	Version      *string
+	// ImageHash is the hex encoded SHA-256 of the flash file
+	ImageHash string
+	// ImageId is the firmware image flashed, orders with an uploaded flash file have none
+	ImageId   string
+	Requester string
 }
 
 type FlashOrderState struct {
+	Order     FlashOrder
 	Requested time.Time
 	Start     *time.Time
 	Finish    *time.Time
 	State     FlashState
 	Error     *string
+	Progress  *FlashProgress
+}
+
+// FlashOrderFilter selects flash orders, unset fields match all orders
+type FlashOrderFilter struct {
+	Bus   string
+	Id    *int
+	State *FlashState
+	// From and To limit the time the order was requested, To is exclusive
+	From *time.Time
+	To   *time.Time
+}
+
+// FlashProgress is the progress of the program data download of a flash order
+type FlashProgress struct {
+	// Transferred and Total are in bytes
+	Transferred int64
+	Total       int64
+	// Rate is the average transfer rate in bytes per second
+	Rate float64
+	// Eta is the estimated time until the download is complete
+	Eta     time.Duration
+	Updated time.Time
+}
+
+// Percent returns the transferred part of the download in percent
+func (p FlashProgress) Percent() float64 {
+	if p.Total <= 0 {
+		return 0
+	}
+	return float64(p.Transferred) * 100 / float64(p.Total)
 }
 
 type FlashState int
@@ -37,6 +75,7 @@ const (
 	FlashProgramAck
 	FlashProgramFinish
 	FlashProgramError
+	FlashCancelled
 )
 
 var flashStateNames = map[FlashState]string{
@@ -53,8 +92,29 @@ var flashStateNames = map[FlashState]string{
 	FlashProgramAck:          "Flash Program acknowledging application",
 	FlashProgramFinish:       "Flash Program finished",
 	FlashProgramError:        "Flash Program finished with error",
+	FlashCancelled:           "Flash cancelled",
 }
 
 func (fs FlashState) String() string {
 	return flashStateNames[fs]
 }
+
+// Finished is true for the final states of a flash order
+func (fs FlashState) Finished() bool {
+	return fs == FlashProgramFinish || fs == FlashProgramError || fs == FlashCancelled
+}
+
+type FlashEventType string
+
+const (
+	FlashStateChanged FlashEventType = "state"
+	FlashProgressed   FlashEventType = "progress"
+	FlashFinished     FlashEventType = "result"
+)
+
+// FlashEvent reports a state transition, a progress update or the result of a flash order
+type FlashEvent struct {
+	Type         FlashEventType
+	FlashOrderId uuid.UUID
+	State        FlashOrderState
+}
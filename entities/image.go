@@ -0,0 +1,16 @@
+package entities
+
+import "time"
+
+// FirmwareImage is a flash file of the image repository. The Id is the hex encoded SHA-256 of the
+// content, an image is stored once however often it is uploaded or flashed.
+type FirmwareImage struct {
+	Id string
+	// ProductCode is the product code of the identity 0x1018 of the nodes the image is built for
+	ProductCode *uint32
+	Version     *string
+	// Signature is stored with the image for the client, it is not verified
+	Signature *string
+	Size      int64
+	Uploaded  time.Time
+}
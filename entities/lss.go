@@ -0,0 +1,16 @@
+package entities
+
+type LssMode string
+
+const (
+	LssWaiting       LssMode = "waiting"
+	LssConfiguration LssMode = "configuration"
+)
+
+// LssIdentity is the LSS address of a node, the identity object 0x1018
+type LssIdentity struct {
+	VendorId       uint32
+	ProductCode    uint32
+	RevisionNumber uint32
+	SerialNumber   uint32
+}
@@ -0,0 +1,62 @@
+package entities
+
+import "time"
+
+// NodeStatus is the liveness of a node derived from its heartbeats
+type NodeStatus struct {
+	Bus           string
+	Id            int
+	Online        bool
+	State         string
+	LastSeen      *time.Time
+	HeartbeatTime time.Duration
+}
+
+type NodeEventType string
+
+const (
+	NodeOnline       NodeEventType = "online"
+	NodeOffline      NodeEventType = "offline"
+	NodeStateChanged NodeEventType = "state-change"
+)
+
+// NodeEvent reports a change of the liveness or NMT state of a node
+type NodeEvent struct {
+	Type      NodeEventType
+	Bus       string
+	Id        int
+	State     string
+	Timestamp time.Time
+}
+
+// ScanResult describes a node found on the bus by SDO or heartbeat
+type ScanResult struct {
+	Id int
+	// SdoResponse is set if the node answered the SDO requests of the scan
+	SdoResponse bool
+	// Heartbeat is set if the node is online according to its heartbeats
+	Heartbeat      bool
+	State          string
+	DeviceType     *uint32
+	VendorId       *uint32
+	ProductCode    *uint32
+	RevisionNumber *uint32
+	SerialNumber   *uint32
+	// HasEds is set if an eds is stored for the node
+	HasEds bool
+}
+
+// NodeInfo describes a node with stored eds by the file and device information of the eds
+type NodeInfo struct {
+	Bus              string
+	Id               int
+	FileName         string
+	FileVersion      string
+	Description      string
+	ModificationDate string
+	VendorName       string
+	VendorNumber     string
+	ProductName      string
+	ProductNumber    string
+	RevisionNumber   string
+}
@@ -0,0 +1,17 @@
+package entities
+
+// OdObject is an entry of the object dictionary of a node as described by its eds.
+// Arrays and records hold their sub-indexes in SubObjects, variables describe their data.
+type OdObject struct {
+	Index        uint16
+	Subindex     uint8
+	Name         string
+	ObjectType   string
+	DataType     string
+	AccessType   string
+	DefaultValue string
+	PdoMapping   bool
+	LowLimit     string
+	HighLimit    string
+	SubObjects   []OdObject
+}
@@ -0,0 +1,27 @@
+package entities
+
+type ParameterGroup string
+
+const (
+	ParameterGroupAll           ParameterGroup = "all"
+	ParameterGroupCommunication ParameterGroup = "communication"
+	ParameterGroupApplication   ParameterGroup = "application"
+	ParameterGroupManufacturer  ParameterGroup = "manufacturer"
+)
+
+type ParameterReset string
+
+const (
+	ParameterResetNone          ParameterReset = ""
+	ParameterResetNode          ParameterReset = "node"
+	ParameterResetCommunication ParameterReset = "communication"
+)
+
+// ParameterCapability tells how a node stores (0x1010) and restores (0x1011) a parameter group
+type ParameterCapability struct {
+	Group             ParameterGroup
+	Subindex          uint8
+	Store             bool
+	StoreAutonomously bool
+	Restore           bool
+}
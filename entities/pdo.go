@@ -0,0 +1,51 @@
+package entities
+
+import "time"
+
+type PdoDirection string
+
+const (
+	PdoReceive  PdoDirection = "rx"
+	PdoTransmit PdoDirection = "tx"
+)
+
+// PdoConfig holds the communication and mapping parameters of a PDO
+type PdoConfig struct {
+	Direction        PdoDirection
+	Number           int
+	CobId            uint32
+	Enabled          bool
+	RtrAllowed       bool
+	TransmissionType uint8
+	InhibitTime      *uint16
+	EventTimer       *uint16
+	SyncStart        *uint8
+	Mapping          []PdoMapping
+}
+
+// PdoMapping is a single mapped object of a PDO
+type PdoMapping struct {
+	Index     uint16
+	Subindex  uint8
+	BitLength uint8
+	Name      string
+	DataType  string
+}
+
+// PdoValues holds the latest data of a PDO decoded per mapped object
+type PdoValues struct {
+	Number    int
+	CobId     uint32
+	Received  bool
+	Timestamp *time.Time
+	Data      []byte
+	Values    []PdoValue
+}
+
+// PdoValue is the value of a single mapped object
+type PdoValue struct {
+	Index    uint16
+	Subindex uint8
+	Name     string
+	Value    any
+}
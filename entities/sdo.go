@@ -0,0 +1,14 @@
+package entities
+
+// SdoValue is the value of an object decoded with the data type of the eds
+type SdoValue struct {
+	Index    uint16
+	Subindex uint8
+	Name     string
+	DataType string
+	Value    any
+}
+
+// SdoProgress is called during streamed SDO transfers with the bytes transferred so far.
+// total is -1 if the size of the transfer is not known.
+type SdoProgress func(transferred int64, total int64)
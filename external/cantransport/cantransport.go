@@ -0,0 +1,103 @@
+package cantransport
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+
+	"github.com/jaster-prj/canopenrest/common"
+	can "github.com/jaster-prj/go-can"
+	transports "github.com/jaster-prj/go-can/transports"
+)
+
+const (
+	TransportSocketCan      = "socketcan"
+	TransportUSBCanAnalyzer = "usbcananalyzer"
+	TransportVirtual        = "virtual"
+)
+
+const (
+	defaultSerialBaudrate = 2000000
+	// usbCanAnalyzerBitrate is the bus speed selected by the init sequence of
+	// the go-can USBCanAnalyzer transport and can not be changed
+	usbCanAnalyzerBitrate = 125000
+)
+
+// Bitrates lists the bit rates defined by CiA 301
+var Bitrates = []int{10000, 20000, 50000, 125000, 250000, 500000, 800000, 1000000}
+
+// TransportConfig describes the CAN transport a bus is opened with
+type TransportConfig struct {
+	// Type of the transport, one of TransportSocketCan, TransportUSBCanAnalyzer or TransportVirtual
+	Type string
+	// Interface is the network interface (e.g. can0) for socketcan,
+	// the serial device (e.g. /dev/ttyUSB0) for usbcananalyzer or
+	// the name of the in-memory bus for virtual
+	Interface string
+	// Bitrate of the CAN bus in bit/s. SocketCAN interfaces are set to it with
+	// "ip link" if it differs, 0 keeps the bitrate configured by the system.
+	Bitrate int
+	// SerialBaudrate of the serial connection to an usbcananalyzer adapter
+	SerialBaudrate int
+}
+
+// Validate checks the configuration and returns a descriptive error for the first problem found
+func (tc *TransportConfig) Validate() error {
+	if tc.Interface == "" {
+		return errors.New("can interface must not be empty")
+	}
+	if tc.Bitrate != 0 && !common.CONTAINS(Bitrates, tc.Bitrate) {
+		return fmt.Errorf("can bitrate %d is not supported, use one of %v", tc.Bitrate, Bitrates)
+	}
+	switch strings.ToLower(tc.Type) {
+	case TransportSocketCan:
+		if _, err := net.InterfaceByName(tc.Interface); err != nil {
+			return fmt.Errorf("can interface %q not available: %w", tc.Interface, err)
+		}
+	case TransportUSBCanAnalyzer:
+		if tc.Bitrate != 0 && tc.Bitrate != usbCanAnalyzerBitrate {
+			return fmt.Errorf("can bitrate %d is not supported by transport %s, only %d is possible", tc.Bitrate, TransportUSBCanAnalyzer, usbCanAnalyzerBitrate)
+		}
+		if tc.SerialBaudrate < 0 {
+			return fmt.Errorf("serial baudrate %d is invalid", tc.SerialBaudrate)
+		}
+		if _, err := os.Stat(tc.Interface); err != nil {
+			return fmt.Errorf("serial device %q not available: %w", tc.Interface, err)
+		}
+	case TransportVirtual:
+	default:
+		return fmt.Errorf("can transport %q is unknown, use one of %s, %s, %s", tc.Type, TransportSocketCan, TransportUSBCanAnalyzer, TransportVirtual)
+	}
+	return nil
+}
+
+// CreateTransport validates the configuration and returns the matching can.Transport
+func (tc *TransportConfig) CreateTransport() (can.Transport, error) {
+	if err := tc.Validate(); err != nil {
+		return nil, err
+	}
+	switch strings.ToLower(tc.Type) {
+	case TransportUSBCanAnalyzer:
+		baudrate := tc.SerialBaudrate
+		if baudrate == 0 {
+			baudrate = defaultSerialBaudrate
+		}
+		return &transports.USBCanAnalyzer{
+			Port:     tc.Interface,
+			BaudRate: baudrate,
+		}, nil
+	case TransportVirtual:
+		return GetVirtualBus(tc.Interface).NewTransport(), nil
+	default:
+		if tc.Bitrate != 0 {
+			if err := configureSocketCanBitrate(tc.Interface, tc.Bitrate); err != nil {
+				return nil, err
+			}
+		}
+		return &transports.SocketCan{
+			Interface: tc.Interface,
+		}, nil
+	}
+}
@@ -0,0 +1,58 @@
+package cantransport
+
+import (
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+)
+
+// socketCanLink is the part of the output of "ip -details -json link show" describing the bit timing
+type socketCanLink struct {
+	Linkinfo struct {
+		InfoKind string `json:"info_kind"`
+		InfoData struct {
+			Bittiming struct {
+				Bitrate int `json:"bitrate"`
+			} `json:"bittiming"`
+		} `json:"info_data"`
+	} `json:"linkinfo"`
+}
+
+// configureSocketCanBitrate sets the bitrate of a SocketCAN interface with "ip link" if it differs.
+// The interface is taken down for the change, which needs the capability CAP_NET_ADMIN.
+// Virtual interfaces (vcan) have no bit timing, their bitrate is left alone.
+func configureSocketCanBitrate(iface string, bitrate int) error {
+	output, err := exec.Command("ip", "-details", "-json", "link", "show", "dev", iface).Output()
+	if err != nil {
+		return fmt.Errorf("read bitrate of can interface %q: %w", iface, err)
+	}
+	var links []socketCanLink
+	err = json.Unmarshal(output, &links)
+	if err != nil || len(links) != 1 {
+		return fmt.Errorf("read bitrate of can interface %q: unexpected output of ip link", iface)
+	}
+	link := links[0].Linkinfo
+	if link.InfoKind != "can" {
+		log.Warn().Str("Function", "configureSocketCanBitrate").Msgf("can interface %s of kind %q has no bit timing, bitrate %d ignored", iface, link.InfoKind, bitrate)
+		return nil
+	}
+	if link.InfoData.Bittiming.Bitrate == bitrate {
+		return nil
+	}
+	log.Info().Str("Function", "configureSocketCanBitrate").Msgf("can interface %s: bitrate %d changed to %d", iface, link.InfoData.Bittiming.Bitrate, bitrate)
+	for _, args := range [][]string{
+		{"link", "set", "dev", iface, "down"},
+		{"link", "set", "dev", iface, "type", "can", "bitrate", strconv.Itoa(bitrate)},
+		{"link", "set", "dev", iface, "up"},
+	} {
+		output, err := exec.Command("ip", args...).CombinedOutput()
+		if err != nil {
+			return fmt.Errorf("set bitrate %d of can interface %q: ip %s: %v %s", bitrate, iface, strings.Join(args, " "), err, strings.TrimSpace(string(output)))
+		}
+	}
+	return nil
+}
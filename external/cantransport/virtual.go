@@ -0,0 +1,141 @@
+package cantransport
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+
+	can "github.com/jaster-prj/go-can"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	virtualReadChanSize    = 256
+	virtualDropLogInterval = 1000
+)
+
+var (
+	virtualBusesMu sync.Mutex
+	virtualBuses   = map[string]*VirtualBus{}
+)
+
+// VirtualBus is an in-memory CAN bus. Every frame written by one of its
+// transports is delivered to all other open transports of the bus.
+type VirtualBus struct {
+	mu         sync.RWMutex
+	transports []*VirtualTransport
+}
+
+// NewVirtualBus returns a new unnamed VirtualBus
+func NewVirtualBus() *VirtualBus {
+	return &VirtualBus{}
+}
+
+// GetVirtualBus returns the process wide VirtualBus with the given name, creating it on first use
+func GetVirtualBus(name string) *VirtualBus {
+	virtualBusesMu.Lock()
+	defer virtualBusesMu.Unlock()
+	bus, ok := virtualBuses[name]
+	if !ok {
+		bus = NewVirtualBus()
+		virtualBuses[name] = bus
+	}
+	return bus
+}
+
+// NewTransport returns a new transport connected to the bus
+func (vb *VirtualBus) NewTransport() *VirtualTransport {
+	return &VirtualTransport{
+		bus: vb,
+	}
+}
+
+func (vb *VirtualBus) attach(transport *VirtualTransport) {
+	vb.mu.Lock()
+	defer vb.mu.Unlock()
+	vb.transports = append(vb.transports, transport)
+}
+
+func (vb *VirtualBus) detach(transport *VirtualTransport) {
+	vb.mu.Lock()
+	defer vb.mu.Unlock()
+	for i, t := range vb.transports {
+		if t == transport {
+			vb.transports = append(vb.transports[:i], vb.transports[i+1:]...)
+			return
+		}
+	}
+}
+
+// publish delivers a frame to all other transports. A transport not reading its frames loses them
+// like a CAN controller overrun, it must not block the senders of the bus.
+func (vb *VirtualBus) publish(sender *VirtualTransport, frm can.Frame) {
+	vb.mu.RLock()
+	defer vb.mu.RUnlock()
+	for _, t := range vb.transports {
+		if t == sender {
+			continue
+		}
+		frmCopy := frm
+		select {
+		case t.readChan <- &frmCopy:
+		default:
+			if dropped := t.dropped.Add(1); dropped == 1 || dropped%virtualDropLogInterval == 0 {
+				log.Warn().Str("Function", "publish").Msgf("virtual transport not reading, %d frames dropped", dropped)
+			}
+		}
+	}
+}
+
+// VirtualTransport implements can.Transport on a VirtualBus
+type VirtualTransport struct {
+	mu       sync.Mutex
+	bus      *VirtualBus
+	open     bool
+	readChan chan *can.Frame
+	// dropped counts the frames lost while readChan was full
+	dropped atomic.Uint64
+}
+
+// Open attaches the transport to its bus
+func (t *VirtualTransport) Open() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.open {
+		return nil
+	}
+	t.readChan = make(chan *can.Frame, virtualReadChanSize)
+	t.open = true
+	t.bus.attach(t)
+	return nil
+}
+
+// Close detaches the transport from its bus
+func (t *VirtualTransport) Close() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if !t.open {
+		return nil
+	}
+	t.open = false
+	t.bus.detach(t)
+	close(t.readChan)
+	return nil
+}
+
+// Write sends the frame to all other transports of the bus
+func (t *VirtualTransport) Write(frm *can.Frame) error {
+	t.mu.Lock()
+	open := t.open
+	t.mu.Unlock()
+	if !open {
+		return errors.New("virtual transport not open")
+	}
+	t.bus.publish(t, *frm)
+	return nil
+}
+
+// ReadChan returns the channel of received frames
+func (t *VirtualTransport) ReadChan() chan *can.Frame {
+	return t.readChan
+}
@@ -0,0 +1,259 @@
+package configuration
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/jaster-prj/canopenrest/external/cantransport"
+	"github.com/jaster-prj/canopenrest/external/simulator"
+	"gopkg.in/yaml.v3"
+)
+
+const defaultConfigFile = "canopenrest.yaml"
+
+// Configuration of the canopenrest service
+type Configuration struct {
+	// Buses to serve, the first one is the default bus
+	Buses []BusConfiguration
+	// FlashConcurrency limits the flash orders running at once, 0 selects the default
+	FlashConcurrency int
+}
+
+// BusConfiguration names a CAN bus and its transport
+type BusConfiguration struct {
+	Name string
+	Can  cantransport.TransportConfig
+	// Simulate lists simulated nodes, only possible on virtual buses
+	Simulate []SimulatedNode
+}
+
+// SimulatedNode describes a simulated slave on a virtual bus
+type SimulatedNode struct {
+	// NodeId is 1-127 or 255 for a node waiting for its node id by LSS
+	NodeId  int
+	EdsPath string
+}
+
+type configurationFile struct {
+	Can   canFile   `yaml:"can"`
+	Buses []canFile `yaml:"buses"`
+	Flash flashFile `yaml:"flash"`
+}
+
+type canFile struct {
+	Name           *string        `yaml:"name,omitempty"`
+	Transport      *string        `yaml:"transport,omitempty"`
+	Interface      *string        `yaml:"interface,omitempty"`
+	Bitrate        *int           `yaml:"bitrate,omitempty"`
+	SerialBaudrate *int           `yaml:"serialBaudrate,omitempty"`
+	Simulate       []simulateFile `yaml:"simulate,omitempty"`
+}
+
+type flashFile struct {
+	Concurrency *int `yaml:"concurrency,omitempty"`
+}
+
+type simulateFile struct {
+	Node int    `yaml:"node"`
+	Eds  string `yaml:"eds"`
+}
+
+// LoadConfiguration builds the configuration from defaults, the config file,
+// environment variables and command line arguments. Later sources override earlier ones.
+// The config file is taken from -config, CANOPEN_CONFIG or canopenrest.yaml next to the executable.
+// It either describes a single bus in "can" or several in "buses"; environment variables
+// and command line arguments always apply to the first (default) bus.
+func LoadConfiguration(args []string) (*Configuration, error) {
+	config := &Configuration{}
+
+	flags := flag.NewFlagSet("canopenrest", flag.ContinueOnError)
+	configPath := flags.String("config", "", "path to the yaml configuration file")
+	canName := flags.String("can-name", "", "name of the default bus, defaults to the interface name")
+	canTransport := flags.String("can-transport", "", "can transport: socketcan, usbcananalyzer or virtual")
+	canInterface := flags.String("can-interface", "", "can interface (socketcan), serial device (usbcananalyzer) or bus name (virtual)")
+	canBitrate := flags.Int("can-bitrate", 0, "can bitrate in bit/s")
+	serialBaudrate := flags.Int("serial-baudrate", 0, "baudrate of the serial connection (usbcananalyzer)")
+	flashConcurrency := flags.Int("flash-concurrency", 0, "number of nodes flashed at once")
+	if err := flags.Parse(args); err != nil {
+		return nil, err
+	}
+	setFlags := map[string]bool{}
+	flags.Visit(func(f *flag.Flag) {
+		setFlags[f.Name] = true
+	})
+
+	filePath, required := *configPath, setFlags["config"]
+	if !required {
+		filePath, required = os.LookupEnv("CANOPEN_CONFIG")
+	}
+	if !required {
+		ex, err := os.Executable()
+		if err != nil {
+			return nil, err
+		}
+		filePath = filepath.Join(filepath.Dir(ex), defaultConfigFile)
+	}
+	if err := config.applyFile(filePath, required); err != nil {
+		return nil, err
+	}
+	if err := config.applyEnv(); err != nil {
+		return nil, err
+	}
+
+	defaultBus := &config.Buses[0]
+	if setFlags["can-name"] {
+		defaultBus.Name = *canName
+	}
+	if setFlags["can-transport"] {
+		defaultBus.Can.Type = *canTransport
+	}
+	if setFlags["can-interface"] {
+		defaultBus.Can.Interface = *canInterface
+	}
+	if setFlags["can-bitrate"] {
+		defaultBus.Can.Bitrate = *canBitrate
+	}
+	if setFlags["serial-baudrate"] {
+		defaultBus.Can.SerialBaudrate = *serialBaudrate
+	}
+	if setFlags["flash-concurrency"] {
+		config.FlashConcurrency = *flashConcurrency
+	}
+
+	if err := config.validate(); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+	return config, nil
+}
+
+func (c *Configuration) validate() error {
+	if c.FlashConcurrency < 0 {
+		return fmt.Errorf("flash concurrency %d is negative", c.FlashConcurrency)
+	}
+	names := map[string]bool{}
+	for i := range c.Buses {
+		bus := &c.Buses[i]
+		if bus.Name == "" {
+			bus.Name = filepath.Base(bus.Can.Interface)
+		}
+		if names[bus.Name] {
+			return fmt.Errorf("bus %s configured twice", bus.Name)
+		}
+		names[bus.Name] = true
+		if err := bus.Can.Validate(); err != nil {
+			return fmt.Errorf("bus %s: %w", bus.Name, err)
+		}
+		if len(bus.Simulate) > 0 && bus.Can.Type != cantransport.TransportVirtual {
+			return fmt.Errorf("bus %s: simulated nodes need transport %s", bus.Name, cantransport.TransportVirtual)
+		}
+		for _, node := range bus.Simulate {
+			if (node.NodeId < 1 || node.NodeId > 127) && node.NodeId != simulator.LSS_UNCONFIGURED_NODE_ID {
+				return fmt.Errorf("bus %s: simulated node id %d out of range 1-127 or 255 (unconfigured)", bus.Name, node.NodeId)
+			}
+			if _, err := os.Stat(node.EdsPath); err != nil {
+				return fmt.Errorf("bus %s: eds of simulated node %d: %w", bus.Name, node.NodeId, err)
+			}
+		}
+	}
+	return nil
+}
+
+func defaultBusConfiguration() BusConfiguration {
+	return BusConfiguration{
+		Can: cantransport.TransportConfig{
+			Type:      cantransport.TransportSocketCan,
+			Interface: "can0",
+		},
+	}
+}
+
+func (c *Configuration) applyFile(filePath string, required bool) error {
+	c.Buses = []BusConfiguration{defaultBusConfiguration()}
+	data, err := os.ReadFile(filePath)
+	if errors.Is(err, fs.ErrNotExist) && !required {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("read config file: %w", err)
+	}
+	var file configurationFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return fmt.Errorf("parse config file %s: %w", filePath, err)
+	}
+	if len(file.Buses) == 0 {
+		file.Buses = []canFile{file.Can}
+	}
+	if file.Flash.Concurrency != nil {
+		c.FlashConcurrency = *file.Flash.Concurrency
+	}
+	c.Buses = []BusConfiguration{}
+	for _, canFile := range file.Buses {
+		bus := defaultBusConfiguration()
+		canFile.apply(&bus)
+		c.Buses = append(c.Buses, bus)
+	}
+	return nil
+}
+
+func (cf *canFile) apply(bus *BusConfiguration) {
+	if cf.Name != nil {
+		bus.Name = *cf.Name
+	}
+	if cf.Transport != nil {
+		bus.Can.Type = *cf.Transport
+	}
+	if cf.Interface != nil {
+		bus.Can.Interface = *cf.Interface
+	}
+	if cf.Bitrate != nil {
+		bus.Can.Bitrate = *cf.Bitrate
+	}
+	if cf.SerialBaudrate != nil {
+		bus.Can.SerialBaudrate = *cf.SerialBaudrate
+	}
+	for _, simulate := range cf.Simulate {
+		bus.Simulate = append(bus.Simulate, SimulatedNode{
+			NodeId:  simulate.Node,
+			EdsPath: simulate.Eds,
+		})
+	}
+}
+
+func (c *Configuration) applyEnv() error {
+	defaultBus := &c.Buses[0]
+	if value, ok := os.LookupEnv("CANOPEN_CAN_NAME"); ok {
+		defaultBus.Name = value
+	}
+	if value, ok := os.LookupEnv("CANOPEN_CAN_TRANSPORT"); ok {
+		defaultBus.Can.Type = value
+	}
+	if value, ok := os.LookupEnv("CANOPEN_CAN_INTERFACE"); ok {
+		defaultBus.Can.Interface = value
+	}
+	if value, ok := os.LookupEnv("CANOPEN_CAN_BITRATE"); ok {
+		bitrate, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("CANOPEN_CAN_BITRATE: %w", err)
+		}
+		defaultBus.Can.Bitrate = bitrate
+	}
+	if value, ok := os.LookupEnv("CANOPEN_SERIAL_BAUDRATE"); ok {
+		baudrate, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("CANOPEN_SERIAL_BAUDRATE: %w", err)
+		}
+		defaultBus.Can.SerialBaudrate = baudrate
+	}
+	if value, ok := os.LookupEnv("CANOPEN_FLASH_CONCURRENCY"); ok {
+		concurrency, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("CANOPEN_FLASH_CONCURRENCY: %w", err)
+		}
+		c.FlashConcurrency = concurrency
+	}
+	return nil
+}
@@ -12,135 +12,2327 @@ import (
 	"net/url"
 	"path"
 	"strings"
+	"time"
 
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/labstack/echo/v4"
 	"github.com/oapi-codegen/runtime"
 )
 
+// Defines values for ConfigurationResultResult.
+const (
+	Failed    ConfigurationResultResult = "failed"
+	Unchanged ConfigurationResultResult = "unchanged"
+	Written   ConfigurationResultResult = "written"
+)
+
+// Defines values for EdsIssueSeverity.
+const (
+	Error   EdsIssueSeverity = "error"
+	Warning EdsIssueSeverity = "warning"
+)
+
+// Defines values for FlashEventType.
+const (
+	Progress FlashEventType = "progress"
+	Result   FlashEventType = "result"
+	State    FlashEventType = "state"
+)
+
+// Defines values for NodeEventType.
+const (
+	Offline     NodeEventType = "offline"
+	Online      NodeEventType = "online"
+	StateChange NodeEventType = "state-change"
+)
+
+// Defines values for ParameterCapabilityGroup.
+const (
+	ParameterCapabilityGroupAll           ParameterCapabilityGroup = "all"
+	ParameterCapabilityGroupApplication   ParameterCapabilityGroup = "application"
+	ParameterCapabilityGroupCommunication ParameterCapabilityGroup = "communication"
+	ParameterCapabilityGroupManufacturer  ParameterCapabilityGroup = "manufacturer"
+)
+
+// Defines values for ParameterDiffChange.
+const (
+	Added   ParameterDiffChange = "added"
+	Changed ParameterDiffChange = "changed"
+	Removed ParameterDiffChange = "removed"
+)
+
+// Defines values for PdoConfigDirection.
+const (
+	PdoConfigDirectionRx PdoConfigDirection = "rx"
+	PdoConfigDirectionTx PdoConfigDirection = "tx"
+)
+
+// Defines values for ProblemKind.
+const (
+	BusError   ProblemKind = "bus-error"
+	Conflict   ProblemKind = "conflict"
+	NotFound   ProblemKind = "not-found"
+	SdoAbort   ProblemKind = "sdo-abort"
+	Timeout    ProblemKind = "timeout"
+	Validation ProblemKind = "validation"
+)
+
+// Defines values for PutLSSStateParamsMode.
+const (
+	Configuration PutLSSStateParamsMode = "configuration"
+	Waiting       PutLSSStateParamsMode = "waiting"
+)
+
+// Defines values for PostNodeParametersRestoreParamsGroup.
+const (
+	PostNodeParametersRestoreParamsGroupAll           PostNodeParametersRestoreParamsGroup = "all"
+	PostNodeParametersRestoreParamsGroupApplication   PostNodeParametersRestoreParamsGroup = "application"
+	PostNodeParametersRestoreParamsGroupCommunication PostNodeParametersRestoreParamsGroup = "communication"
+	PostNodeParametersRestoreParamsGroupManufacturer  PostNodeParametersRestoreParamsGroup = "manufacturer"
+)
+
+// Defines values for PostNodeParametersRestoreParamsReset.
+const (
+	PostNodeParametersRestoreParamsResetCommunication PostNodeParametersRestoreParamsReset = "communication"
+	PostNodeParametersRestoreParamsResetNode          PostNodeParametersRestoreParamsReset = "node"
+)
+
+// Defines values for PostNodeParametersStoreParamsGroup.
+const (
+	PostNodeParametersStoreParamsGroupAll           PostNodeParametersStoreParamsGroup = "all"
+	PostNodeParametersStoreParamsGroupApplication   PostNodeParametersStoreParamsGroup = "application"
+	PostNodeParametersStoreParamsGroupCommunication PostNodeParametersStoreParamsGroup = "communication"
+	PostNodeParametersStoreParamsGroupManufacturer  PostNodeParametersStoreParamsGroup = "manufacturer"
+)
+
+// Defines values for PostNodeParametersStoreParamsReset.
+const (
+	Communication PostNodeParametersStoreParamsReset = "communication"
+	Node          PostNodeParametersStoreParamsReset = "node"
+)
+
+// Defines values for GetPDOParamsDirection.
+const (
+	GetPDOParamsDirectionRx GetPDOParamsDirection = "rx"
+	GetPDOParamsDirectionTx GetPDOParamsDirection = "tx"
+)
+
+// Defines values for PutPDOParamsDirection.
+const (
+	PutPDOParamsDirectionRx PutPDOParamsDirection = "rx"
+	PutPDOParamsDirectionTx PutPDOParamsDirection = "tx"
+)
+
+// Defines values for GetPDOsParamsDirection.
+const (
+	GetPDOsParamsDirectionRx GetPDOsParamsDirection = "rx"
+	GetPDOsParamsDirectionTx GetPDOsParamsDirection = "tx"
+)
+
+// ConfigurationReport defines model for ConfigurationReport.
+type ConfigurationReport struct {
+	Bus        *string                `json:"bus,omitempty"`
+	Failed     *int                   `json:"failed,omitempty"`
+	Node       *int                   `json:"node,omitempty"`
+	Objects    *[]ConfigurationResult `json:"objects,omitempty"`
+	StoreError *string                `json:"storeError,omitempty"`
+
+	// Stored Parameters were stored in the node after the download
+	Stored    *bool `json:"stored,omitempty"`
+	Unchanged *int  `json:"unchanged,omitempty"`
+	Written   *int  `json:"written,omitempty"`
+}
+
+// ConfigurationResult defines model for ConfigurationResult.
+type ConfigurationResult struct {
+	Error *string `json:"error,omitempty"`
+	Index *int    `json:"index,omitempty"`
+	Name  *string `json:"name,omitempty"`
+
+	// Previous Value read from the node before the download
+	Previous *interface{}               `json:"previous,omitempty"`
+	Result   *ConfigurationResultResult `json:"result,omitempty"`
+	Subindex *int                       `json:"subindex,omitempty"`
+	Type     *string                    `json:"type,omitempty"`
+
+	// Value Value downloaded to the node
+	Value *interface{} `json:"value,omitempty"`
+}
+
+// ConfigurationResultResult defines model for ConfigurationResult.Result.
+type ConfigurationResultResult string
+
+// EdsIssue defines model for EdsIssue.
+type EdsIssue struct {
+	Message *string `json:"message,omitempty"`
+
+	// Section ini section of the issue, empty for the whole file
+	Section  *string           `json:"section,omitempty"`
+	Severity *EdsIssueSeverity `json:"severity,omitempty"`
+}
+
+// EdsIssueSeverity defines model for EdsIssue.Severity.
+type EdsIssueSeverity string
+
+// EdsValidation defines model for EdsValidation.
+type EdsValidation struct {
+	Issues *[]EdsIssue `json:"issues,omitempty"`
+	Valid  *bool       `json:"valid,omitempty"`
+}
+
+// Emcy defines model for Emcy.
+type Emcy struct {
+	// Description Meaning of the error code according to CiA 301
+	Description   *string `json:"description,omitempty"`
+	ErrorCode     *int    `json:"errorCode,omitempty"`
+	ErrorRegister *int    `json:"errorRegister,omitempty"`
+
+	// ManufacturerData Manufacturer specific error field as hex
+	ManufacturerData *string    `json:"manufacturerData,omitempty"`
+	Timestamp        *time.Time `json:"timestamp,omitempty"`
+}
+
+// FirmwareImage defines model for FirmwareImage.
+type FirmwareImage struct {
+	// Id Hex encoded SHA-256 of the image
+	Id          *string `json:"id,omitempty"`
+	ProductCode *int    `json:"productCode,omitempty"`
+
+	// Signature Opaque metadata given with the upload, the service does not verify it
+	Signature *string `json:"signature,omitempty"`
+
+	// Size Size of the image in bytes
+	Size     *int       `json:"size,omitempty"`
+	Uploaded *time.Time `json:"uploaded,omitempty"`
+	Version  *string    `json:"version,omitempty"`
+}
+
+// FlashEvent defines model for FlashEvent.
+type FlashEvent struct {
+	// Id uuid of the FlashOrder
+	Id    *string          `json:"id,omitempty"`
+	Order *FlashOrderState `json:"order,omitempty"`
+	Type  *FlashEventType  `json:"type,omitempty"`
+}
+
+// FlashEventType defines model for FlashEvent.Type.
+type FlashEventType string
+
+// FlashOrderState defines model for FlashOrderState.
+type FlashOrderState struct {
+	Bus    *string    `json:"bus,omitempty"`
+	Error  *string    `json:"error,omitempty"`
+	Finish *time.Time `json:"finish,omitempty"`
+
+	// Id uuid of the FlashOrder
+	Id *string `json:"id,omitempty"`
+
+	// ImageHash Hex encoded SHA-256 of the flash file
+	ImageHash *string `json:"imageHash,omitempty"`
+
+	// ImageId Firmware image flashed, not set for orders with uploaded binary
+	ImageId *string `json:"imageId,omitempty"`
+	Node    *int    `json:"node,omitempty"`
+
+	// Progress Progress of the program data download, updated periodically while the state is data writing
+	Progress  *FlashProgress `json:"progress,omitempty"`
+	Requested *time.Time     `json:"requested,omitempty"`
+	Requester *string        `json:"requester,omitempty"`
+
+	// Size Size of the flash file in bytes
+	Size  *int       `json:"size,omitempty"`
+	Start *time.Time `json:"start,omitempty"`
+	State *string    `json:"state,omitempty"`
+
+	// Version Version requested to be flashed
+	Version *string `json:"version,omitempty"`
+}
+
+// FlashOrders defines model for FlashOrders.
+type FlashOrders struct {
+	Limit  *int               `json:"limit,omitempty"`
+	Offset *int               `json:"offset,omitempty"`
+	Orders *[]FlashOrderState `json:"orders,omitempty"`
+
+	// Total Number of orders matching the filter
+	Total *int `json:"total,omitempty"`
+}
+
+// FlashProgress Progress of the program data download, updated periodically while the state is data writing
+type FlashProgress struct {
+	// Eta Estimated seconds until the download is complete
+	Eta     *float32 `json:"eta,omitempty"`
+	Percent *float32 `json:"percent,omitempty"`
+
+	// Rate Average transfer rate in bytes per second
+	Rate *float32 `json:"rate,omitempty"`
+
+	// Total Size of the flash file in bytes
+	Total *int `json:"total,omitempty"`
+
+	// Transferred Bytes downloaded to the node
+	Transferred *int       `json:"transferred,omitempty"`
+	Updated     *time.Time `json:"updated,omitempty"`
+}
+
+// ImageFlashRequest defines model for ImageFlashRequest.
+type ImageFlashRequest struct {
+	// Bus CAN bus of the nodes, defaults to the first configured bus
+	Bus *string `json:"bus,omitempty"`
+
+	// Nodes Nodes to flash as hex
+	Nodes []string `json:"nodes"`
+
+	// Requester Client of the orders recorded in the order history, defaults to the address of the client
+	Requester *string `json:"requester,omitempty"`
+}
+
+// LssIdentity defines model for LssIdentity.
+type LssIdentity struct {
+	ProductCode    *int `json:"productCode,omitempty"`
+	RevisionNumber *int `json:"revisionNumber,omitempty"`
+	SerialNumber   *int `json:"serialNumber,omitempty"`
+	VendorId       *int `json:"vendorId,omitempty"`
+}
+
+// NodeEvent defines model for NodeEvent.
+type NodeEvent struct {
+	Bus  *string `json:"bus,omitempty"`
+	Node *int    `json:"node,omitempty"`
+
+	// State NMT state of the last heartbeat
+	State     *string        `json:"state,omitempty"`
+	Timestamp *time.Time     `json:"timestamp,omitempty"`
+	Type      *NodeEventType `json:"type,omitempty"`
+}
+
+// NodeEventType defines model for NodeEvent.Type.
+type NodeEventType string
+
+// NodeInfo defines model for NodeInfo.
+type NodeInfo struct {
+	Bus              *string `json:"bus,omitempty"`
+	Description      *string `json:"description,omitempty"`
+	FileName         *string `json:"fileName,omitempty"`
+	FileVersion      *string `json:"fileVersion,omitempty"`
+	ModificationDate *string `json:"modificationDate,omitempty"`
+	Node             *int    `json:"node,omitempty"`
+	ProductName      *string `json:"productName,omitempty"`
+	ProductNumber    *string `json:"productNumber,omitempty"`
+	RevisionNumber   *string `json:"revisionNumber,omitempty"`
+	VendorName       *string `json:"vendorName,omitempty"`
+	VendorNumber     *string `json:"vendorNumber,omitempty"`
+}
+
+// NodeStatus defines model for NodeStatus.
+type NodeStatus struct {
+	Bus *string `json:"bus,omitempty"`
+
+	// HeartbeatTime Producer heartbeat time in ms, 0 if unknown
+	HeartbeatTime *int       `json:"heartbeatTime,omitempty"`
+	LastSeen      *time.Time `json:"lastSeen,omitempty"`
+	Node          *int       `json:"node,omitempty"`
+	Online        *bool      `json:"online,omitempty"`
+
+	// State NMT state of the last heartbeat
+	State *string `json:"state,omitempty"`
+}
+
+// OdObject defines model for OdObject.
+type OdObject struct {
+	// AccessType ro, wo, rw, rwr, rww or const
+	AccessType *string `json:"accessType,omitempty"`
+	DataType   *string `json:"dataType,omitempty"`
+
+	// DefaultValue Default value as written in the eds, may contain $NODEID
+	DefaultValue *string `json:"defaultValue,omitempty"`
+	HighLimit    *string `json:"highLimit,omitempty"`
+	Index        *int    `json:"index,omitempty"`
+	LowLimit     *string `json:"lowLimit,omitempty"`
+	Name         *string `json:"name,omitempty"`
+
+	// ObjectType VAR, ARRAY, RECORD, DOMAIN, DEFTYPE or DEFSTRUCT
+	ObjectType *string `json:"objectType,omitempty"`
+	PdoMapping *bool   `json:"pdoMapping,omitempty"`
+
+	// SubObjects Sub-indexes of arrays and records
+	SubObjects *[]OdObject `json:"subObjects,omitempty"`
+	Subindex   *int        `json:"subindex,omitempty"`
+}
+
+// ParameterCapability defines model for ParameterCapability.
+type ParameterCapability struct {
+	Group *ParameterCapabilityGroup `json:"group,omitempty"`
+
+	// Restore Node restores the defaults of the group on command
+	Restore *bool `json:"restore,omitempty"`
+
+	// Store Node saves the group on command
+	Store *bool `json:"store,omitempty"`
+
+	// StoreAutonomously Node saves the group autonomously
+	StoreAutonomously *bool `json:"storeAutonomously,omitempty"`
+	Subindex          *int  `json:"subindex,omitempty"`
+}
+
+// ParameterCapabilityGroup defines model for ParameterCapability.Group.
+type ParameterCapabilityGroup string
+
+// ParameterDiff defines model for ParameterDiff.
+type ParameterDiff struct {
+	Change *ParameterDiffChange `json:"change,omitempty"`
+
+	// From Value of the backup
+	From     *interface{} `json:"from,omitempty"`
+	Index    *int         `json:"index,omitempty"`
+	Name     *string      `json:"name,omitempty"`
+	Subindex *int         `json:"subindex,omitempty"`
+
+	// To Value of the other backup or the node
+	To   *interface{} `json:"to,omitempty"`
+	Type *string      `json:"type,omitempty"`
+}
+
+// ParameterDiffChange defines model for ParameterDiff.Change.
+type ParameterDiffChange string
+
+// ParameterSnapshot defines model for ParameterSnapshot.
+type ParameterSnapshot struct {
+	Bus     *string `json:"bus,omitempty"`
+	Comment *string `json:"comment,omitempty"`
+
+	// Count Number of objects in the backup
+	Count   *int        `json:"count,omitempty"`
+	Created *time.Time  `json:"created,omitempty"`
+	Node    *int        `json:"node,omitempty"`
+	Values  *[]SdoValue `json:"values,omitempty"`
+	Version *int        `json:"version,omitempty"`
+}
+
+// PdoConfig defines model for PdoConfig.
+type PdoConfig struct {
+	// CobId 11 bit CAN identifier
+	CobId     *int                `json:"cobId,omitempty"`
+	Direction *PdoConfigDirection `json:"direction,omitempty"`
+	Enabled   *bool               `json:"enabled,omitempty"`
+
+	// EventTimer Event timer in ms, omitted if not supported
+	EventTimer *int `json:"eventTimer,omitempty"`
+
+	// InhibitTime Inhibit time in multiples of 100us, omitted if not supported
+	InhibitTime *int          `json:"inhibitTime,omitempty"`
+	Mapping     *[]PdoMapping `json:"mapping,omitempty"`
+	Number      *int          `json:"number,omitempty"`
+	RtrAllowed  *bool         `json:"rtrAllowed,omitempty"`
+
+	// SyncStart SYNC start value of TPDOs, omitted if not supported
+	SyncStart        *int `json:"syncStart,omitempty"`
+	TransmissionType *int `json:"transmissionType,omitempty"`
+}
+
+// PdoConfigDirection defines model for PdoConfig.Direction.
+type PdoConfigDirection string
+
+// PdoMapping defines model for PdoMapping.
+type PdoMapping struct {
+	BitLength *int `json:"bitLength,omitempty"`
+
+	// DataType Data type of the mapped object from the eds
+	DataType *string `json:"dataType,omitempty"`
+	Index    *int    `json:"index,omitempty"`
+
+	// Name Name of the mapped object from the eds
+	Name     *string `json:"name,omitempty"`
+	Subindex *int    `json:"subindex,omitempty"`
+}
+
+// PdoValue defines model for PdoValue.
+type PdoValue struct {
+	Index    *int    `json:"index,omitempty"`
+	Name     *string `json:"name,omitempty"`
+	Subindex *int    `json:"subindex,omitempty"`
+
+	// Value Value decoded according to the data type of the mapped object
+	Value *interface{} `json:"value,omitempty"`
+}
+
+// PdoValues defines model for PdoValues.
+type PdoValues struct {
+	CobId *int `json:"cobId,omitempty"`
+
+	// Data Received data as hex
+	Data      *string     `json:"data,omitempty"`
+	Number    *int        `json:"number,omitempty"`
+	Received  *bool       `json:"received,omitempty"`
+	Timestamp *time.Time  `json:"timestamp,omitempty"`
+	Values    *[]PdoValue `json:"values,omitempty"`
+}
+
+// Problem Problem details (RFC 7807) of a failed request
+type Problem struct {
+	Detail *string `json:"detail,omitempty"`
+
+	// Index Index of the aborted SDO transfer
+	Index *int `json:"index,omitempty"`
+
+	// Instance Path of the failed request
+	Instance *string `json:"instance,omitempty"`
+
+	// Issues Errors and warnings of an invalid eds or dcf file
+	Issues *[]EdsIssue  `json:"issues,omitempty"`
+	Kind   *ProblemKind `json:"kind,omitempty"`
+
+	// SdoAbortCode SDO abort code of the node or the gateway as hex
+	SdoAbortCode *string `json:"sdoAbortCode,omitempty"`
+
+	// SdoAbortText CiA 301 meaning of the abort code
+	SdoAbortText *string `json:"sdoAbortText,omitempty"`
+	Status       int     `json:"status"`
+
+	// Subindex Subindex of the aborted SDO transfer
+	Subindex *int   `json:"subindex,omitempty"`
+	Title    string `json:"title"`
+
+	// Type urn:canopenrest:problem:<kind> or about:blank for internal errors
+	Type string `json:"type"`
+}
+
+// ProblemKind defines model for Problem.Kind.
+type ProblemKind string
+
+// ScanResult defines model for ScanResult.
+type ScanResult struct {
+	DeviceType *int `json:"deviceType,omitempty"`
+
+	// HasEds An eds is stored for the node
+	HasEds *bool `json:"hasEds,omitempty"`
+
+	// Heartbeat The node is online by heartbeat
+	Heartbeat      *bool `json:"heartbeat,omitempty"`
+	Node           *int  `json:"node,omitempty"`
+	ProductCode    *int  `json:"productCode,omitempty"`
+	RevisionNumber *int  `json:"revisionNumber,omitempty"`
+
+	// SdoResponse The node answered the SDO requests
+	SdoResponse  *bool `json:"sdoResponse,omitempty"`
+	SerialNumber *int  `json:"serialNumber,omitempty"`
+
+	// State NMT state of the last heartbeat
+	State    *string `json:"state,omitempty"`
+	VendorId *int    `json:"vendorId,omitempty"`
+}
+
+// SdoValue defines model for SdoValue.
+type SdoValue struct {
+	Index    *int    `json:"index,omitempty"`
+	Name     *string `json:"name,omitempty"`
+	Subindex *int    `json:"subindex,omitempty"`
+
+	// Type CiA 301 data type, e.g. UNSIGNED16, REAL32, VISIBLE_STRING or DOMAIN
+	Type *string `json:"type,omitempty"`
+
+	// Value Number, boolean or string, DOMAIN and OCTET_STRING as hex string
+	Value interface{} `json:"value"`
+}
+
+// DeleteEmcyParams defines parameters for DeleteEmcy.
+type DeleteEmcyParams struct {
+	// Node Node to query
+	Node string `form:"node" json:"node"`
+
+	// Bus CAN bus of the node, defaults to the first configured bus
+	Bus *string `form:"bus,omitempty" json:"bus,omitempty"`
+}
+
+// GetEmcyParams defines parameters for GetEmcy.
+type GetEmcyParams struct {
+	// Node Node to query
+	Node string `form:"node" json:"node"`
+
+	// Bus CAN bus of the node, defaults to the first configured bus
+	Bus *string `form:"bus,omitempty" json:"bus,omitempty"`
+}
+
+// DeleteFlashParams defines parameters for DeleteFlash.
+type DeleteFlashParams struct {
+	// Id uuid of FlashOrder
+	Id string `form:"id" json:"id"`
+}
+
 // GetFlashParams defines parameters for GetFlash.
 type GetFlashParams struct {
 	// Id uuid of TestOrder
 	Id string `form:"id" json:"id"`
 }
 
-// PostFlashParams defines parameters for PostFlash.
-type PostFlashParams struct {
-	// Node Node to query
-	Node string `form:"node" json:"node"`
+// PostFlashParams defines parameters for PostFlash.
+type PostFlashParams struct {
+	// Node Node to query
+	Node string `form:"node" json:"node"`
+
+	// Bus CAN bus of the node, defaults to the first configured bus
+	Bus *string `form:"bus,omitempty" json:"bus,omitempty"`
+
+	// Version Version that will be flashed
+	Version *string `form:"version,omitempty" json:"version,omitempty"`
+
+	// Requester Client of the order recorded in the order history, defaults to the address of the client
+	Requester *string `form:"requester,omitempty" json:"requester,omitempty"`
+}
+
+// GetFlashEventsParams defines parameters for GetFlashEvents.
+type GetFlashEventsParams struct {
+	// Id uuid of the FlashOrder to follow, all orders if not given
+	Id *string `form:"id,omitempty" json:"id,omitempty"`
+}
+
+// GetFlashOrdersParams defines parameters for GetFlashOrders.
+type GetFlashOrdersParams struct {
+	// Bus CAN bus of the orders, all buses if not given
+	Bus *string `form:"bus,omitempty" json:"bus,omitempty"`
+
+	// Node Node of the orders
+	Node *string `form:"node,omitempty" json:"node,omitempty"`
+
+	// State State of the orders
+	State *int `form:"state,omitempty" json:"state,omitempty"`
+
+	// From Orders requested at or after this time (RFC 3339)
+	From *time.Time `form:"from,omitempty" json:"from,omitempty"`
+
+	// To Orders requested before this time (RFC 3339)
+	To *time.Time `form:"to,omitempty" json:"to,omitempty"`
+
+	// Offset Number of orders to skip
+	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+
+	// Limit Maximum number of orders to return, defaults to 50
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetImagesParams defines parameters for GetImages.
+type GetImagesParams struct {
+	// ProductCode Product code the images are built for, all images if not given
+	ProductCode *string `form:"productCode,omitempty" json:"productCode,omitempty"`
+}
+
+// PostImageParams defines parameters for PostImage.
+type PostImageParams struct {
+	// ProductCode Product code of the identity 0x1018 of the nodes the image is built for, checked before flashing
+	ProductCode *string `form:"productCode,omitempty" json:"productCode,omitempty"`
+
+	// Version Version of the image
+	Version *string `form:"version,omitempty" json:"version,omitempty"`
+
+	// Hash Expected hex encoded SHA-256 of the image, an upload with different content is rejected
+	Hash *string `form:"hash,omitempty" json:"hash,omitempty"`
+
+	// Signature Signature of the image, stored as opaque metadata for the clients and not verified
+	Signature *string `form:"signature,omitempty" json:"signature,omitempty"`
+}
+
+// PutLSSBitTimingParams defines parameters for PutLSSBitTiming.
+type PutLSSBitTimingParams struct {
+	// Bus CAN bus of the LSS slaves, defaults to the first configured bus
+	Bus *string `form:"bus,omitempty" json:"bus,omitempty"`
+
+	// Bitrate Bitrate of the CiA 301 bit timing table in bit/s
+	Bitrate int `form:"bitrate" json:"bitrate"`
+
+	// SwitchDelay Activates the bit timing, delay in ms before and after switching
+	SwitchDelay *int `form:"switchDelay,omitempty" json:"switchDelay,omitempty"`
+}
+
+// PostLSSFastscanParams defines parameters for PostLSSFastscan.
+type PostLSSFastscanParams struct {
+	// Bus CAN bus of the LSS slaves, defaults to the first configured bus
+	Bus *string `form:"bus,omitempty" json:"bus,omitempty"`
+}
+
+// GetLSSIdentityParams defines parameters for GetLSSIdentity.
+type GetLSSIdentityParams struct {
+	// Bus CAN bus of the LSS slaves, defaults to the first configured bus
+	Bus *string `form:"bus,omitempty" json:"bus,omitempty"`
+}
+
+// GetLSSNodeIdParams defines parameters for GetLSSNodeId.
+type GetLSSNodeIdParams struct {
+	// Bus CAN bus of the LSS slaves, defaults to the first configured bus
+	Bus *string `form:"bus,omitempty" json:"bus,omitempty"`
+}
+
+// PutLSSNodeIdParams defines parameters for PutLSSNodeId.
+type PutLSSNodeIdParams struct {
+	// Bus CAN bus of the LSS slaves, defaults to the first configured bus
+	Bus *string `form:"bus,omitempty" json:"bus,omitempty"`
+
+	// Node New node id, FF marks the node as unconfigured
+	Node string `form:"node" json:"node"`
+}
+
+// PostLSSSelectParams defines parameters for PostLSSSelect.
+type PostLSSSelectParams struct {
+	// Bus CAN bus of the LSS slaves, defaults to the first configured bus
+	Bus *string `form:"bus,omitempty" json:"bus,omitempty"`
+}
+
+// PutLSSStateParams defines parameters for PutLSSState.
+type PutLSSStateParams struct {
+	// Bus CAN bus of the LSS slaves, defaults to the first configured bus
+	Bus  *string               `form:"bus,omitempty" json:"bus,omitempty"`
+	Mode PutLSSStateParamsMode `form:"mode" json:"mode"`
+}
+
+// PutLSSStateParamsMode defines parameters for PutLSSState.
+type PutLSSStateParamsMode string
+
+// PostLSSStoreParams defines parameters for PostLSSStore.
+type PostLSSStoreParams struct {
+	// Bus CAN bus of the LSS slaves, defaults to the first configured bus
+	Bus *string `form:"bus,omitempty" json:"bus,omitempty"`
+}
+
+// GetNMTParams defines parameters for GetNMT.
+type GetNMTParams struct {
+	// Node Node to query
+	Node string `form:"node" json:"node"`
+
+	// Bus CAN bus of the node, defaults to the first configured bus
+	Bus *string `form:"bus,omitempty" json:"bus,omitempty"`
+}
+
+// PostNMTJSONBody defines parameters for PostNMT.
+type PostNMTJSONBody = string
+
+// PostNMTParams defines parameters for PostNMT.
+type PostNMTParams struct {
+	// Node Node to query
+	Node string `form:"node" json:"node"`
+
+	// Bus CAN bus of the node, defaults to the first configured bus
+	Bus *string `form:"bus,omitempty" json:"bus,omitempty"`
+}
+
+// DeleteNodeParams defines parameters for DeleteNode.
+type DeleteNodeParams struct {
+	// Node Node to query
+	Node string `form:"node" json:"node"`
+
+	// Bus CAN bus of the node, defaults to the first configured bus
+	Bus *string `form:"bus,omitempty" json:"bus,omitempty"`
+}
+
+// GetNodeParams defines parameters for GetNode.
+type GetNodeParams struct {
+	// Node Node to query
+	Node string `form:"node" json:"node"`
+
+	// Bus CAN bus of the node, defaults to the first configured bus
+	Bus *string `form:"bus,omitempty" json:"bus,omitempty"`
+}
+
+// PostNodeParams defines parameters for PostNode.
+type PostNodeParams struct {
+	// Node Node to query
+	Node string `form:"node" json:"node"`
+
+	// Bus CAN bus of the node, defaults to the first configured bus
+	Bus *string `form:"bus,omitempty" json:"bus,omitempty"`
+}
+
+// PutNodeParams defines parameters for PutNode.
+type PutNodeParams struct {
+	// Node Node to query
+	Node string `form:"node" json:"node"`
+
+	// Bus CAN bus of the node, defaults to the first configured bus
+	Bus *string `form:"bus,omitempty" json:"bus,omitempty"`
+}
+
+// GetNodeBackupsParams defines parameters for GetNodeBackups.
+type GetNodeBackupsParams struct {
+	// Bus CAN bus of the node, defaults to the first configured bus
+	Bus *string `form:"bus,omitempty" json:"bus,omitempty"`
+}
+
+// PostNodeBackupParams defines parameters for PostNodeBackup.
+type PostNodeBackupParams struct {
+	// Bus CAN bus of the node, defaults to the first configured bus
+	Bus *string `form:"bus,omitempty" json:"bus,omitempty"`
+
+	// Comment Comment stored with the backup
+	Comment *string `form:"comment,omitempty" json:"comment,omitempty"`
+}
+
+// DeleteNodeBackupParams defines parameters for DeleteNodeBackup.
+type DeleteNodeBackupParams struct {
+	// Bus CAN bus of the node, defaults to the first configured bus
+	Bus *string `form:"bus,omitempty" json:"bus,omitempty"`
+}
+
+// GetNodeBackupParams defines parameters for GetNodeBackup.
+type GetNodeBackupParams struct {
+	// Bus CAN bus of the node, defaults to the first configured bus
+	Bus *string `form:"bus,omitempty" json:"bus,omitempty"`
+}
+
+// GetNodeBackupDiffParams defines parameters for GetNodeBackupDiff.
+type GetNodeBackupDiffParams struct {
+	// Bus CAN bus of the node, defaults to the first configured bus
+	Bus *string `form:"bus,omitempty" json:"bus,omitempty"`
+
+	// Against Version of the backup to compare with, the node is read if omitted
+	Against *int `form:"against,omitempty" json:"against,omitempty"`
+}
+
+// PostNodeBackupRestoreParams defines parameters for PostNodeBackupRestore.
+type PostNodeBackupRestoreParams struct {
+	// Bus CAN bus of the node, defaults to the first configured bus
+	Bus *string `form:"bus,omitempty" json:"bus,omitempty"`
+
+	// Store Store the parameters in the node (0x1010) after the restore
+	Store *bool `form:"store,omitempty" json:"store,omitempty"`
+}
+
+// PostNodeConfigurationJSONBody defines parameters for PostNodeConfiguration.
+type PostNodeConfigurationJSONBody = []SdoValue
+
+// PostNodeConfigurationParams defines parameters for PostNodeConfiguration.
+type PostNodeConfigurationParams struct {
+	// Bus CAN bus of the node, defaults to the first configured bus
+	Bus *string `form:"bus,omitempty" json:"bus,omitempty"`
+
+	// Store Store the parameters in the node (0x1010) after the download
+	Store *bool `form:"store,omitempty" json:"store,omitempty"`
+}
+
+// GetNodeEDSParams defines parameters for GetNodeEDS.
+type GetNodeEDSParams struct {
+	// Bus CAN bus of the node, defaults to the first configured bus
+	Bus *string `form:"bus,omitempty" json:"bus,omitempty"`
+}
+
+// GetNodeODParams defines parameters for GetNodeOD.
+type GetNodeODParams struct {
+	// Bus CAN bus of the node, defaults to the first configured bus
+	Bus *string `form:"bus,omitempty" json:"bus,omitempty"`
+}
+
+// GetNodeParametersParams defines parameters for GetNodeParameters.
+type GetNodeParametersParams struct {
+	// Bus CAN bus of the node, defaults to the first configured bus
+	Bus *string `form:"bus,omitempty" json:"bus,omitempty"`
+}
+
+// PostNodeParametersRestoreParams defines parameters for PostNodeParametersRestore.
+type PostNodeParametersRestoreParams struct {
+	// Bus CAN bus of the node, defaults to the first configured bus
+	Bus *string `form:"bus,omitempty" json:"bus,omitempty"`
+
+	// Group Parameter group, defaults to all
+	Group *PostNodeParametersRestoreParamsGroup `form:"group,omitempty" json:"group,omitempty"`
+
+	// Reset NMT reset of the node after the command
+	Reset *PostNodeParametersRestoreParamsReset `form:"reset,omitempty" json:"reset,omitempty"`
+}
+
+// PostNodeParametersRestoreParamsGroup defines parameters for PostNodeParametersRestore.
+type PostNodeParametersRestoreParamsGroup string
+
+// PostNodeParametersRestoreParamsReset defines parameters for PostNodeParametersRestore.
+type PostNodeParametersRestoreParamsReset string
+
+// PostNodeParametersStoreParams defines parameters for PostNodeParametersStore.
+type PostNodeParametersStoreParams struct {
+	// Bus CAN bus of the node, defaults to the first configured bus
+	Bus *string `form:"bus,omitempty" json:"bus,omitempty"`
+
+	// Group Parameter group, defaults to all
+	Group *PostNodeParametersStoreParamsGroup `form:"group,omitempty" json:"group,omitempty"`
+
+	// Reset NMT reset of the node after the command
+	Reset *PostNodeParametersStoreParamsReset `form:"reset,omitempty" json:"reset,omitempty"`
+}
+
+// PostNodeParametersStoreParamsGroup defines parameters for PostNodeParametersStore.
+type PostNodeParametersStoreParamsGroup string
+
+// PostNodeParametersStoreParamsReset defines parameters for PostNodeParametersStore.
+type PostNodeParametersStoreParamsReset string
+
+// GetNodesParams defines parameters for GetNodes.
+type GetNodesParams struct {
+	// Bus CAN bus of the nodes, defaults to the first configured bus
+	Bus *string `form:"bus,omitempty" json:"bus,omitempty"`
+}
+
+// GetNodesEventsParams defines parameters for GetNodesEvents.
+type GetNodesEventsParams struct {
+	// Bus CAN bus of the nodes, all buses if not given
+	Bus *string `form:"bus,omitempty" json:"bus,omitempty"`
+}
+
+// GetNodesStatusParams defines parameters for GetNodesStatus.
+type GetNodesStatusParams struct {
+	// Bus CAN bus of the nodes, defaults to the first configured bus
+	Bus *string `form:"bus,omitempty" json:"bus,omitempty"`
+}
+
+// GetPDOParams defines parameters for GetPDO.
+type GetPDOParams struct {
+	// Node Node to query
+	Node string `form:"node" json:"node"`
+
+	// Bus CAN bus of the node, defaults to the first configured bus
+	Bus *string `form:"bus,omitempty" json:"bus,omitempty"`
+
+	// Direction rx for receive PDOs, tx for transmit PDOs
+	Direction GetPDOParamsDirection `form:"direction" json:"direction"`
+
+	// Number PDO number starting at 1
+	Number int `form:"number" json:"number"`
+}
+
+// GetPDOParamsDirection defines parameters for GetPDO.
+type GetPDOParamsDirection string
+
+// PutPDOParams defines parameters for PutPDO.
+type PutPDOParams struct {
+	// Node Node to query
+	Node string `form:"node" json:"node"`
+
+	// Bus CAN bus of the node, defaults to the first configured bus
+	Bus *string `form:"bus,omitempty" json:"bus,omitempty"`
+
+	// Direction rx for receive PDOs, tx for transmit PDOs
+	Direction PutPDOParamsDirection `form:"direction" json:"direction"`
+
+	// Number PDO number starting at 1
+	Number int `form:"number" json:"number"`
+}
+
+// PutPDOParamsDirection defines parameters for PutPDO.
+type PutPDOParamsDirection string
+
+// PostPDOTransmitJSONBody defines parameters for PostPDOTransmit.
+type PostPDOTransmitJSONBody = []PdoValue
+
+// PostPDOTransmitTextBody defines parameters for PostPDOTransmit.
+type PostPDOTransmitTextBody = string
+
+// PostPDOTransmitParams defines parameters for PostPDOTransmit.
+type PostPDOTransmitParams struct {
+	// Node Node to query
+	Node string `form:"node" json:"node"`
+
+	// Bus CAN bus of the node, defaults to the first configured bus
+	Bus *string `form:"bus,omitempty" json:"bus,omitempty"`
+
+	// Number PDO number starting at 1
+	Number int `form:"number" json:"number"`
+}
+
+// GetPDOValuesParams defines parameters for GetPDOValues.
+type GetPDOValuesParams struct {
+	// Node Node to query
+	Node string `form:"node" json:"node"`
+
+	// Bus CAN bus of the node, defaults to the first configured bus
+	Bus *string `form:"bus,omitempty" json:"bus,omitempty"`
+
+	// Number PDO number starting at 1
+	Number int `form:"number" json:"number"`
+}
+
+// GetPDOsParams defines parameters for GetPDOs.
+type GetPDOsParams struct {
+	// Node Node to query
+	Node string `form:"node" json:"node"`
+
+	// Bus CAN bus of the node, defaults to the first configured bus
+	Bus *string `form:"bus,omitempty" json:"bus,omitempty"`
+
+	// Direction rx for receive PDOs, tx for transmit PDOs
+	Direction GetPDOsParamsDirection `form:"direction" json:"direction"`
+}
+
+// GetPDOsParamsDirection defines parameters for GetPDOs.
+type GetPDOsParamsDirection string
+
+// ScanNodesParams defines parameters for ScanNodes.
+type ScanNodesParams struct {
+	// Bus CAN bus to scan, defaults to the first configured bus
+	Bus *string `form:"bus,omitempty" json:"bus,omitempty"`
+
+	// Timeout Time in ms to wait for SDO answers of a batch of nodes, defaults to 100
+	Timeout *int `form:"timeout,omitempty" json:"timeout,omitempty"`
+}
+
+// GetSDOParams defines parameters for GetSDO.
+type GetSDOParams struct {
+	// Node Node to query
+	Node string `form:"node" json:"node"`
+
+	// Bus CAN bus of the node, defaults to the first configured bus
+	Bus *string `form:"bus,omitempty" json:"bus,omitempty"`
+
+	// Index Index to query, required unless name is given
+	Index *string `form:"index,omitempty" json:"index,omitempty"`
+
+	// Subindex Subindex to query
+	Subindex *int `form:"subindex,omitempty" json:"subindex,omitempty"`
+
+	// Name Parameter name of the eds instead of index and subindex, compared without case, spaces and punctuation,
+	// e.g. ProducerHeartbeatTime or IdentityObject.VendorId for sub-indexes
+	Name *string `form:"name,omitempty" json:"name,omitempty"`
+}
+
+// PostSDOTextBody defines parameters for PostSDO.
+type PostSDOTextBody = string
+
+// PostSDOParams defines parameters for PostSDO.
+type PostSDOParams struct {
+	// Node Node to query
+	Node string `form:"node" json:"node"`
+
+	// Bus CAN bus of the node, defaults to the first configured bus
+	Bus *string `form:"bus,omitempty" json:"bus,omitempty"`
+
+	// Index Index to query, required unless name is given
+	Index *string `form:"index,omitempty" json:"index,omitempty"`
+
+	// Subindex Subindex to query
+	Subindex *int `form:"subindex,omitempty" json:"subindex,omitempty"`
+
+	// Name Parameter name of the eds instead of index and subindex, compared without case, spaces and punctuation,
+	// e.g. ProducerHeartbeatTime or IdentityObject.VendorId for sub-indexes
+	Name *string `form:"name,omitempty" json:"name,omitempty"`
+}
+
+// PostImageFlashJSONRequestBody defines body for PostImageFlash for application/json ContentType.
+type PostImageFlashJSONRequestBody = ImageFlashRequest
+
+// PostLSSSelectJSONRequestBody defines body for PostLSSSelect for application/json ContentType.
+type PostLSSSelectJSONRequestBody = LssIdentity
+
+// PostNMTJSONRequestBody defines body for PostNMT for application/json ContentType.
+type PostNMTJSONRequestBody = PostNMTJSONBody
+
+// PostNodeConfigurationJSONRequestBody defines body for PostNodeConfiguration for application/json ContentType.
+type PostNodeConfigurationJSONRequestBody = PostNodeConfigurationJSONBody
+
+// PutPDOJSONRequestBody defines body for PutPDO for application/json ContentType.
+type PutPDOJSONRequestBody = PdoConfig
+
+// PostPDOTransmitJSONRequestBody defines body for PostPDOTransmit for application/json ContentType.
+type PostPDOTransmitJSONRequestBody = PostPDOTransmitJSONBody
+
+// PostPDOTransmitTextRequestBody defines body for PostPDOTransmit for text/plain ContentType.
+type PostPDOTransmitTextRequestBody = PostPDOTransmitTextBody
+
+// PostSDOJSONRequestBody defines body for PostSDO for application/json ContentType.
+type PostSDOJSONRequestBody = SdoValue
+
+// PostSDOTextRequestBody defines body for PostSDO for text/plain ContentType.
+type PostSDOTextRequestBody = PostSDOTextBody
+
+// ServerInterface represents all server handlers.
+type ServerInterface interface {
+	// Lists configured CAN buses
+	// (GET /buses)
+	GetBuses(ctx echo.Context) error
+	// Validates eds
+	// (POST /eds/validate)
+	ValidateEds(ctx echo.Context) error
+	// Clears emergency history of node
+	// (DELETE /emcy)
+	DeleteEmcy(ctx echo.Context, params DeleteEmcyParams) error
+	// Reads emergency history of node
+	// (GET /emcy)
+	GetEmcy(ctx echo.Context, params GetEmcyParams) error
+	// Cancels FlashOrder
+	// (DELETE /flash)
+	DeleteFlash(ctx echo.Context, params DeleteFlashParams) error
+	// Gets information from FlashOrder
+	// (GET /flash)
+	GetFlash(ctx echo.Context, params GetFlashParams) error
+	// Flash updates node with binary
+	// (POST /flash)
+	PostFlash(ctx echo.Context, params PostFlashParams) error
+	// Streams flash order updates
+	// (GET /flash/events)
+	GetFlashEvents(ctx echo.Context, params GetFlashEventsParams) error
+	// Lists flash orders
+	// (GET /flash/orders)
+	GetFlashOrders(ctx echo.Context, params GetFlashOrdersParams) error
+	// Lists firmware images
+	// (GET /images)
+	GetImages(ctx echo.Context, params GetImagesParams) error
+	// Uploads firmware image
+	// (POST /images)
+	PostImage(ctx echo.Context, params PostImageParams) error
+	// Deletes firmware image
+	// (DELETE /images/{id})
+	DeleteImage(ctx echo.Context, id string) error
+	// Reads firmware image
+	// (GET /images/{id})
+	GetImage(ctx echo.Context, id string) error
+	// Flash updates nodes with firmware image
+	// (POST /images/{id}/flash)
+	PostImageFlash(ctx echo.Context, id string) error
+	// Configures bitrate of LSS slave
+	// (PUT /lss/bittiming)
+	PutLSSBitTiming(ctx echo.Context, params PutLSSBitTimingParams) error
+	// Identifies an unconfigured LSS slave
+	// (POST /lss/fastscan)
+	PostLSSFastscan(ctx echo.Context, params PostLSSFastscanParams) error
+	// Inquires identity of LSS slave
+	// (GET /lss/identity)
+	GetLSSIdentity(ctx echo.Context, params GetLSSIdentityParams) error
+	// Inquires node id of LSS slave
+	// (GET /lss/nodeid)
+	GetLSSNodeId(ctx echo.Context, params GetLSSNodeIdParams) error
+	// Configures node id of LSS slave
+	// (PUT /lss/nodeid)
+	PutLSSNodeId(ctx echo.Context, params PutLSSNodeIdParams) error
+	// Switches a single LSS slave to configuration state
+	// (POST /lss/select)
+	PostLSSSelect(ctx echo.Context, params PostLSSSelectParams) error
+	// Switches state of all LSS slaves
+	// (PUT /lss/state)
+	PutLSSState(ctx echo.Context, params PutLSSStateParams) error
+	// Stores configuration of LSS slave
+	// (POST /lss/store)
+	PostLSSStore(ctx echo.Context, params PostLSSStoreParams) error
+	// Reads nmt state from node
+	// (GET /nmt)
+	GetNMT(ctx echo.Context, params GetNMTParams) error
+	// Writes nmt state to node
+	// (POST /nmt)
+	PostNMT(ctx echo.Context, params PostNMTParams) error
+	// Deletes node
+	// (DELETE /node)
+	DeleteNode(ctx echo.Context, params DeleteNodeParams) error
+	// Reads node
+	// (GET /node)
+	GetNode(ctx echo.Context, params GetNodeParams) error
+	// Creates node with eds
+	// (POST /node)
+	PostNode(ctx echo.Context, params PostNodeParams) error
+	// Replaces eds of node
+	// (PUT /node)
+	PutNode(ctx echo.Context, params PutNodeParams) error
+	// Lists backups of node
+	// (GET /node/{node}/backup)
+	GetNodeBackups(ctx echo.Context, node string, params GetNodeBackupsParams) error
+	// Backs up parameters of node
+	// (POST /node/{node}/backup)
+	PostNodeBackup(ctx echo.Context, node string, params PostNodeBackupParams) error
+	// Deletes backup of node
+	// (DELETE /node/{node}/backup/{version})
+	DeleteNodeBackup(ctx echo.Context, node string, version int, params DeleteNodeBackupParams) error
+	// Reads backup of node
+	// (GET /node/{node}/backup/{version})
+	GetNodeBackup(ctx echo.Context, node string, version int, params GetNodeBackupParams) error
+	// Compares backup
+	// (GET /node/{node}/backup/{version}/diff)
+	GetNodeBackupDiff(ctx echo.Context, node string, version int, params GetNodeBackupDiffParams) error
+	// Restores backup to node
+	// (POST /node/{node}/backup/{version}/restore)
+	PostNodeBackupRestore(ctx echo.Context, node string, version int, params PostNodeBackupRestoreParams) error
+	// Downloads configuration to node
+	// (POST /node/{node}/configuration)
+	PostNodeConfiguration(ctx echo.Context, node string, params PostNodeConfigurationParams) error
+	// Downloads eds of node
+	// (GET /node/{node}/eds)
+	GetNodeEDS(ctx echo.Context, node string, params GetNodeEDSParams) error
+	// Reads object dictionary of node
+	// (GET /node/{node}/od)
+	GetNodeOD(ctx echo.Context, node string, params GetNodeODParams) error
+	// Reads store and restore capabilities of node
+	// (GET /node/{node}/parameters)
+	GetNodeParameters(ctx echo.Context, node string, params GetNodeParametersParams) error
+	// Restores default parameters of node
+	// (POST /node/{node}/parameters/restore)
+	PostNodeParametersRestore(ctx echo.Context, node string, params PostNodeParametersRestoreParams) error
+	// Stores parameters of node
+	// (POST /node/{node}/parameters/store)
+	PostNodeParametersStore(ctx echo.Context, node string, params PostNodeParametersStoreParams) error
+	// Lists nodes
+	// (GET /nodes)
+	GetNodes(ctx echo.Context, params GetNodesParams) error
+	// Streams liveness changes of nodes
+	// (GET /nodes/events)
+	GetNodesEvents(ctx echo.Context, params GetNodesEventsParams) error
+	// Reads liveness of all nodes
+	// (GET /nodes/status)
+	GetNodesStatus(ctx echo.Context, params GetNodesStatusParams) error
+	// Reads PDO configuration of node
+	// (GET /pdo)
+	GetPDO(ctx echo.Context, params GetPDOParams) error
+	// Writes PDO configuration to node
+	// (PUT /pdo)
+	PutPDO(ctx echo.Context, params PutPDOParams) error
+	// Transmits RPDO to node
+	// (POST /pdo/transmit)
+	PostPDOTransmit(ctx echo.Context, params PostPDOTransmitParams) error
+	// Reads latest TPDO values of node
+	// (GET /pdo/values)
+	GetPDOValues(ctx echo.Context, params GetPDOValuesParams) error
+	// Reads all PDO configurations of node
+	// (GET /pdos)
+	GetPDOs(ctx echo.Context, params GetPDOsParams) error
+	// Scans the bus for nodes
+	// (POST /scan)
+	ScanNodes(ctx echo.Context, params ScanNodesParams) error
+	// Reads sdo data from node
+	// (GET /sdo)
+	GetSDO(ctx echo.Context, params GetSDOParams) error
+	// Writes sdo data to node
+	// (POST /sdo)
+	PostSDO(ctx echo.Context, params PostSDOParams) error
+}
+
+// ServerInterfaceWrapper converts echo contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler ServerInterface
+}
+
+// GetBuses converts echo context to params.
+func (w *ServerInterfaceWrapper) GetBuses(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetBuses(ctx)
+	return err
+}
+
+// ValidateEds converts echo context to params.
+func (w *ServerInterfaceWrapper) ValidateEds(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.ValidateEds(ctx)
+	return err
+}
+
+// DeleteEmcy converts echo context to params.
+func (w *ServerInterfaceWrapper) DeleteEmcy(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DeleteEmcyParams
+	// ------------- Required query parameter "node" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "node", ctx.QueryParams(), &params.Node)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter node: %s", err))
+	}
+
+	// ------------- Optional query parameter "bus" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "bus", ctx.QueryParams(), &params.Bus)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter bus: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.DeleteEmcy(ctx, params)
+	return err
+}
+
+// GetEmcy converts echo context to params.
+func (w *ServerInterfaceWrapper) GetEmcy(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetEmcyParams
+	// ------------- Required query parameter "node" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "node", ctx.QueryParams(), &params.Node)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter node: %s", err))
+	}
+
+	// ------------- Optional query parameter "bus" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "bus", ctx.QueryParams(), &params.Bus)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter bus: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetEmcy(ctx, params)
+	return err
+}
+
+// DeleteFlash converts echo context to params.
+func (w *ServerInterfaceWrapper) DeleteFlash(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DeleteFlashParams
+	// ------------- Required query parameter "id" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "id", ctx.QueryParams(), &params.Id)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.DeleteFlash(ctx, params)
+	return err
+}
+
+// GetFlash converts echo context to params.
+func (w *ServerInterfaceWrapper) GetFlash(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetFlashParams
+	// ------------- Required query parameter "id" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "id", ctx.QueryParams(), &params.Id)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetFlash(ctx, params)
+	return err
+}
+
+// PostFlash converts echo context to params.
+func (w *ServerInterfaceWrapper) PostFlash(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostFlashParams
+	// ------------- Required query parameter "node" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "node", ctx.QueryParams(), &params.Node)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter node: %s", err))
+	}
+
+	// ------------- Optional query parameter "bus" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "bus", ctx.QueryParams(), &params.Bus)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter bus: %s", err))
+	}
+
+	// ------------- Optional query parameter "version" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "version", ctx.QueryParams(), &params.Version)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter version: %s", err))
+	}
+
+	// ------------- Optional query parameter "requester" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "requester", ctx.QueryParams(), &params.Requester)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter requester: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostFlash(ctx, params)
+	return err
+}
+
+// GetFlashEvents converts echo context to params.
+func (w *ServerInterfaceWrapper) GetFlashEvents(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetFlashEventsParams
+	// ------------- Optional query parameter "id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "id", ctx.QueryParams(), &params.Id)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetFlashEvents(ctx, params)
+	return err
+}
+
+// GetFlashOrders converts echo context to params.
+func (w *ServerInterfaceWrapper) GetFlashOrders(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetFlashOrdersParams
+	// ------------- Optional query parameter "bus" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "bus", ctx.QueryParams(), &params.Bus)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter bus: %s", err))
+	}
+
+	// ------------- Optional query parameter "node" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "node", ctx.QueryParams(), &params.Node)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter node: %s", err))
+	}
+
+	// ------------- Optional query parameter "state" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "state", ctx.QueryParams(), &params.State)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter state: %s", err))
+	}
+
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "from", ctx.QueryParams(), &params.From)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter from: %s", err))
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "to", ctx.QueryParams(), &params.To)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter to: %s", err))
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", ctx.QueryParams(), &params.Offset)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter offset: %s", err))
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetFlashOrders(ctx, params)
+	return err
+}
+
+// GetImages converts echo context to params.
+func (w *ServerInterfaceWrapper) GetImages(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetImagesParams
+	// ------------- Optional query parameter "productCode" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "productCode", ctx.QueryParams(), &params.ProductCode)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter productCode: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetImages(ctx, params)
+	return err
+}
+
+// PostImage converts echo context to params.
+func (w *ServerInterfaceWrapper) PostImage(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostImageParams
+	// ------------- Optional query parameter "productCode" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "productCode", ctx.QueryParams(), &params.ProductCode)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter productCode: %s", err))
+	}
+
+	// ------------- Optional query parameter "version" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "version", ctx.QueryParams(), &params.Version)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter version: %s", err))
+	}
+
+	// ------------- Optional query parameter "hash" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "hash", ctx.QueryParams(), &params.Hash)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter hash: %s", err))
+	}
+
+	// ------------- Optional query parameter "signature" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "signature", ctx.QueryParams(), &params.Signature)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter signature: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostImage(ctx, params)
+	return err
+}
+
+// DeleteImage converts echo context to params.
+func (w *ServerInterfaceWrapper) DeleteImage(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", ctx.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.DeleteImage(ctx, id)
+	return err
+}
+
+// GetImage converts echo context to params.
+func (w *ServerInterfaceWrapper) GetImage(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", ctx.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetImage(ctx, id)
+	return err
+}
+
+// PostImageFlash converts echo context to params.
+func (w *ServerInterfaceWrapper) PostImageFlash(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", ctx.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostImageFlash(ctx, id)
+	return err
+}
+
+// PutLSSBitTiming converts echo context to params.
+func (w *ServerInterfaceWrapper) PutLSSBitTiming(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PutLSSBitTimingParams
+	// ------------- Optional query parameter "bus" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "bus", ctx.QueryParams(), &params.Bus)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter bus: %s", err))
+	}
+
+	// ------------- Required query parameter "bitrate" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "bitrate", ctx.QueryParams(), &params.Bitrate)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter bitrate: %s", err))
+	}
+
+	// ------------- Optional query parameter "switchDelay" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "switchDelay", ctx.QueryParams(), &params.SwitchDelay)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter switchDelay: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PutLSSBitTiming(ctx, params)
+	return err
+}
+
+// PostLSSFastscan converts echo context to params.
+func (w *ServerInterfaceWrapper) PostLSSFastscan(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostLSSFastscanParams
+	// ------------- Optional query parameter "bus" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "bus", ctx.QueryParams(), &params.Bus)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter bus: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostLSSFastscan(ctx, params)
+	return err
+}
+
+// GetLSSIdentity converts echo context to params.
+func (w *ServerInterfaceWrapper) GetLSSIdentity(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetLSSIdentityParams
+	// ------------- Optional query parameter "bus" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "bus", ctx.QueryParams(), &params.Bus)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter bus: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetLSSIdentity(ctx, params)
+	return err
+}
+
+// GetLSSNodeId converts echo context to params.
+func (w *ServerInterfaceWrapper) GetLSSNodeId(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetLSSNodeIdParams
+	// ------------- Optional query parameter "bus" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "bus", ctx.QueryParams(), &params.Bus)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter bus: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetLSSNodeId(ctx, params)
+	return err
+}
+
+// PutLSSNodeId converts echo context to params.
+func (w *ServerInterfaceWrapper) PutLSSNodeId(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PutLSSNodeIdParams
+	// ------------- Optional query parameter "bus" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "bus", ctx.QueryParams(), &params.Bus)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter bus: %s", err))
+	}
+
+	// ------------- Required query parameter "node" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "node", ctx.QueryParams(), &params.Node)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter node: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PutLSSNodeId(ctx, params)
+	return err
+}
+
+// PostLSSSelect converts echo context to params.
+func (w *ServerInterfaceWrapper) PostLSSSelect(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostLSSSelectParams
+	// ------------- Optional query parameter "bus" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "bus", ctx.QueryParams(), &params.Bus)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter bus: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostLSSSelect(ctx, params)
+	return err
+}
+
+// PutLSSState converts echo context to params.
+func (w *ServerInterfaceWrapper) PutLSSState(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PutLSSStateParams
+	// ------------- Optional query parameter "bus" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "bus", ctx.QueryParams(), &params.Bus)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter bus: %s", err))
+	}
+
+	// ------------- Required query parameter "mode" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "mode", ctx.QueryParams(), &params.Mode)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter mode: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PutLSSState(ctx, params)
+	return err
+}
+
+// PostLSSStore converts echo context to params.
+func (w *ServerInterfaceWrapper) PostLSSStore(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostLSSStoreParams
+	// ------------- Optional query parameter "bus" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "bus", ctx.QueryParams(), &params.Bus)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter bus: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostLSSStore(ctx, params)
+	return err
+}
+
+// GetNMT converts echo context to params.
+func (w *ServerInterfaceWrapper) GetNMT(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetNMTParams
+	// ------------- Required query parameter "node" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "node", ctx.QueryParams(), &params.Node)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter node: %s", err))
+	}
+
+	// ------------- Optional query parameter "bus" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "bus", ctx.QueryParams(), &params.Bus)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter bus: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetNMT(ctx, params)
+	return err
+}
+
+// PostNMT converts echo context to params.
+func (w *ServerInterfaceWrapper) PostNMT(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostNMTParams
+	// ------------- Required query parameter "node" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "node", ctx.QueryParams(), &params.Node)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter node: %s", err))
+	}
+
+	// ------------- Optional query parameter "bus" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "bus", ctx.QueryParams(), &params.Bus)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter bus: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostNMT(ctx, params)
+	return err
+}
+
+// DeleteNode converts echo context to params.
+func (w *ServerInterfaceWrapper) DeleteNode(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DeleteNodeParams
+	// ------------- Required query parameter "node" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "node", ctx.QueryParams(), &params.Node)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter node: %s", err))
+	}
+
+	// ------------- Optional query parameter "bus" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "bus", ctx.QueryParams(), &params.Bus)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter bus: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.DeleteNode(ctx, params)
+	return err
+}
+
+// GetNode converts echo context to params.
+func (w *ServerInterfaceWrapper) GetNode(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetNodeParams
+	// ------------- Required query parameter "node" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "node", ctx.QueryParams(), &params.Node)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter node: %s", err))
+	}
+
+	// ------------- Optional query parameter "bus" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "bus", ctx.QueryParams(), &params.Bus)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter bus: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetNode(ctx, params)
+	return err
+}
+
+// PostNode converts echo context to params.
+func (w *ServerInterfaceWrapper) PostNode(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostNodeParams
+	// ------------- Required query parameter "node" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "node", ctx.QueryParams(), &params.Node)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter node: %s", err))
+	}
+
+	// ------------- Optional query parameter "bus" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "bus", ctx.QueryParams(), &params.Bus)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter bus: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostNode(ctx, params)
+	return err
+}
+
+// PutNode converts echo context to params.
+func (w *ServerInterfaceWrapper) PutNode(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PutNodeParams
+	// ------------- Required query parameter "node" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "node", ctx.QueryParams(), &params.Node)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter node: %s", err))
+	}
+
+	// ------------- Optional query parameter "bus" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "bus", ctx.QueryParams(), &params.Bus)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter bus: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PutNode(ctx, params)
+	return err
+}
+
+// GetNodeBackups converts echo context to params.
+func (w *ServerInterfaceWrapper) GetNodeBackups(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "node" -------------
+	var node string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "node", ctx.Param("node"), &node, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter node: %s", err))
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetNodeBackupsParams
+	// ------------- Optional query parameter "bus" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "bus", ctx.QueryParams(), &params.Bus)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter bus: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetNodeBackups(ctx, node, params)
+	return err
+}
+
+// PostNodeBackup converts echo context to params.
+func (w *ServerInterfaceWrapper) PostNodeBackup(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "node" -------------
+	var node string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "node", ctx.Param("node"), &node, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter node: %s", err))
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostNodeBackupParams
+	// ------------- Optional query parameter "bus" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "bus", ctx.QueryParams(), &params.Bus)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter bus: %s", err))
+	}
+
+	// ------------- Optional query parameter "comment" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "comment", ctx.QueryParams(), &params.Comment)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter comment: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostNodeBackup(ctx, node, params)
+	return err
+}
+
+// DeleteNodeBackup converts echo context to params.
+func (w *ServerInterfaceWrapper) DeleteNodeBackup(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "node" -------------
+	var node string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "node", ctx.Param("node"), &node, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter node: %s", err))
+	}
+
+	// ------------- Path parameter "version" -------------
+	var version int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "version", ctx.Param("version"), &version, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter version: %s", err))
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DeleteNodeBackupParams
+	// ------------- Optional query parameter "bus" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "bus", ctx.QueryParams(), &params.Bus)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter bus: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.DeleteNodeBackup(ctx, node, version, params)
+	return err
+}
+
+// GetNodeBackup converts echo context to params.
+func (w *ServerInterfaceWrapper) GetNodeBackup(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "node" -------------
+	var node string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "node", ctx.Param("node"), &node, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter node: %s", err))
+	}
+
+	// ------------- Path parameter "version" -------------
+	var version int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "version", ctx.Param("version"), &version, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter version: %s", err))
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetNodeBackupParams
+	// ------------- Optional query parameter "bus" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "bus", ctx.QueryParams(), &params.Bus)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter bus: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetNodeBackup(ctx, node, version, params)
+	return err
+}
+
+// GetNodeBackupDiff converts echo context to params.
+func (w *ServerInterfaceWrapper) GetNodeBackupDiff(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "node" -------------
+	var node string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "node", ctx.Param("node"), &node, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter node: %s", err))
+	}
+
+	// ------------- Path parameter "version" -------------
+	var version int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "version", ctx.Param("version"), &version, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter version: %s", err))
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetNodeBackupDiffParams
+	// ------------- Optional query parameter "bus" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "bus", ctx.QueryParams(), &params.Bus)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter bus: %s", err))
+	}
+
+	// ------------- Optional query parameter "against" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "against", ctx.QueryParams(), &params.Against)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter against: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetNodeBackupDiff(ctx, node, version, params)
+	return err
+}
+
+// PostNodeBackupRestore converts echo context to params.
+func (w *ServerInterfaceWrapper) PostNodeBackupRestore(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "node" -------------
+	var node string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "node", ctx.Param("node"), &node, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter node: %s", err))
+	}
+
+	// ------------- Path parameter "version" -------------
+	var version int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "version", ctx.Param("version"), &version, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter version: %s", err))
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostNodeBackupRestoreParams
+	// ------------- Optional query parameter "bus" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "bus", ctx.QueryParams(), &params.Bus)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter bus: %s", err))
+	}
+
+	// ------------- Optional query parameter "store" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "store", ctx.QueryParams(), &params.Store)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter store: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostNodeBackupRestore(ctx, node, version, params)
+	return err
+}
+
+// PostNodeConfiguration converts echo context to params.
+func (w *ServerInterfaceWrapper) PostNodeConfiguration(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "node" -------------
+	var node string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "node", ctx.Param("node"), &node, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter node: %s", err))
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostNodeConfigurationParams
+	// ------------- Optional query parameter "bus" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "bus", ctx.QueryParams(), &params.Bus)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter bus: %s", err))
+	}
+
+	// ------------- Optional query parameter "store" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "store", ctx.QueryParams(), &params.Store)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter store: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostNodeConfiguration(ctx, node, params)
+	return err
+}
+
+// GetNodeEDS converts echo context to params.
+func (w *ServerInterfaceWrapper) GetNodeEDS(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "node" -------------
+	var node string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "node", ctx.Param("node"), &node, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter node: %s", err))
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetNodeEDSParams
+	// ------------- Optional query parameter "bus" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "bus", ctx.QueryParams(), &params.Bus)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter bus: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetNodeEDS(ctx, node, params)
+	return err
+}
+
+// GetNodeOD converts echo context to params.
+func (w *ServerInterfaceWrapper) GetNodeOD(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "node" -------------
+	var node string
 
-	// Version Version that will be flashed
-	Version *string `form:"version,omitempty" json:"version,omitempty"`
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "node", ctx.Param("node"), &node, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter node: %s", err))
+	}
 
-// GetNMTParams defines parameters for GetNMT.
-type GetNMTParams struct {
-	// Node Node to query
-	Node string `form:"node" json:"node"`
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetNodeODParams
+	// ------------- Optional query parameter "bus" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "bus", ctx.QueryParams(), &params.Bus)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter bus: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetNodeOD(ctx, node, params)
+	return err
 }
 
-// PostNMTJSONBody defines parameters for PostNMT.
-type PostNMTJSONBody = string
+// GetNodeParameters converts echo context to params.
+func (w *ServerInterfaceWrapper) GetNodeParameters(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "node" -------------
+	var node string
 
-// PostNMTParams defines parameters for PostNMT.
-type PostNMTParams struct {
-	// Node Node to query
-	Node string `form:"node" json:"node"`
+	err = runtime.BindStyledParameterWithOptions("simple", "node", ctx.Param("node"), &node, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter node: %s", err))
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetNodeParametersParams
+	// ------------- Optional query parameter "bus" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "bus", ctx.QueryParams(), &params.Bus)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter bus: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetNodeParameters(ctx, node, params)
+	return err
 }
 
-// PostNodeParams defines parameters for PostNode.
-type PostNodeParams struct {
-	// Node Node to query
-	Node string `form:"node" json:"node"`
+// PostNodeParametersRestore converts echo context to params.
+func (w *ServerInterfaceWrapper) PostNodeParametersRestore(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "node" -------------
+	var node string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "node", ctx.Param("node"), &node, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter node: %s", err))
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostNodeParametersRestoreParams
+	// ------------- Optional query parameter "bus" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "bus", ctx.QueryParams(), &params.Bus)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter bus: %s", err))
+	}
+
+	// ------------- Optional query parameter "group" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "group", ctx.QueryParams(), &params.Group)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter group: %s", err))
+	}
+
+	// ------------- Optional query parameter "reset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "reset", ctx.QueryParams(), &params.Reset)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter reset: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostNodeParametersRestore(ctx, node, params)
+	return err
 }
 
-// GetSDOParams defines parameters for GetSDO.
-type GetSDOParams struct {
-	// Node Node to query
-	Node string `form:"node" json:"node"`
+// PostNodeParametersStore converts echo context to params.
+func (w *ServerInterfaceWrapper) PostNodeParametersStore(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "node" -------------
+	var node string
 
-	// Index Index to query
-	Index string `form:"index" json:"index"`
+	err = runtime.BindStyledParameterWithOptions("simple", "node", ctx.Param("node"), &node, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter node: %s", err))
+	}
 
-	// Subindex Subindex to query
-	Subindex *int `form:"subindex,omitempty" json:"subindex,omitempty"`
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostNodeParametersStoreParams
+	// ------------- Optional query parameter "bus" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "bus", ctx.QueryParams(), &params.Bus)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter bus: %s", err))
+	}
+
+	// ------------- Optional query parameter "group" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "group", ctx.QueryParams(), &params.Group)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter group: %s", err))
+	}
+
+	// ------------- Optional query parameter "reset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "reset", ctx.QueryParams(), &params.Reset)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter reset: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostNodeParametersStore(ctx, node, params)
+	return err
 }
 
-// PostSDOTextBody defines parameters for PostSDO.
-type PostSDOTextBody = string
+// GetNodes converts echo context to params.
+func (w *ServerInterfaceWrapper) GetNodes(ctx echo.Context) error {
+	var err error
 
-// PostSDOParams defines parameters for PostSDO.
-type PostSDOParams struct {
-	// Node Node to query
-	Node string `form:"node" json:"node"`
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetNodesParams
+	// ------------- Optional query parameter "bus" -------------
 
-	// Index Index to query
-	Index string `form:"index" json:"index"`
+	err = runtime.BindQueryParameter("form", true, false, "bus", ctx.QueryParams(), &params.Bus)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter bus: %s", err))
+	}
 
-	// Subindex Subindex to query
-	Subindex *int `form:"subindex,omitempty" json:"subindex,omitempty"`
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetNodes(ctx, params)
+	return err
 }
 
-// PostNMTJSONRequestBody defines body for PostNMT for application/json ContentType.
-type PostNMTJSONRequestBody = PostNMTJSONBody
+// GetNodesEvents converts echo context to params.
+func (w *ServerInterfaceWrapper) GetNodesEvents(ctx echo.Context) error {
+	var err error
 
-// PostSDOTextRequestBody defines body for PostSDO for text/plain ContentType.
-type PostSDOTextRequestBody = PostSDOTextBody
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetNodesEventsParams
+	// ------------- Optional query parameter "bus" -------------
 
-// ServerInterface represents all server handlers.
-type ServerInterface interface {
-	// Gets information from FlashOrder
-	// (GET /flash)
-	GetFlash(ctx echo.Context, params GetFlashParams) error
-	// Flash updates node with binary
-	// (POST /flash)
-	PostFlash(ctx echo.Context, params PostFlashParams) error
-	// Reads nmt state from node
-	// (GET /nmt)
-	GetNMT(ctx echo.Context, params GetNMTParams) error
-	// Writes nmt state to node
-	// (POST /nmt)
-	PostNMT(ctx echo.Context, params PostNMTParams) error
-	// Creates node with eds
-	// (POST /node)
-	PostNode(ctx echo.Context, params PostNodeParams) error
-	// Reads sdo data from node
-	// (GET /sdo)
-	GetSDO(ctx echo.Context, params GetSDOParams) error
-	// Writes sdo data to node
-	// (POST /sdo)
-	PostSDO(ctx echo.Context, params PostSDOParams) error
+	err = runtime.BindQueryParameter("form", true, false, "bus", ctx.QueryParams(), &params.Bus)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter bus: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetNodesEvents(ctx, params)
+	return err
 }
 
-// ServerInterfaceWrapper converts echo contexts to parameters.
-type ServerInterfaceWrapper struct {
-	Handler ServerInterface
+// GetNodesStatus converts echo context to params.
+func (w *ServerInterfaceWrapper) GetNodesStatus(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetNodesStatusParams
+	// ------------- Optional query parameter "bus" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "bus", ctx.QueryParams(), &params.Bus)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter bus: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetNodesStatus(ctx, params)
+	return err
 }
 
-// GetFlash converts echo context to params.
-func (w *ServerInterfaceWrapper) GetFlash(ctx echo.Context) error {
+// GetPDO converts echo context to params.
+func (w *ServerInterfaceWrapper) GetPDO(ctx echo.Context) error {
 	var err error
 
 	// Parameter object where we will unmarshal all parameters from the context
-	var params GetFlashParams
-	// ------------- Required query parameter "id" -------------
+	var params GetPDOParams
+	// ------------- Required query parameter "node" -------------
 
-	err = runtime.BindQueryParameter("form", true, true, "id", ctx.QueryParams(), &params.Id)
+	err = runtime.BindQueryParameter("form", true, true, "node", ctx.QueryParams(), &params.Node)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter node: %s", err))
+	}
+
+	// ------------- Optional query parameter "bus" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "bus", ctx.QueryParams(), &params.Bus)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter bus: %s", err))
+	}
+
+	// ------------- Required query parameter "direction" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "direction", ctx.QueryParams(), &params.Direction)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter direction: %s", err))
+	}
+
+	// ------------- Required query parameter "number" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "number", ctx.QueryParams(), &params.Number)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter number: %s", err))
 	}
 
 	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.GetFlash(ctx, params)
+	err = w.Handler.GetPDO(ctx, params)
 	return err
 }
 
-// PostFlash converts echo context to params.
-func (w *ServerInterfaceWrapper) PostFlash(ctx echo.Context) error {
+// PutPDO converts echo context to params.
+func (w *ServerInterfaceWrapper) PutPDO(ctx echo.Context) error {
 	var err error
 
 	// Parameter object where we will unmarshal all parameters from the context
-	var params PostFlashParams
+	var params PutPDOParams
 	// ------------- Required query parameter "node" -------------
 
 	err = runtime.BindQueryParameter("form", true, true, "node", ctx.QueryParams(), &params.Node)
@@ -148,24 +2340,38 @@ func (w *ServerInterfaceWrapper) PostFlash(ctx echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter node: %s", err))
 	}
 
-	// ------------- Optional query parameter "version" -------------
+	// ------------- Optional query parameter "bus" -------------
 
-	err = runtime.BindQueryParameter("form", true, false, "version", ctx.QueryParams(), &params.Version)
+	err = runtime.BindQueryParameter("form", true, false, "bus", ctx.QueryParams(), &params.Bus)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter version: %s", err))
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter bus: %s", err))
+	}
+
+	// ------------- Required query parameter "direction" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "direction", ctx.QueryParams(), &params.Direction)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter direction: %s", err))
+	}
+
+	// ------------- Required query parameter "number" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "number", ctx.QueryParams(), &params.Number)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter number: %s", err))
 	}
 
 	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.PostFlash(ctx, params)
+	err = w.Handler.PutPDO(ctx, params)
 	return err
 }
 
-// GetNMT converts echo context to params.
-func (w *ServerInterfaceWrapper) GetNMT(ctx echo.Context) error {
+// PostPDOTransmit converts echo context to params.
+func (w *ServerInterfaceWrapper) PostPDOTransmit(ctx echo.Context) error {
 	var err error
 
 	// Parameter object where we will unmarshal all parameters from the context
-	var params GetNMTParams
+	var params PostPDOTransmitParams
 	// ------------- Required query parameter "node" -------------
 
 	err = runtime.BindQueryParameter("form", true, true, "node", ctx.QueryParams(), &params.Node)
@@ -173,17 +2379,31 @@ func (w *ServerInterfaceWrapper) GetNMT(ctx echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter node: %s", err))
 	}
 
+	// ------------- Optional query parameter "bus" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "bus", ctx.QueryParams(), &params.Bus)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter bus: %s", err))
+	}
+
+	// ------------- Required query parameter "number" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "number", ctx.QueryParams(), &params.Number)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter number: %s", err))
+	}
+
 	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.GetNMT(ctx, params)
+	err = w.Handler.PostPDOTransmit(ctx, params)
 	return err
 }
 
-// PostNMT converts echo context to params.
-func (w *ServerInterfaceWrapper) PostNMT(ctx echo.Context) error {
+// GetPDOValues converts echo context to params.
+func (w *ServerInterfaceWrapper) GetPDOValues(ctx echo.Context) error {
 	var err error
 
 	// Parameter object where we will unmarshal all parameters from the context
-	var params PostNMTParams
+	var params GetPDOValuesParams
 	// ------------- Required query parameter "node" -------------
 
 	err = runtime.BindQueryParameter("form", true, true, "node", ctx.QueryParams(), &params.Node)
@@ -191,17 +2411,31 @@ func (w *ServerInterfaceWrapper) PostNMT(ctx echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter node: %s", err))
 	}
 
+	// ------------- Optional query parameter "bus" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "bus", ctx.QueryParams(), &params.Bus)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter bus: %s", err))
+	}
+
+	// ------------- Required query parameter "number" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "number", ctx.QueryParams(), &params.Number)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter number: %s", err))
+	}
+
 	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.PostNMT(ctx, params)
+	err = w.Handler.GetPDOValues(ctx, params)
 	return err
 }
 
-// PostNode converts echo context to params.
-func (w *ServerInterfaceWrapper) PostNode(ctx echo.Context) error {
+// GetPDOs converts echo context to params.
+func (w *ServerInterfaceWrapper) GetPDOs(ctx echo.Context) error {
 	var err error
 
 	// Parameter object where we will unmarshal all parameters from the context
-	var params PostNodeParams
+	var params GetPDOsParams
 	// ------------- Required query parameter "node" -------------
 
 	err = runtime.BindQueryParameter("form", true, true, "node", ctx.QueryParams(), &params.Node)
@@ -209,8 +2443,47 @@ func (w *ServerInterfaceWrapper) PostNode(ctx echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter node: %s", err))
 	}
 
+	// ------------- Optional query parameter "bus" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "bus", ctx.QueryParams(), &params.Bus)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter bus: %s", err))
+	}
+
+	// ------------- Required query parameter "direction" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "direction", ctx.QueryParams(), &params.Direction)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter direction: %s", err))
+	}
+
 	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.PostNode(ctx, params)
+	err = w.Handler.GetPDOs(ctx, params)
+	return err
+}
+
+// ScanNodes converts echo context to params.
+func (w *ServerInterfaceWrapper) ScanNodes(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ScanNodesParams
+	// ------------- Optional query parameter "bus" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "bus", ctx.QueryParams(), &params.Bus)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter bus: %s", err))
+	}
+
+	// ------------- Optional query parameter "timeout" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "timeout", ctx.QueryParams(), &params.Timeout)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter timeout: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.ScanNodes(ctx, params)
 	return err
 }
 
@@ -227,9 +2500,16 @@ func (w *ServerInterfaceWrapper) GetSDO(ctx echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter node: %s", err))
 	}
 
-	// ------------- Required query parameter "index" -------------
+	// ------------- Optional query parameter "bus" -------------
 
-	err = runtime.BindQueryParameter("form", true, true, "index", ctx.QueryParams(), &params.Index)
+	err = runtime.BindQueryParameter("form", true, false, "bus", ctx.QueryParams(), &params.Bus)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter bus: %s", err))
+	}
+
+	// ------------- Optional query parameter "index" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "index", ctx.QueryParams(), &params.Index)
 	if err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter index: %s", err))
 	}
@@ -241,6 +2521,13 @@ func (w *ServerInterfaceWrapper) GetSDO(ctx echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter subindex: %s", err))
 	}
 
+	// ------------- Optional query parameter "name" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "name", ctx.QueryParams(), &params.Name)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter name: %s", err))
+	}
+
 	// Invoke the callback with all the unmarshaled arguments
 	err = w.Handler.GetSDO(ctx, params)
 	return err
@@ -259,9 +2546,16 @@ func (w *ServerInterfaceWrapper) PostSDO(ctx echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter node: %s", err))
 	}
 
-	// ------------- Required query parameter "index" -------------
+	// ------------- Optional query parameter "bus" -------------
 
-	err = runtime.BindQueryParameter("form", true, true, "index", ctx.QueryParams(), &params.Index)
+	err = runtime.BindQueryParameter("form", true, false, "bus", ctx.QueryParams(), &params.Bus)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter bus: %s", err))
+	}
+
+	// ------------- Optional query parameter "index" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "index", ctx.QueryParams(), &params.Index)
 	if err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter index: %s", err))
 	}
@@ -273,6 +2567,13 @@ func (w *ServerInterfaceWrapper) PostSDO(ctx echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter subindex: %s", err))
 	}
 
+	// ------------- Optional query parameter "name" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "name", ctx.QueryParams(), &params.Name)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter name: %s", err))
+	}
+
 	// Invoke the callback with all the unmarshaled arguments
 	err = w.Handler.PostSDO(ctx, params)
 	return err
@@ -306,11 +2607,55 @@ func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL
 		Handler: si,
 	}
 
+	router.GET(baseURL+"/buses", wrapper.GetBuses)
+	router.POST(baseURL+"/eds/validate", wrapper.ValidateEds)
+	router.DELETE(baseURL+"/emcy", wrapper.DeleteEmcy)
+	router.GET(baseURL+"/emcy", wrapper.GetEmcy)
+	router.DELETE(baseURL+"/flash", wrapper.DeleteFlash)
 	router.GET(baseURL+"/flash", wrapper.GetFlash)
 	router.POST(baseURL+"/flash", wrapper.PostFlash)
+	router.GET(baseURL+"/flash/events", wrapper.GetFlashEvents)
+	router.GET(baseURL+"/flash/orders", wrapper.GetFlashOrders)
+	router.GET(baseURL+"/images", wrapper.GetImages)
+	router.POST(baseURL+"/images", wrapper.PostImage)
+	router.DELETE(baseURL+"/images/:id", wrapper.DeleteImage)
+	router.GET(baseURL+"/images/:id", wrapper.GetImage)
+	router.POST(baseURL+"/images/:id/flash", wrapper.PostImageFlash)
+	router.PUT(baseURL+"/lss/bittiming", wrapper.PutLSSBitTiming)
+	router.POST(baseURL+"/lss/fastscan", wrapper.PostLSSFastscan)
+	router.GET(baseURL+"/lss/identity", wrapper.GetLSSIdentity)
+	router.GET(baseURL+"/lss/nodeid", wrapper.GetLSSNodeId)
+	router.PUT(baseURL+"/lss/nodeid", wrapper.PutLSSNodeId)
+	router.POST(baseURL+"/lss/select", wrapper.PostLSSSelect)
+	router.PUT(baseURL+"/lss/state", wrapper.PutLSSState)
+	router.POST(baseURL+"/lss/store", wrapper.PostLSSStore)
 	router.GET(baseURL+"/nmt", wrapper.GetNMT)
 	router.POST(baseURL+"/nmt", wrapper.PostNMT)
+	router.DELETE(baseURL+"/node", wrapper.DeleteNode)
+	router.GET(baseURL+"/node", wrapper.GetNode)
 	router.POST(baseURL+"/node", wrapper.PostNode)
+	router.PUT(baseURL+"/node", wrapper.PutNode)
+	router.GET(baseURL+"/node/:node/backup", wrapper.GetNodeBackups)
+	router.POST(baseURL+"/node/:node/backup", wrapper.PostNodeBackup)
+	router.DELETE(baseURL+"/node/:node/backup/:version", wrapper.DeleteNodeBackup)
+	router.GET(baseURL+"/node/:node/backup/:version", wrapper.GetNodeBackup)
+	router.GET(baseURL+"/node/:node/backup/:version/diff", wrapper.GetNodeBackupDiff)
+	router.POST(baseURL+"/node/:node/backup/:version/restore", wrapper.PostNodeBackupRestore)
+	router.POST(baseURL+"/node/:node/configuration", wrapper.PostNodeConfiguration)
+	router.GET(baseURL+"/node/:node/eds", wrapper.GetNodeEDS)
+	router.GET(baseURL+"/node/:node/od", wrapper.GetNodeOD)
+	router.GET(baseURL+"/node/:node/parameters", wrapper.GetNodeParameters)
+	router.POST(baseURL+"/node/:node/parameters/restore", wrapper.PostNodeParametersRestore)
+	router.POST(baseURL+"/node/:node/parameters/store", wrapper.PostNodeParametersStore)
+	router.GET(baseURL+"/nodes", wrapper.GetNodes)
+	router.GET(baseURL+"/nodes/events", wrapper.GetNodesEvents)
+	router.GET(baseURL+"/nodes/status", wrapper.GetNodesStatus)
+	router.GET(baseURL+"/pdo", wrapper.GetPDO)
+	router.PUT(baseURL+"/pdo", wrapper.PutPDO)
+	router.POST(baseURL+"/pdo/transmit", wrapper.PostPDOTransmit)
+	router.GET(baseURL+"/pdo/values", wrapper.GetPDOValues)
+	router.GET(baseURL+"/pdos", wrapper.GetPDOs)
+	router.POST(baseURL+"/scan", wrapper.ScanNodes)
 	router.GET(baseURL+"/sdo", wrapper.GetSDO)
 	router.POST(baseURL+"/sdo", wrapper.PostSDO)
 
@@ -319,22 +2664,145 @@ func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+yY32/jRBDH/5XViAcQjp0WXsgLHL3eqRI06FLgoQrSxJ4kW9m7vt1x0qry/45mXYcm",
-	"cX60R48i3VuVnZ1f38/MprkHbaYWBveQWsOYsvxJBeocBsDxDXom91NpPZONM4I6gox86nTJ2hoYwIfz",
-	"0ZUakVvolNTUOqUNk8OUtZmppea5Gr0dKju5oZS9QpOp38sMmdo7EEGuUzKeJLLBgmAAb0pM56RO4z5E",
-	"UDnJZc5cDpJkuVzGGE5j62bJw1Wf/HJxdn45Ou+dxv14zkUuiTK5wg+nbaCVD7/E2YxcrG0STBKIgDXn",
-	"YnKGZliSUY/LgggW5HxTbz8+ifvi3ZZksNQwgO/CRxGUyHMvVSTTHP1c/ppRaOh6y94TeyVtdwXKR2rq",
-	"bKHeyZ2hy8hBcO7C2UXWXAinIYbDgpich8H1puOq0pmyU3VFnltPWg4+VuTuIGrbqzOIwNHHSjvKYMCu",
-	"ogh8OqcCJVu+K8XKs9NmBnU9FmNfWmm0nJ/2+y0wZEJ9WJa5TkPCyY2XXO4f+SudlMO6uU3OWdcRJoKp",
-	"NrppW9MaGICQ0mNdiAZb9lIBeZYSjr3iGR0/yZypqyUr2wZsqOutwXhP3Gg6Ck7qCL5v+rZudmEWmOtM",
-	"aVNWHDz7qijQ3R0HCuNMSICGuHEdgczqdphwSVVh9LwyNqNmOCfaYEBjnbjfrD8OuUvxxFa1hHXhJtGe",
-	"BFy0GeWPZvwUz5HVUue5mpAKJVO2I2g7sQfBDgz9bLO7PUzblIl7nh1hsc72iqNVIzeD1PXW+JxshGK6",
-	"5aTMUW8MzparTcbOHMkqfUTEczA7yMYmZHUEiSl454L7QJh5ZQpWYYAabB8o2Npsl79efRbISmQmJzf/",
-	"+rp/fTv+5sfrN713/d4P42+/6pDtU5feQe18labk/bTK1aonB+VjnKkF5hVtSLiv4616otjuBfGn00H9",
-	"lQu23ZLJanjNmh0zz0fIVXfr/4Ia7lFgXcIwfnIgHeoUs9kLj2eZMt+tZBPg/yvlv7yaO3QbPUfkrj27",
-	"S5WVutLDRl6f2QPb1WdWZci4f7mO3g5fo7rRdtsyuj2UhRajF01jVE30MZn4B7uubxfy/89MXuInPiGf",
-	"AnL0/K8QL/AMdaLZQi5kH3yFVh72PUJf2P6v2X7p/fwErD/vJt+N6Trm4Ra5RUtn81NGkqKxJRlHnhMs",
-	"dbI4AdGrOb2vXF5DBAt0Gid5U044kgynWOX8z08ZuU0xn1vPnT7relz/HQAA//+RN7E54REAAA==",
+	"H4sIAAAAAAAC/+x9aXPcuNngX0Fx82FcL9Vq+Uje6MuuLMkTbXkkl1pxKpX2btAkWo2YBBgA1BGX/vtb",
+	"zwOAV4Nstq3xpf4wY0kkcT73+SlKZF5IwYTR0eGnSDFdSKEZ/vJOyUXGcvgxkcIwYeBHWhQZT6jhUuwX",
+	"9o3/+peWAp7pZMVyCj/9QbFldBj9r/16/H37VO/7cR8eHuIoZTpRvIDhosPoDeUZS4li/y6ZNhNytWJE",
+	"G2pKTZYyy+StJmbFyEcuUiKX+DNTSqpD8nI6JVzc0IxXnxOpyA3NSkYU+xdLDEvJ4h6+mQshUxaTl9OX",
+	"pBQfhbwVZFHqmNg/s1THRC7gExhCqpQpePnPJJFimfHEkFtuVsS4xTG/FPi8/mIuXk2fk9nJBTGKCr1k",
+	"itCFVPUy3HSvpi/I8dE5rMBuBv70khieM1ma7tjws99fymiaccFwKti+YUrQzI4Swem6I4cbOZZiya9L",
+	"hTd3yQqp8DoLJQumDLdXvijxH3NfsOgw0kZxcR09xNES76XxCOa6ZgqewcrCT+wZ4ojcsFxvAozOEnWZ",
+	"GRjGDUyVovfwuzZSsVPcY2it+BjX2oatd1TRnBmmNLllihH7HuGiPl+6NMwecSpvRSZpGlXTL6TMGBUw",
+	"QymSFRXXfQdyq7gxTIQe1ruxZwOvh3a9djGsd7tcpOyu52ZozoLfFIrdcFnq9UN67/CFpmSpZF4fzYIt",
+	"pWLts3mII1Wtl4kyjw7/Ue2+eUwVAH2IA/dVLgb2YP8S2ANidt8G/BJZSoysNhE+/9NUn2ldsvVDz5nW",
+	"9Do8vWaJnbG7AC44cQ897nIYPiYsL8w9WTokvl3JjJElz1gUOhR2wxQ3982TtTAQR7dUCXht/TB7tvce",
+	"qCL1y23vEdc2HkWrwwrgJRLfxmFVGBNcVp7cr6+mdZSdX6PfGIV9twg/SRBvk0SqFJ4ZSY75EXkxPQid",
+	"Kn5y3Euv8PElu+baMBV+JaeiXNLElIqpE2poYJWNN4guWMKXPHGLXXKWpYRqsmJ3ofUBzdeG5gUMu5Qq",
+	"pyY6jFJq2B48ikZd+Buu8luq2FnuYLdz4QHS+Bd2R5iAo0zJ7C9He89f/bGCXRwlDtEQmZaJ6T9Nza8F",
+	"hWNYn++ioP8uGcmZoSk1lFzzGyZqploWgLsx/qyZuuEJYDTTREhDAC+W94SbINrw/wSmm/H/sNZ+gOgv",
+	"7g3TURxYuJ2epWMvIY5umNIOZMdcUEb16vTGyVObb6cseSXt4LcXKmUqtA4UPDahcD3EzFDDmkTWUxqU",
+	"aiK842vFtI4qQj+O5nSnGC1n9LO5JRdcr8ZfyZcdJELJX6herQ8ygCtLGLOXpOOYZ4FleYR1sImjsDRG",
+	"aNfMIMfAm9UWRTx8kgUXVN2H5uqXyaorHQMl7/zLyOlR6NwGLfwnPXLaRlytz3MYYbWhyoxflvYwOYTH",
+	"HanCPiDVIRAjyaK6q2hLrNDrGJHxnJseMXq51KzvWTXcKPbdi/s1FzfS0Gz9BM7LfMEU3IwDxZyaZIUs",
+	"d4VijGli0qDA2watdTndPfFQgBBLc4Kcwgt2MSkLuOSUFExxmfKEZtk9uV0BsNSKGdf2MxBK4V7irlgd",
+	"4uCn2vAcx9YskSLVpBSGZy3ZF0aG882YacCYwENCNGMqcQR+7Zly4Nee9eiGKUD/SllU1NRwD9t0ywlN",
+	"13Npn4NMfv6gDvUa19IjXYd5Kd7Sl8gzKMcgzFxa7OtlJ+21ep26oUDrmKRsScvMaL/yJVfakMTpYEBU",
+	"S91HUQOTnMOfYSx7vJVoV2Hj2kBdfGsRyc4OMs5EZQFweKcYyLq14op/JiuujVT36/ujadpEpgSHDB48",
+	"LITjrf/D7fZD4Dbean2WMmGcbtK+h40yIeidQEgtNQm/o5niNBt644aJVKqzdKyGDZfUI2/1CSL9DLTi",
+	"Hh1A+O2qbQzKqDZkxagyC0bNo0j764KaFGD/iZBHuJ9wEXtW8x4prsH5nImlHH88HT0tIK1l7LzP+gAP",
+	"3/dKzHGUyxR0JlRYT/pY9aCEA0B43m/8sM+7ANaUW3qgtCkpAAj2zuEe933fdwkzNHWOv4YKvK54zoKc",
+	"NC0ToA7+PbQqAuXIdUymhC+9/TNIvAGEZ4yJ8fDZfysOUgPWgcdDqdCxXqQX9ue1Q6VJwrS+ui8CUysZ",
+	"k1sZE3UL/yn43y1Ba4PQQVwGKeOqz1TlaPL7sMXqxD51pmqqibOgeQKP5uic3sPkhnJB/nB+cXJ6dhJa",
+	"xopfr952JMlRpsJM3vZ/12tHtMccPsL3R5cxObq8PPp7TC5Pjy8uT2JycvHb0dl5TE5O31z9/d0pnOjJ",
+	"6ZvZ1eVfj69C2ylS+RstCvgtDDjl4qK2MXdkn3KxhxtmyPyQ32pCReo4qI7icTJzBUEhU/SA7TIEjZUN",
+	"+pgWdMGzIBe9VrIsmlSeZlkUR4nM81I4uhjFTUdM1DZMBc2siqHBOyzCEPfUulcqGcLhHS6ISAESb05F",
+	"2Cg+NLqmN0xvOdRRaaSQuSx1dj9yWNr8JA7Dy+dd1wlfLtcvyjHZxk3VBm+apvivYrm86bF8g4W9z4Lt",
+	"jn5Bk49l8bl2/g2mdblhcmlWTLkleN+TtaP32uUHT3EmaKFXcgsxDKCkrUY1n5XCrO+goaha0uDpqDvK",
+	"EKNLFKNbWTb6+RxS8fEK+SyVli2E7OlrMtIwsKbSupICgCoXIcPTwQFZcIPOR44y/ZIHlfg4Srmq/R0e",
+	"2BXasO+CoM0EXbQ9hg00ZCCLg8gS0HlQTkcxRXk5RebAD1OQVtAkVhYFulGDK+VixRe8RyA6sw9rKajM",
+	"DC8yyyEOptNy29nymjuNuu53NUMLXLgY0HmUUUfgAO87U30vkpm3g3U44d/PjwnayJyMIZfk6t3Jxbab",
+	"RctAzjUAZUfW2QSYDS7eQXxu3jJxbVbhXTfFqo7URA0l8IEnVnAVLPV++8p5ydKgOj+CmnbICs0/c6qt",
+	"mY4nCevOgUdnAcNOVGat3C3vGsoHg2c/vCs9QJ3C97++vEuWMH7DUruQfnfaID65McLY9Bmq+ZaE/10v",
+	"4Q+eXh2Ks6biwQOSMkN5pskvl2+OyZ/+e/qnZyj0kmUroGbN/mk/g5/YHQVrJpCLZsSKXJLp3fPpdEp0",
+	"uSBTH8FySKZ30z9O4cGUWPG49tCxO65NZZ5yDzmyjx53RQWhXXKdsjsPY27mVkBNDwfQhookpA9Ts/LD",
+	"rR3M+qIqz3iHQyklldUknCfeKhiiij9iqSZSkTRZemfQF/vWIeKpyXuFNHtLWVoB2kYKRXGkU7mH5xTF",
+	"IFLt+YiBmzoAII58EFM4GCOVRzCAN+F1OMnJhb0H63YPhCZdU8Nu6X2NlDVc1RATDUx8xe4CLMz59Ene",
+	"DgGo19Ka6csBUleGmGrUl9OXQd9Tg76uKaD8swDYcJOxAZx0Aw3ZBzt+TyUOEypkwYRi2hy6sL3DeTmd",
+	"vkgAtPAnvES6kKU5XGRUfETHYzuoTLfOuW/cJhgO25nxqd9xdewhu/Msob3BUSmDEIE+kSSOVlSfpgFM",
+	"PhKIqlz7WLBlU8kJKZC18WltsCv3HQxnjV0Q5hewVjWG22jE/EJLeiovXUDnwHqp0BAPl+LOAdAcTdTB",
+	"JW+2zj+egXxbO//sK0pOYUzzdKqSkGLCJtcT8tfz2dmv56cnB38EY9jR2xfPY/L+bHb2+u3p/59dXZ6d",
+	"/4q2MDSP9QoXfbpuTNz9wBj2G29qQy51cXx1euWnsYSZ9OCjnWcd/x6Qr1oPAZohrUGV5Sg9RGbyL6oN",
+	"U/+nkNowObEmgo7Qdjq7IjMXzVNRFpqAX9bGNQDseZ0d1v1X9B36b+ZiLqxDTKM1FB3mVhx1sZOWC997",
+	"+PVRtitGU6bIP533cO/Ksst/epb175Kp+7kovJWC/NMNUL0ImiLPMu7cwRPUmJEcU2QimmjDs4yoUiB3",
+	"ul0xy2usnqlJWcyFVA0HHEm5TqQQdq+qYg5xizto2AIVpGK7EziEdoy0+9zjMB4kJUVHLFzI9L4jG6L8",
+	"3hfMPZmLM6NtnHWSUa3BNKDrsLu4XpMmCVXqvsOP8QK50aTDuSdRHGU8YY4kWeSLjgqarBh5PgHRoFQA",
+	"UitjisP9/dvb2wnFpxOprvfdp3r/7dnx6fnsdO/5ZDpZmTxDlGQq1xdLBy/1GPqWXl8zNeFyH1/ZrzjO",
+	"YXRMxUXBBGlCZ9QwvUTTycFkCqMDl6MFjw6jF/inOCqoWSF12V+ULmT+mgU4w1sO14R8hebOGp1lPuab",
+	"aYxx81HhXMPlJUzruOGflgLhqGGcdX5qIHJ4fUAlo1+ZeY1LiduR/M+n04Eo/vXo/bHe6/UAfl2iV2VZ",
+	"ZqRamo1EL/MchC1/HA2Pe3UQcC/0WgMhgt19gO/2War3nfxqCbvUIQFxxZKPQDa6wjdihCwNsnhATm4Q",
+	"NjNcBUAoW5fn1w7WRdCy09SeLaLea5neDxyrTAwze9ooRvP28VYKZV8A18PDwxfe4AY9oxERPPISY2/i",
+	"ADi0ag4ajWxwM+H27G3iQRy9HFzuo6eNnDnFi4uiNA2PW9+Q1dE2Bm2CqL9u7W06DipRXnNg6eKXU4Yh",
+	"QIHYDUaVJixn6pqJ5N5HaAD2O/GyDWInOBCGRcdRxYxg2qDvw0jLuCJgzNFh5H9xFNVNUfN2o0oWN066",
+	"oMYwBV/+v1+m/7j78Ox//+No7810788f/usPIbF9RHTN6OCa0ILtk3p93QV8CCNEV+UKUZ8fHhxHwJKH",
+	"UATLDw9xmBNdMlMq4fh4NZpLc9DE28TaqR8xkVnKtLG3aXOy/AI+MlZoJ80beOdgOq3Gm4T40w7AtwPw",
+	"7Xn2IOmH0/9sNv7DI9Ilo+lWeASUHiP7Bkk9FQnLNKGNoPIJOQLwLVnqAvS4Js4VXGMXvkCoIVIkDL7w",
+	"CoT/ZC6qjEGbfsWNJoLdGaINK2JCw8GxIEnWOgM5ws1BwpGuo2lvqZ4LbSQa7kEdw23C5NfMCUb+Vf8W",
+	"vaZcWMEeNqOZIwZ2tUyklasV9fy5mNtQX5LgAWUsnUeTHr6HL26iCz5+vxW7H8I1ng6ShsdGva0irgNJ",
+	"r/AKPq01R3dkOCPcVR15DrfehROnf+J9O0H9W2JqXGXVYs6T4HrF0uatPQJLdDjXAgWPvBZje7ngrwjg",
+	"wkrhcL6IkK2R1tjWVvB5xbT5ecDzV2ZIDaI/ARcYcf/rkBRWO/Ejl4qgHZkFC4xV7Cx5tD83rMxVnhuO",
+	"7pAYyGodVz8XTsBo0ffp3cGbV1NP3ReZTD7WXgEc9fjymCSgCgPVdxO7HBViJMmpuLfJ79qapexsyH/g",
+	"+T5mIFld2H+2uPd/3v/E0wfLDgkX2jCaTubiwoalyyVJ+XLJFBOG2BmoqhJkgDEA6mQZy0hZePGpITjZ",
+	"YRMpklIpYM4xmMz80PCyBg88HrEUPlWbCoxRmpAL+Ke2iRlJqLsOyo1LC+EGNu1WtM6I3kk9Ds0fRTz9",
+	"7uTPuC/nyawoFDzIsnbCU2gObzbbbqPrWQ6PleQQWmOdb7GR5n4DK89BZyrD7sx+kVHeIaVrQ3WJ5jGG",
+	"tHWY7g9OuIepbYBsV+L7Poac9ZtoZ3h/upE5hnSVw2MdE580Wc1tw4i1D5JtEHJNqDPoqj0NcG1nnswF",
+	"8AL8Bc3A3pzbDKOxT3/BBTQmlcrN9Syuw294pW9gxNxkLv4GR8FTtwfYjw360jXDsbTVtP1wNf9BEd4S",
+	"Vm60mzSeC29Chas0VSEWsGG7Ldf5cevujUmvOHVq72SkUNVO2sWUK1xI3FyIi2PDfPIh4etLhC1ESbyq",
+	"INpvlLZw2yF0wQfu6ho3LZeE0WTloKNz7z8BVnvca0pDDs8GUbpOfd3gdWlhp2C3lS0rdnmrVsqxHNai",
+	"BmADOu8c4NVhQmFQdlm9G0C5w9Ltiiz8WkfQCPDdmo2fNyJ1pF/mgMjyeBa02RqV6Zva1xtY21bD3b9W",
+	"Q8KnQ/qMbGowfsWV8OHaXiA6PV+8ePHnZz1zYxB+HOLbg3mqG5dTFcwZvRIjH2Eda3naRhL9kRc9c7rc",
+	"8u2O/jd6x/MyJyIwl0ITc1tEezXtmd0mvQ9O/nWUX/2EjKCWMDaJYg+ZtVrfGALbKmDRprEhknlmB95A",
+	"LW32ogsnqEq3WLVyUfIMC2NY6umejCCfzbiqzbSO7i17yd1Xsde3a/k8XcO9g9k2nDXA1v2h31Azs9lt",
+	"tDOG1y/tL4oVUnNQMSfkrAY2p13WdfvaZV+4mgt3rjFGAeNYlW3FWs1r6w/aWkC+QDu7WTXKIMll05Vm",
+	"RwjbKc5cVabxCOTG5i53H2xJ04P/bhVHaJwE100UQ4tSzdK8p+B3R7Fek0SnNtWjmSJO7wpbJ3K1oSAW",
+	"XrS9IKtd1bYvBwrWR2LLTvYscWVtTVusb+ZLanUW40CLaiI7lbWWrbAzq7hWVbR479Lq2l0/gpHkCySA",
+	"Nn1dJ2Qze7Lc099vSUeJVARghuRcY/WdRyCsf0UY7pLWEGWtJQK0Aw85RK1Lb53aNsmjI6eVj7TyabW0",
+	"Na7JR1YY8svL6Z+frVFCO88oWjiiwh3iAcT0Pbaj5knEqPg73wxIlVcuLBd+zzf5dWjOTx18sTWhqeMv",
+	"eiI/0dDdjr5oAQTyQLSgWQuPd0ll/GNVVEkuiZ1nQs5l7VZ3eeFzwZfen7SimghJGISBH3lqhiJA0RS3",
+	"bBJIy/lmZSxni+W69f6AlDfKJfX1kGIMu98OBNfrez08PHTX9aVCwONUx+tzszSDG3TbZ9UUsH9KF4wD",
+	"6XF4nWm9v+DG8Nxng5chZc1rRgtuVMOG+HY2IzqjN6i2Jc2y2dZsOyHoA9G33CSrE5ZBHmKW1Z9Vllcw",
+	"wtq3nAeRKzBa+Pni0OQ+s5HbnEJX6SOAuKV5O5u9xhIEruTgFjbheq1f19n7ur1Zn7XhiiVg7jcUdMDS",
+	"gdzs985rhxlDVgbsi0eJ4TcIYO4e3BrgSOBSsS6E10ZBo7FGX3uj/YppAy4+y+b4JLi0r0bPdBMBKrBs",
+	"IHamm1i9pNrohIp+Rg1j+LfiuuCIxnCOUjTgusZzuFx7bUwTgAMZwvsg83w7m73xa/oucfD3lDGbVRp/",
+	"IAnz5fRlqNRTH3TYBPgvh/mzGhap6JmsF/B5oxpmT9A9yL02l5XwNG4JfjHxibwW1jG7tuFYGcP2Qib2",
+	"t7NZBQA76H8SlPtMIMfVtY13FOEWMmU83QC9cPkUuLLPcU/HAmdMnr96ZQtN1nDTA7FYhzT9OeF1Tch4",
+	"cmDZAJx+qIw3aARbQt+EnBmyYInMmfYAXEVDYSIFlCXAXAbSqq9IpIrRcNBlCNaGgHH6TjBB5R7CTEFK",
+	"trP2KAXfM3yvBxKwW3/aMXnzhuRUfWzcAdVdjP4aWVo7ybwcjUqewGuW+fq3YdeoVYQRcol9md+wuJa7",
+	"24hWoU/FZn6xvsRnW0rnM7uu75faP76pqyOYPDxhaJ556KJEc3GdNUGsF456ALzqMVNuAu/rTC5o1oDt",
+	"jn2oQcmlCi0Cjb5rDMGX3ej+maetEFzMRAgzjj6WMXM7/z45RuizfBPJrxrFUd+To3XOoZr1H3aIouug",
+	"7TbMDqCFK8gcJvu/0Y9MjxKibHgBhoQHABzU1trsF6b0uJIfSax/GpBlY6LaN75RpBC52aAsitxnGGBi",
+	"XbDaxa/MnP92tSsE8Ptpmv2pQd8ZOBt6besSP5qPOQyAHpQBgPsDBf+muGHNIYwMQzDQth0IP7r4PLYS",
+	"0pMB6QGAbEM0EueqbGs4MusSS1DoqpZTuO5lKMrq3D7awfou6Gs96KsLj7ZM1oYiRFidDdPesXxrKyNf",
+	"LrcCUZAmdvD59R0tVeOwpxbF1gfwwyFqtakAezp5CLfFw7FINRgefBFhxYqMJiwNSx47cP++YtJ/EKIf",
+	"u8KXVVt6zJpqlHZ0Newfw14dgvog0oTMdkdG5q6pq0MEPYQwccM7BKjjsleqOnbs1s2+ZmTbYdIOk75z",
+	"TLr0COCAP8x9vPy//wn+/7Dv+l0dfhoWw+rWWC3Qqurm2r4m7SKQfQLYazvOZ6FTOzR5J4Z9TgDzeqe1",
+	"p54y2oDsDtbYJwNSm5X0wNiuGE0h+jUmQoo97AFKhalq1TeUlVbhVBvRVbVVzAnVNsoYZyZ1hmJYvHvt",
+	"+9XtcGnjAmybwLUCa1XLv9Asvrfgt1KeAsj6ZJATYFuTsiA1bA+haJi17X9yKNRJRuwzX+0Qauvab+0u",
+	"pMHDqMnYFiH/O6OZZ03DnGlQcqONIVpim5PZhqW0HR58v3iw4y+PYqXbjGGbGMt+6ho/b6iA40XBFMtA",
+	"gFFiwcwtY83OwzZTSYSaKlt8RTmzLUIOozB2pd6h8bdH43ET2ri2vKDKkumuzYpiSxXXkrdn61hNRv/O",
+	"NbO20zURCp+unnlsb1TXULU9lWn05h+MSgB4uVXcpmJ21E8HYzUFauui8NijWn88Q0VaLt2KdtTlO6Qu",
+	"GLiFc9WX47PO8bJtRPb0WVUNkRFVXWi4AKMM1/qpOj/+rqLKcTME7ZIVUpnxfalcJ1+PDkBdwTJbF49W",
+	"bsCfQKpx1qSan4wVbNohtmPoTEXfbTPwX6A/pV0+/uGZj0QN0SOK7dca1MaVJ5alsa0IG43sKsR1xhuW",
+	"2jusi+HHhJL/O7s4t59aq7uVmOZC3jCluG1OPjjmhFyI7D4gqHVMdspSWMNEPBfQJN8uhmvYY+oqyduK",
+	"AQ3kA9Eutz3u8X1fHWDeb95rwfxYOlsRsKdKaz+H9HlI+kLa93nxbKNkqdlQM/gfoqfgU6Dg/R4/oHaP",
+	"6vE7cSDbjc5ep/g9zj+W6lGeP3RaAEnbIszq9GS2kwu/1FfwRZj8RFSrGgu2cnzLdBToB9x3ASSwZZxs",
+	"sULbDrwHKy5OdkjxjdzeF+lF1QP+SXc2tCBNUo5tyWmws2EP0jQhdzC7B+VlK2Sh2Is/HoBgnKxqoYxc",
+	"K1kWjXxxp7m4xin4Sx8mvauXssOobxxIckwLuuAZJiw/ceRCoG0CMEn84XAWYk8N0B3Ct83Wv0rv76q4",
+	"tItwTv05eNawLeNR+xCUdvELxWzzh7CGWuPhzho4XkN9176R9uQ0y3rmwZejUJ60/aZVnSRqaYVRHOVU",
+	"lEuamFIxFUqgXq/uUZU9aRxUQ2eG6ahIe3uodZuG+MW6+22v9nMzumc/Kzlx6OzGGQ6CGUtFNtCQGdY3",
+	"WCcYle1E7N3IjBrQBXOWu67Ia2aVBl1ZUQRpXRaFVIZoeuOtcHZkKRpQtInCzHb0ZUdfdvTlMfP6t6cq",
+	"m3r9gLkf37OyB6AByhMNy31TW077BPxtG6XhnD++UF1nyz3xoGzhYKBHJdVju3Xiy+RaAt+RIuOCEamI",
+	"XC7xR5DUrR9GV516sNyba/EXatNZd7Kci0ArSzK6h6ddTlwtRio77Z5dUaOT51xgS0c0rGInz16sGdcv",
+	"M4w7j9pj8HftkVkdxJe1yGwM89N0yMzgzpjWTbDeiEsAduVmZ4DDH1ezs4EmnU6YGdWGrBhVZsGo8U7g",
+	"Dlewzf8X944l1O9P5uKoinjyuIE1sYRsjEqV4jcukN8JqHollWGW3usyZ41BXXtFlFD/+KzBEOZiKRXh",
+	"Blbi2jfUBeDjKsOpGtFNdzB5hUNqLHlkq/X2zfenZ/34OrMn/2R5ndv/U7cbVVjbxJUwxhap3GB9bRcp",
+	"bYYdtMU9St6dXMT4sONZTZltE1JZgpxvZcjr9+7kYpciO0Y5U3foPFIsYVBqFuJHYmLsH7GjeM4N/rVn",
+	"wpQrlphNEWJeGVLgjDJ3oxSydycXvqw4liTESBVDDvquCl8dXEVuu8BGh68OnsdRzoX97SD+ymHuqbTB",
+	"B0+OsMCVrhVv6+p5qezPsXfRXlvRFCsDw9Rcd8Ki5gIipxCwsJm8fYQ6/y1VKYqeVX/kCTnxsI6vO9gE",
+	"CmXoRybquCyEzGB9zB1Z2pGlLyZLj1/lt0WRHnak71FJn6NZ67TPyDDtc3LVvofzfhP5lXtDE9pElSba",
+	"TmwgqsvXAWJVUGyNS5NEKhuGatEZPnRkNJ6LjhzWqq2Aw6ANgmryH6bkhLzm1nmvsBEtqrhc23dK0d95",
+	"SmogiX4bO9J4+OnnJUDj3PhfI640ttaeIqP8UWo5/qRW+Zq6XALMbSJXlsSMCmRz/cIdyUorkxht8fZK",
+	"8SuYauuFk7loyHQYeStscfJKBrMY6GCVSDHp0RLf+8TjHeV50hqZg4MnZ+qxmHgFV+tkBLkcxHP9WPae",
+	"LEMBHn8mlbwOAM1FHUg/ztazQ+AfS6v6OiGBtbLxxA26Dtfa+scwpg93xoTJWKu/lSYHewfP/wSOC8Vo",
+	"I70NK+XC6Vv/wxQSnUQa6MUDf6WpK1Wq58KsqCGaibT2Y2iyKA1JJfoDqdC3TJHZyYVn83qCTrRaW+kk",
+	"6C0zen1tXf0rqk9T7eQI2Cu2NdFk2ejr70f1mAmX7dpI6Nj+QxYM3vQ9s2ESWJp7k4p0LmwmkJu19kKJ",
+	"LGAhmiVUbBVuYCSxzUm/JhW5AmeS7Wjr2sHgscFF2DvxfjVqklXl8Wuv8WA67VkOuKpk+T3UC4DbuGQa",
+	"sO/pEhA4BF21oIZ7HvAG6Y3eIJ1KK+0PNtqY7cyk41DxDPJ7qmOIid8qKUXGtK5iPoZiJ3yO0OMdy6xc",
+	"8NbCembW5WJ98s3FBOp4QtxeIxuKC20YxS5zdgHAU/wssS9lktZ+dKpZTHSBpUrh3aIUiSltkudcsMn1",
+	"hLxz3vS/eNqN5E8q4vui2UyeyXtsl3tmc7F0udjDSVnfVeM/36qQX529+22sKuOSbLv7c/YAXRcgql3C",
+	"SFNaUU2pjueib28uIKeZVgf8GVjYIpPJRysNL12EBTm+PCbJiiUff6qmM0Fa7Km69m64oaoL1QhDLWd2",
+	"xHxHzHfE/Hfx231vdHyNYjOxFcV24fZIatG4iGwB34rnov5CgT8J7zjjYKC2Ln58xDWRuBya4Qsu5yNH",
+	"XcRNMxlgDFw73mDtyXU1qhHcIZ4LnYOuXUUvCacuVkDa+b5RvIVodp0zAYEG0VP2OvSzljZrwq8wHNpy",
+	"lFJl0WG0n1AhCyYU02afFnz/5iB6iP3TT6XKHqI4uqGKQ6wHHi4++lQvO1oZUxzu72cyodlKahMc8+Hh",
+	"w8P/DAAhzl8CjOYAAA==",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
@@ -1,21 +1,23 @@
 package canopenrest
 
 import (
-	"bytes"
+	"context"
 	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
-	"os"
-	"path"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jaster-prj/canopenrest/common"
 	"github.com/jaster-prj/canopenrest/entities"
 	apicanopenrest "github.com/jaster-prj/canopenrest/external/echoserver/generated/canopenrest"
 	"github.com/jaster-prj/canopenrest/external/echoserver/implementation"
+	"github.com/jaster-prj/canopenrest/usecases/canopenuc"
 
 	log "github.com/rs/zerolog/log"
 
@@ -23,12 +25,264 @@ import (
 	middleware "github.com/oapi-codegen/echo-middleware"
 )
 
+// FLASH_EVENTS_KEEPALIVE is the interval of comments keeping idle event streams open
+const FLASH_EVENTS_KEEPALIVE = 15 * time.Second
+
+// FLASH_ORDERS_DEFAULT_LIMIT is the page size of the flash order list if the client sets none
+const FLASH_ORDERS_DEFAULT_LIMIT = 50
+
+const (
+	PROBLEM_CONTENT_TYPE = "application/problem+json"
+	// PROBLEM_TYPE_PREFIX is followed by the error kind in the type of problem details
+	PROBLEM_TYPE_PREFIX = "urn:canopenrest:problem:"
+)
+
 type FlashOrderState struct {
+	Id        uuid.UUID           `json:"id"`
+	Bus       string              `json:"bus,omitempty"`
+	Node      int                 `json:"node"`
+	Version   *string             `json:"version,omitempty"`
+	ImageHash string              `json:"imageHash,omitempty"`
+	ImageId   string              `json:"imageId,omitempty"`
+	Size      int64               `json:"size"`
+	Requester string              `json:"requester,omitempty"`
 	Requested time.Time           `json:"requested"`
 	Start     *time.Time          `json:"start,omitempty"`
 	Finish    *time.Time          `json:"finish,omitempty"`
 	State     entities.FlashState `json:"state"`
 	Error     *string             `json:"error,omitempty"`
+	Progress  *FlashProgress      `json:"progress,omitempty"`
+}
+
+type FlashOrders struct {
+	Total  int               `json:"total"`
+	Offset int               `json:"offset"`
+	Limit  int               `json:"limit"`
+	Orders []FlashOrderState `json:"orders"`
+}
+
+type FlashEvent struct {
+	Id    uuid.UUID       `json:"id"`
+	Type  string          `json:"type"`
+	Order FlashOrderState `json:"order"`
+}
+
+type FlashProgress struct {
+	Transferred int64     `json:"transferred"`
+	Total       int64     `json:"total"`
+	Percent     float64   `json:"percent"`
+	Rate        float64   `json:"rate"`
+	Eta         float64   `json:"eta"`
+	Updated     time.Time `json:"updated"`
+}
+
+type FirmwareImage struct {
+	Id          string    `json:"id"`
+	ProductCode *uint32   `json:"productCode,omitempty"`
+	Version     *string   `json:"version,omitempty"`
+	Signature   *string   `json:"signature,omitempty"`
+	Size        int64     `json:"size"`
+	Uploaded    time.Time `json:"uploaded"`
+}
+
+type ImageFlashRequest struct {
+	Bus       string   `json:"bus"`
+	Nodes     []string `json:"nodes"`
+	Requester *string  `json:"requester"`
+}
+
+type Emcy struct {
+	Timestamp        time.Time `json:"timestamp"`
+	ErrorCode        uint16    `json:"errorCode"`
+	ErrorRegister    uint8     `json:"errorRegister"`
+	ManufacturerData string    `json:"manufacturerData"`
+	Description      string    `json:"description"`
+}
+
+type LssIdentity struct {
+	VendorId       uint32 `json:"vendorId"`
+	ProductCode    uint32 `json:"productCode"`
+	RevisionNumber uint32 `json:"revisionNumber"`
+	SerialNumber   uint32 `json:"serialNumber"`
+}
+
+type ConfigurationResult struct {
+	Index    uint16 `json:"index"`
+	Subindex uint8  `json:"subindex"`
+	Name     string `json:"name,omitempty"`
+	Type     string `json:"type"`
+	Value    any    `json:"value"`
+	Previous any    `json:"previous,omitempty"`
+	Result   string `json:"result"`
+	Error    string `json:"error,omitempty"`
+}
+
+type ConfigurationReport struct {
+	Bus        string                `json:"bus"`
+	Node       int                   `json:"node"`
+	Written    int                   `json:"written"`
+	Unchanged  int                   `json:"unchanged"`
+	Failed     int                   `json:"failed"`
+	Stored     bool                  `json:"stored"`
+	StoreError string                `json:"storeError,omitempty"`
+	Objects    []ConfigurationResult `json:"objects"`
+}
+
+type ParameterCapability struct {
+	Group             string `json:"group"`
+	Subindex          uint8  `json:"subindex"`
+	Store             bool   `json:"store"`
+	StoreAutonomously bool   `json:"storeAutonomously"`
+	Restore           bool   `json:"restore"`
+}
+
+type ParameterSnapshot struct {
+	Bus     string     `json:"bus"`
+	Node    int        `json:"node"`
+	Version int        `json:"version"`
+	Created time.Time  `json:"created"`
+	Comment string     `json:"comment,omitempty"`
+	Count   int        `json:"count"`
+	Values  []SdoValue `json:"values,omitempty"`
+}
+
+type ParameterDiff struct {
+	Index    uint16 `json:"index"`
+	Subindex uint8  `json:"subindex"`
+	Name     string `json:"name,omitempty"`
+	Type     string `json:"type"`
+	Change   string `json:"change"`
+	From     any    `json:"from,omitempty"`
+	To       any    `json:"to,omitempty"`
+}
+
+type EdsIssue struct {
+	Severity string `json:"severity"`
+	Section  string `json:"section,omitempty"`
+	Message  string `json:"message"`
+}
+
+type EdsValidation struct {
+	Valid  bool       `json:"valid"`
+	Issues []EdsIssue `json:"issues"`
+}
+
+type NodeInfo struct {
+	Bus              string `json:"bus"`
+	Node             int    `json:"node"`
+	FileName         string `json:"fileName,omitempty"`
+	FileVersion      string `json:"fileVersion,omitempty"`
+	Description      string `json:"description,omitempty"`
+	ModificationDate string `json:"modificationDate,omitempty"`
+	VendorName       string `json:"vendorName,omitempty"`
+	VendorNumber     string `json:"vendorNumber,omitempty"`
+	ProductName      string `json:"productName,omitempty"`
+	ProductNumber    string `json:"productNumber,omitempty"`
+	RevisionNumber   string `json:"revisionNumber,omitempty"`
+}
+
+type NodeStatus struct {
+	Bus           string     `json:"bus"`
+	Node          int        `json:"node"`
+	Online        bool       `json:"online"`
+	State         string     `json:"state,omitempty"`
+	LastSeen      *time.Time `json:"lastSeen,omitempty"`
+	HeartbeatTime int64      `json:"heartbeatTime"`
+}
+
+type NodeEvent struct {
+	Bus       string    `json:"bus"`
+	Node      int       `json:"node"`
+	Type      string    `json:"type"`
+	State     string    `json:"state"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+type SdoValue struct {
+	Index    uint16 `json:"index"`
+	Subindex uint8  `json:"subindex"`
+	Name     string `json:"name,omitempty"`
+	Type     string `json:"type"`
+	Value    any    `json:"value"`
+}
+
+type ScanResult struct {
+	Node           int     `json:"node"`
+	SdoResponse    bool    `json:"sdoResponse"`
+	Heartbeat      bool    `json:"heartbeat"`
+	State          string  `json:"state,omitempty"`
+	DeviceType     *uint32 `json:"deviceType,omitempty"`
+	VendorId       *uint32 `json:"vendorId,omitempty"`
+	ProductCode    *uint32 `json:"productCode,omitempty"`
+	RevisionNumber *uint32 `json:"revisionNumber,omitempty"`
+	SerialNumber   *uint32 `json:"serialNumber,omitempty"`
+	HasEds         bool    `json:"hasEds"`
+}
+
+type OdObject struct {
+	Index        uint16     `json:"index"`
+	Subindex     uint8      `json:"subindex"`
+	Name         string     `json:"name"`
+	ObjectType   string     `json:"objectType"`
+	DataType     string     `json:"dataType,omitempty"`
+	AccessType   string     `json:"accessType,omitempty"`
+	DefaultValue string     `json:"defaultValue,omitempty"`
+	PdoMapping   bool       `json:"pdoMapping"`
+	LowLimit     string     `json:"lowLimit,omitempty"`
+	HighLimit    string     `json:"highLimit,omitempty"`
+	SubObjects   []OdObject `json:"subObjects,omitempty"`
+}
+
+type PdoConfig struct {
+	Direction        entities.PdoDirection `json:"direction"`
+	Number           int                   `json:"number"`
+	CobId            uint32                `json:"cobId"`
+	Enabled          bool                  `json:"enabled"`
+	RtrAllowed       bool                  `json:"rtrAllowed"`
+	TransmissionType uint8                 `json:"transmissionType"`
+	InhibitTime      *uint16               `json:"inhibitTime,omitempty"`
+	EventTimer       *uint16               `json:"eventTimer,omitempty"`
+	SyncStart        *uint8                `json:"syncStart,omitempty"`
+	Mapping          []PdoMapping          `json:"mapping"`
+}
+
+type PdoMapping struct {
+	Index     uint16 `json:"index"`
+	Subindex  uint8  `json:"subindex"`
+	BitLength uint8  `json:"bitLength"`
+	Name      string `json:"name,omitempty"`
+	DataType  string `json:"dataType,omitempty"`
+}
+
+type PdoValues struct {
+	Number    int        `json:"number"`
+	CobId     uint32     `json:"cobId"`
+	Received  bool       `json:"received"`
+	Timestamp *time.Time `json:"timestamp,omitempty"`
+	Data      string     `json:"data,omitempty"`
+	Values    []PdoValue `json:"values"`
+}
+
+type PdoValue struct {
+	Index    uint16 `json:"index"`
+	Subindex uint8  `json:"subindex"`
+	Name     string `json:"name,omitempty"`
+	Value    any    `json:"value"`
+}
+
+// Problem is the problem details body (RFC 7807) of failed requests
+type Problem struct {
+	Type         string     `json:"type"`
+	Title        string     `json:"title"`
+	Status       int        `json:"status"`
+	Detail       string     `json:"detail,omitempty"`
+	Instance     string     `json:"instance,omitempty"`
+	Kind         string     `json:"kind,omitempty"`
+	SdoAbortCode string     `json:"sdoAbortCode,omitempty"`
+	SdoAbortText string     `json:"sdoAbortText,omitempty"`
+	Index        *uint16    `json:"index,omitempty"`
+	Subindex     *uint8     `json:"subindex,omitempty"`
+	Issues       []EdsIssue `json:"issues,omitempty"`
 }
 
 // EndpointRegisterer handle Registration of jobs Endpoint to the echo server
@@ -94,17 +348,20 @@ type Handler struct {
 	canopenUC implementation.ICanopenRest
 }
 
+// GetBuses handles the GET request for the configured buses
+func (h *Handler) GetBuses(ctx echo.Context) error {
+	return ctx.JSON(http.StatusOK, h.canopenUC.GetBuses())
+}
+
 // GetNMT handles the GET request for the NMT
 func (h *Handler) GetNMT(ctx echo.Context, params apicanopenrest.GetNMTParams) error {
-	id, err := h.getIntFromHex(params.Node)
+	id, err := h.getNodeId(params.Node)
 	if err != nil {
-		log.Error().Msg(err.Error())
-		return ctx.NoContent(http.StatusBadRequest)
+		return badRequest(ctx, err)
 	}
-	status, err := h.canopenUC.ReadNmt(int(id))
-	if err != nil || status == nil {
-		log.Error().Msg(err.Error())
-		return ctx.NoContent(http.StatusBadRequest)
+	status, err := h.canopenUC.ReadNmt(ctx.Request().Context(), getBus(params.Bus), int(id))
+	if err != nil {
+		return problem(ctx, err)
 	}
 	return ctx.JSON(
 		http.StatusOK,
@@ -116,55 +373,166 @@ func (h *Handler) GetNMT(ctx echo.Context, params apicanopenrest.GetNMTParams) e
 func (h *Handler) PostNMT(ctx echo.Context, params apicanopenrest.PostNMTParams) error {
 	state, err := io.ReadAll(ctx.Request().Body)
 	if err != nil {
-		log.Error().Msg(err.Error())
-		return ctx.NoContent(http.StatusBadRequest)
+		return badRequest(ctx, err)
 	}
-	id, err := h.getIntFromHex(params.Node)
+	id, err := h.getNodeId(params.Node)
 	if err != nil {
-		log.Error().Msg(err.Error())
-		return ctx.NoContent(http.StatusBadRequest)
+		return badRequest(ctx, err)
 	}
-	err = h.canopenUC.WriteNmt(int(id), string(state))
+	err = h.canopenUC.WriteNmt(ctx.Request().Context(), getBus(params.Bus), int(id), string(state))
 	if err != nil {
 		log.Error().Msg(string(state))
-		log.Error().Msg(err.Error())
-		return ctx.NoContent(http.StatusBadRequest)
+		return problem(ctx, err)
 	}
 	return ctx.NoContent(http.StatusOK)
 }
 
-// GetSDO handles the GET request for the SDO
-func (h *Handler) GetSDO(ctx echo.Context, params apicanopenrest.GetSDOParams) error {
+// PutLSSState handles the PUT request to switch the state of all LSS slaves
+func (h *Handler) PutLSSState(ctx echo.Context, params apicanopenrest.PutLSSStateParams) error {
+	err := h.canopenUC.LssSwitchStateGlobal(ctx.Request().Context(), getBus(params.Bus), entities.LssMode(params.Mode))
+	if err != nil {
+		return problem(ctx, err)
+	}
+	return ctx.NoContent(http.StatusOK)
+}
+
+// PostLSSSelect handles the POST request to switch a single LSS slave to configuration state
+func (h *Handler) PostLSSSelect(ctx echo.Context, params apicanopenrest.PostLSSSelectParams) error {
+	var request LssIdentity
+	err := json.NewDecoder(ctx.Request().Body).Decode(&request)
+	if err != nil {
+		return badRequest(ctx, err)
+	}
+	err = h.canopenUC.LssSwitchStateSelective(ctx.Request().Context(), getBus(params.Bus), entities.LssIdentity(request))
+	if err != nil {
+		return problem(ctx, err)
+	}
+	return ctx.NoContent(http.StatusOK)
+}
+
+// GetLSSNodeId handles the GET request for the node id of the LSS slave in configuration state
+func (h *Handler) GetLSSNodeId(ctx echo.Context, params apicanopenrest.GetLSSNodeIdParams) error {
+	id, err := h.canopenUC.LssInquireNodeId(ctx.Request().Context(), getBus(params.Bus))
+	if err != nil {
+		return problem(ctx, err)
+	}
+	return ctx.JSON(http.StatusOK, id)
+}
+
+// PutLSSNodeId handles the PUT request to configure the node id of the LSS slave in configuration state
+func (h *Handler) PutLSSNodeId(ctx echo.Context, params apicanopenrest.PutLSSNodeIdParams) error {
 	id, err := h.getIntFromHex(params.Node)
 	if err != nil {
-		log.Error().Msg(err.Error())
-		return ctx.NoContent(http.StatusBadRequest)
+		return badRequest(ctx, err)
+	}
+	err = h.canopenUC.LssConfigureNodeId(ctx.Request().Context(), getBus(params.Bus), int(id))
+	if err != nil {
+		return problem(ctx, err)
+	}
+	return ctx.NoContent(http.StatusOK)
+}
+
+// PutLSSBitTiming handles the PUT request to configure and optionally activate the bitrate of LSS slaves
+func (h *Handler) PutLSSBitTiming(ctx echo.Context, params apicanopenrest.PutLSSBitTimingParams) error {
+	err := h.canopenUC.LssConfigureBitTiming(ctx.Request().Context(), getBus(params.Bus), params.Bitrate)
+	if err != nil {
+		return problem(ctx, err)
+	}
+	if params.SwitchDelay != nil {
+		err = h.canopenUC.LssActivateBitTiming(ctx.Request().Context(), getBus(params.Bus), time.Duration(*params.SwitchDelay)*time.Millisecond)
+		if err != nil {
+			return problem(ctx, err)
+		}
+	}
+	return ctx.NoContent(http.StatusOK)
+}
+
+// PostLSSStore handles the POST request to store the configuration of the LSS slave in configuration state
+func (h *Handler) PostLSSStore(ctx echo.Context, params apicanopenrest.PostLSSStoreParams) error {
+	err := h.canopenUC.LssStoreConfiguration(ctx.Request().Context(), getBus(params.Bus))
+	if err != nil {
+		return problem(ctx, err)
 	}
-	index, err := h.getIntFromHex(params.Index)
+	return ctx.NoContent(http.StatusOK)
+}
+
+// GetLSSIdentity handles the GET request for the identity of the LSS slave in configuration state
+func (h *Handler) GetLSSIdentity(ctx echo.Context, params apicanopenrest.GetLSSIdentityParams) error {
+	identity, err := h.canopenUC.LssInquireIdentity(ctx.Request().Context(), getBus(params.Bus))
 	if err != nil {
-		log.Error().Msg(err.Error())
-		return ctx.NoContent(http.StatusBadRequest)
+		return problem(ctx, err)
 	}
-	subindex := uint8(0)
-	if params.Subindex != nil {
-		subindex = uint8(*params.Subindex)
+	return ctx.JSON(http.StatusOK, LssIdentity(*identity))
+}
+
+// PostLSSFastscan handles the POST request to identify an unconfigured LSS slave
+func (h *Handler) PostLSSFastscan(ctx echo.Context, params apicanopenrest.PostLSSFastscanParams) error {
+	identity, err := h.canopenUC.LssFastscan(ctx.Request().Context(), getBus(params.Bus))
+	if err != nil {
+		return problem(ctx, err)
+	}
+	if identity == nil {
+		return ctx.NoContent(http.StatusNotFound)
+	}
+	return ctx.JSON(http.StatusOK, LssIdentity(*identity))
+}
+
+// GetSDO handles the GET request for the SDO
+func (h *Handler) GetSDO(ctx echo.Context, params apicanopenrest.GetSDOParams) error {
+	id, err := h.getNodeId(params.Node)
+	if err != nil {
+		return badRequest(ctx, err)
 	}
-	bytesSDO, err := h.canopenUC.ReadSDO(int(id), uint16(index), subindex)
+	index, subindex, err := h.getObjectAddress(ctx.Request().Context(), getBus(params.Bus), int(id), params.Index, params.Subindex, params.Name)
 	if err != nil {
-		log.Error().Msg(err.Error())
-		return ctx.NoContent(http.StatusBadRequest)
+		return problem(ctx, err)
 	}
 	accept := ctx.Request().Header.Get("accept")
 	switch {
+	case strings.Contains(accept, "application/json"):
+		value, err := h.canopenUC.ReadSDOValue(ctx.Request().Context(), getBus(params.Bus), int(id), index, subindex)
+		if err != nil {
+			return problem(ctx, err)
+		}
+		return ctx.JSON(http.StatusOK, SdoValue{
+			Index:    value.Index,
+			Subindex: value.Subindex,
+			Name:     value.Name,
+			Type:     value.DataType,
+			Value:    value.Value,
+		})
 	case strings.Contains(accept, "application/octet-stream"):
-		reader := bytes.NewBuffer(bytesSDO)
-		return ctx.Stream(http.StatusOK, "application/octet-stream", reader)
+		// The object is streamed by block transfer, the status is sent once the node accepted the upload
+		response := ctx.Response()
+		progress := func(transferred int64, total int64) {
+			if !response.Committed {
+				response.Header().Set(echo.HeaderContentType, echo.MIMEOctetStream)
+				if total >= 0 {
+					response.Header().Set(echo.HeaderContentLength, strconv.FormatInt(total, 10))
+				}
+				response.WriteHeader(http.StatusOK)
+			}
+			log.Debug().Str("Function", "GetSDO").Msgf("0x%04X sub %d: %d of %d bytes read", index, subindex, transferred, total)
+		}
+		_, err := h.canopenUC.ReadSDOStream(ctx.Request().Context(), getBus(params.Bus), int(id), index, subindex, response, progress)
+		if err != nil {
+			if response.Committed {
+				// The client sees the truncated body
+				log.Error().Msg(err.Error())
+				return nil
+			}
+			return problem(ctx, err)
+		}
+		return nil
 	case strings.Contains(accept, "text/plain"):
+		bytesSDO, err := h.canopenUC.ReadSDO(ctx.Request().Context(), getBus(params.Bus), int(id), index, subindex)
+		if err != nil {
+			return problem(ctx, err)
+		}
 		responseStr := fmt.Sprintf("%X", bytesSDO)
 		return ctx.String(http.StatusOK, addSpacerToHex(responseStr, ":"))
 	default:
-		log.Error().Msgf("switch to default: %s", accept)
-		return ctx.NoContent(http.StatusBadRequest)
+		return badRequest(ctx, fmt.Errorf("unsupported accept header %q", accept))
 	}
 }
 
@@ -172,49 +540,57 @@ func (h *Handler) GetSDO(ctx echo.Context, params apicanopenrest.GetSDOParams) e
 func (h *Handler) PostSDO(ctx echo.Context, params apicanopenrest.PostSDOParams) error {
 
 	var bytesSDO []byte
+	var sdoValue *SdoValue
+	var stream bool
 	var err error
 	content := ctx.Request().Header.Get("Content-Type")
 	switch {
-	case strings.Contains(content, "application/octet-stream"):
-		bytesSDO, err = io.ReadAll(ctx.Request().Body)
+	case strings.Contains(content, "application/json"):
+		decoder := json.NewDecoder(ctx.Request().Body)
+		decoder.UseNumber()
+		sdoValue = &SdoValue{}
+		err = decoder.Decode(sdoValue)
 		if err != nil {
-			log.Error().Msg(err.Error())
-			return ctx.NoContent(http.StatusBadRequest)
+			return badRequest(ctx, err)
 		}
+	case strings.Contains(content, "application/octet-stream"):
+		// The body is streamed to the node after the object address is resolved
+		stream = true
 	case strings.Contains(content, "text/plain"):
 		requestBytes, err := io.ReadAll(ctx.Request().Body)
 		if err != nil {
-			log.Error().Msg(err.Error())
-			return ctx.NoContent(http.StatusBadRequest)
+			return badRequest(ctx, err)
 		}
 		request := strings.ReplaceAll(string(requestBytes), ":", "")
 		bytesSDO, err = hex.DecodeString(request)
 		if err != nil {
-			log.Error().Msg(err.Error())
-			return ctx.NoContent(http.StatusBadRequest)
+			return badRequest(ctx, err)
 		}
 	default:
-		return ctx.NoContent(http.StatusBadRequest)
+		return badRequest(ctx, fmt.Errorf("unsupported content type %q", content))
 	}
 
-	id, err := h.getIntFromHex(params.Node)
+	id, err := h.getNodeId(params.Node)
 	if err != nil {
-		log.Error().Msg(err.Error())
-		return ctx.NoContent(http.StatusBadRequest)
+		return badRequest(ctx, err)
 	}
-	index, err := h.getIntFromHex(params.Index)
+	index, subindex, err := h.getObjectAddress(ctx.Request().Context(), getBus(params.Bus), int(id), params.Index, params.Subindex, params.Name)
 	if err != nil {
-		log.Error().Msg(err.Error())
-		return ctx.NoContent(http.StatusBadRequest)
+		return problem(ctx, err)
 	}
-	subindex := uint8(0)
-	if params.Subindex != nil {
-		subindex = uint8(*params.Subindex)
+	switch {
+	case sdoValue != nil:
+		err = h.canopenUC.WriteSDOValue(ctx.Request().Context(), getBus(params.Bus), int(id), index, subindex, sdoValue.Value, sdoValue.Type)
+	case stream:
+		progress := func(transferred int64, total int64) {
+			log.Debug().Str("Function", "PostSDO").Msgf("0x%04X sub %d: %d of %d bytes written", index, subindex, transferred, total)
+		}
+		err = h.canopenUC.WriteSDOStream(ctx.Request().Context(), getBus(params.Bus), int(id), index, subindex, ctx.Request().Body, ctx.Request().ContentLength, progress)
+	default:
+		err = h.canopenUC.WriteSDO(ctx.Request().Context(), getBus(params.Bus), int(id), index, subindex, bytesSDO)
 	}
-	err = h.canopenUC.WriteSDO(int(id), uint16(index), subindex, bytesSDO)
 	if err != nil {
-		log.Error().Msg(err.Error())
-		return ctx.NoContent(http.StatusBadRequest)
+		return problem(ctx, err)
 	}
 	return ctx.NoContent(http.StatusOK)
 }
@@ -222,56 +598,434 @@ func (h *Handler) PostSDO(ctx echo.Context, params apicanopenrest.PostSDOParams)
 func (h *Handler) PostNode(ctx echo.Context, params apicanopenrest.PostNodeParams) error {
 	bytesEDS, err := io.ReadAll(ctx.Request().Body)
 	if err != nil {
-		log.Error().Msg(err.Error())
-		return ctx.NoContent(http.StatusBadRequest)
+		return badRequest(ctx, err)
 	}
-	id, err := h.getIntFromHex(params.Node)
+	id, err := h.getNodeId(params.Node)
 	if err != nil {
-		log.Error().Msg(err.Error())
-		return ctx.NoContent(http.StatusBadRequest)
+		return badRequest(ctx, err)
 	}
-	err = h.canopenUC.CreateNode(int(id), bytesEDS)
+	err = h.canopenUC.CreateNode(ctx.Request().Context(), getBus(params.Bus), int(id), bytesEDS)
 	if err != nil {
-		log.Error().Msg(err.Error())
-		return ctx.NoContent(http.StatusBadRequest)
+		return problem(ctx, err)
 	}
 	return ctx.NoContent(http.StatusOK)
 }
 
-func (h *Handler) PostFlash(ctx echo.Context, params apicanopenrest.PostFlashParams) error {
-	basePath := os.Getenv("CANOPEN_STORAGE")
-	var err error
-	if basePath == "" {
-		basePath, err = os.UserConfigDir()
+// GetNodes handles the GET request for all nodes with stored eds
+func (h *Handler) GetNodes(ctx echo.Context, params apicanopenrest.GetNodesParams) error {
+	nodes, err := h.canopenUC.GetNodes(ctx.Request().Context(), getBus(params.Bus))
+	if err != nil {
+		return problem(ctx, err)
+	}
+	response := []NodeInfo{}
+	for _, node := range nodes {
+		response = append(response, newNodeInfo(node))
+	}
+	return ctx.JSON(http.StatusOK, response)
+}
+
+// GetNode handles the GET request for a node with stored eds
+func (h *Handler) GetNode(ctx echo.Context, params apicanopenrest.GetNodeParams) error {
+	id, err := h.getNodeId(params.Node)
+	if err != nil {
+		return badRequest(ctx, err)
+	}
+	node, err := h.canopenUC.GetNode(ctx.Request().Context(), getBus(params.Bus), int(id))
+	if err != nil {
+		return problem(ctx, err)
+	}
+	return ctx.JSON(http.StatusOK, newNodeInfo(*node))
+}
+
+// PutNode handles the PUT request to replace the eds of a node
+func (h *Handler) PutNode(ctx echo.Context, params apicanopenrest.PutNodeParams) error {
+	bytesEDS, err := io.ReadAll(ctx.Request().Body)
+	if err != nil {
+		return badRequest(ctx, err)
+	}
+	id, err := h.getNodeId(params.Node)
+	if err != nil {
+		return badRequest(ctx, err)
+	}
+	err = h.canopenUC.ReplaceNode(ctx.Request().Context(), getBus(params.Bus), int(id), bytesEDS)
+	if err != nil {
+		return problem(ctx, err)
+	}
+	return ctx.NoContent(http.StatusOK)
+}
+
+// DeleteNode handles the DELETE request for a node
+func (h *Handler) DeleteNode(ctx echo.Context, params apicanopenrest.DeleteNodeParams) error {
+	id, err := h.getNodeId(params.Node)
+	if err != nil {
+		return badRequest(ctx, err)
+	}
+	err = h.canopenUC.DeleteNode(ctx.Request().Context(), getBus(params.Bus), int(id))
+	if err != nil {
+		return problem(ctx, err)
+	}
+	return ctx.NoContent(http.StatusOK)
+}
+
+// PostNodeConfiguration handles the POST request downloading a dcf, the eds defaults or overrides to a node
+func (h *Handler) PostNodeConfiguration(ctx echo.Context, node string, params apicanopenrest.PostNodeConfigurationParams) error {
+	id, err := h.getNodeId(node)
+	if err != nil {
+		return badRequest(ctx, err)
+	}
+	var dcfFile []byte
+	overrides := []entities.ConfigurationValue{}
+	if strings.Contains(ctx.Request().Header.Get("Content-Type"), "application/json") {
+		decoder := json.NewDecoder(ctx.Request().Body)
+		decoder.UseNumber()
+		values := []SdoValue{}
+		err = decoder.Decode(&values)
+		if err != nil {
+			return badRequest(ctx, err)
+		}
+		for _, value := range values {
+			index, subindex := value.Index, value.Subindex
+			if value.Name != "" {
+				index, subindex, err = h.canopenUC.FindObjectByName(ctx.Request().Context(), getBus(params.Bus), int(id), value.Name)
+				if err != nil {
+					return problem(ctx, err)
+				}
+			}
+			overrides = append(overrides, entities.ConfigurationValue{
+				Index:    index,
+				Subindex: subindex,
+				Value:    value.Value,
+			})
+		}
+	} else {
+		dcfFile, err = io.ReadAll(ctx.Request().Body)
 		if err != nil {
-			return err
+			return badRequest(ctx, err)
+		}
+		if len(dcfFile) == 0 {
+			dcfFile = nil
 		}
 	}
-	out, err := os.Create(path.Join(basePath, "CanOpenRest", "flashfile.bin"))
+	store := params.Store != nil && *params.Store
+	report, err := h.canopenUC.DownloadConfiguration(ctx.Request().Context(), getBus(params.Bus), int(id), dcfFile, overrides, store)
 	if err != nil {
-		return err
+		return problem(ctx, err)
 	}
-	defer out.Close()
+	return ctx.JSON(http.StatusOK, newConfigurationReport(*report))
+}
 
-	_, err = io.Copy(out, ctx.Request().Body)
+// GetNodeParameters handles the GET request for the store and restore capabilities of a node
+func (h *Handler) GetNodeParameters(ctx echo.Context, node string, params apicanopenrest.GetNodeParametersParams) error {
+	id, err := h.getNodeId(node)
 	if err != nil {
-		return err
+		return badRequest(ctx, err)
 	}
-	out.Close()
-	flashFile, err := os.ReadFile(path.Join(basePath, "CanOpenRest", "flashfile.bin"))
+	capabilities, err := h.canopenUC.GetParameterCapabilities(ctx.Request().Context(), getBus(params.Bus), int(id))
 	if err != nil {
-		return err
+		return problem(ctx, err)
 	}
-	id, err := h.getIntFromHex(params.Node)
+	response := []ParameterCapability{}
+	for _, capability := range capabilities {
+		response = append(response, ParameterCapability{
+			Group:             string(capability.Group),
+			Subindex:          capability.Subindex,
+			Store:             capability.Store,
+			StoreAutonomously: capability.StoreAutonomously,
+			Restore:           capability.Restore,
+		})
+	}
+	return ctx.JSON(http.StatusOK, response)
+}
+
+// PostNodeParametersStore handles the POST request storing parameters of a node
+func (h *Handler) PostNodeParametersStore(ctx echo.Context, node string, params apicanopenrest.PostNodeParametersStoreParams) error {
+	id, err := h.getNodeId(node)
+	if err != nil {
+		return badRequest(ctx, err)
+	}
+	group, reset := entities.ParameterGroupAll, entities.ParameterResetNone
+	if params.Group != nil {
+		group = entities.ParameterGroup(*params.Group)
+	}
+	if params.Reset != nil {
+		reset = entities.ParameterReset(*params.Reset)
+	}
+	err = h.canopenUC.StoreParameters(ctx.Request().Context(), getBus(params.Bus), int(id), group, reset)
+	if err != nil {
+		return problem(ctx, err)
+	}
+	return ctx.NoContent(http.StatusOK)
+}
+
+// PostNodeParametersRestore handles the POST request restoring the default parameters of a node
+func (h *Handler) PostNodeParametersRestore(ctx echo.Context, node string, params apicanopenrest.PostNodeParametersRestoreParams) error {
+	id, err := h.getNodeId(node)
+	if err != nil {
+		return badRequest(ctx, err)
+	}
+	group, reset := entities.ParameterGroupAll, entities.ParameterResetNone
+	if params.Group != nil {
+		group = entities.ParameterGroup(*params.Group)
+	}
+	if params.Reset != nil {
+		reset = entities.ParameterReset(*params.Reset)
+	}
+	err = h.canopenUC.RestoreDefaultParameters(ctx.Request().Context(), getBus(params.Bus), int(id), group, reset)
+	if err != nil {
+		return problem(ctx, err)
+	}
+	return ctx.NoContent(http.StatusOK)
+}
+
+// PostNodeBackup handles the POST request backing up the parameters of a node
+func (h *Handler) PostNodeBackup(ctx echo.Context, node string, params apicanopenrest.PostNodeBackupParams) error {
+	id, err := h.getNodeId(node)
+	if err != nil {
+		return badRequest(ctx, err)
+	}
+	comment := ""
+	if params.Comment != nil {
+		comment = *params.Comment
+	}
+	snapshot, err := h.canopenUC.BackupNode(ctx.Request().Context(), getBus(params.Bus), int(id), comment)
+	if err != nil {
+		return problem(ctx, err)
+	}
+	return ctx.JSON(http.StatusOK, newParameterSnapshot(*snapshot))
+}
+
+// GetNodeBackups handles the GET request for the backups of a node
+func (h *Handler) GetNodeBackups(ctx echo.Context, node string, params apicanopenrest.GetNodeBackupsParams) error {
+	id, err := h.getNodeId(node)
+	if err != nil {
+		return badRequest(ctx, err)
+	}
+	snapshots, err := h.canopenUC.GetSnapshots(ctx.Request().Context(), getBus(params.Bus), int(id))
+	if err != nil {
+		return problem(ctx, err)
+	}
+	response := []ParameterSnapshot{}
+	for _, snapshot := range snapshots {
+		info := newParameterSnapshot(snapshot)
+		info.Values = nil
+		response = append(response, info)
+	}
+	return ctx.JSON(http.StatusOK, response)
+}
+
+// GetNodeBackup handles the GET request for a backup of a node
+func (h *Handler) GetNodeBackup(ctx echo.Context, node string, version int, params apicanopenrest.GetNodeBackupParams) error {
+	id, err := h.getNodeId(node)
+	if err != nil {
+		return badRequest(ctx, err)
+	}
+	snapshot, err := h.canopenUC.GetSnapshot(ctx.Request().Context(), getBus(params.Bus), int(id), version)
+	if err != nil {
+		return problem(ctx, err)
+	}
+	return ctx.JSON(http.StatusOK, newParameterSnapshot(*snapshot))
+}
+
+// DeleteNodeBackup handles the DELETE request for a backup of a node
+func (h *Handler) DeleteNodeBackup(ctx echo.Context, node string, version int, params apicanopenrest.DeleteNodeBackupParams) error {
+	id, err := h.getNodeId(node)
+	if err != nil {
+		return badRequest(ctx, err)
+	}
+	err = h.canopenUC.DeleteSnapshot(ctx.Request().Context(), getBus(params.Bus), int(id), version)
+	if err != nil {
+		return problem(ctx, err)
+	}
+	return ctx.NoContent(http.StatusOK)
+}
+
+// PostNodeBackupRestore handles the POST request restoring a backup to a node
+func (h *Handler) PostNodeBackupRestore(ctx echo.Context, node string, version int, params apicanopenrest.PostNodeBackupRestoreParams) error {
+	id, err := h.getNodeId(node)
+	if err != nil {
+		return badRequest(ctx, err)
+	}
+	store := params.Store != nil && *params.Store
+	report, err := h.canopenUC.RestoreSnapshot(ctx.Request().Context(), getBus(params.Bus), int(id), version, store)
+	if err != nil {
+		return problem(ctx, err)
+	}
+	return ctx.JSON(http.StatusOK, newConfigurationReport(*report))
+}
+
+// GetNodeBackupDiff handles the GET request comparing a backup with another backup or the node
+func (h *Handler) GetNodeBackupDiff(ctx echo.Context, node string, version int, params apicanopenrest.GetNodeBackupDiffParams) error {
+	id, err := h.getNodeId(node)
+	if err != nil {
+		return badRequest(ctx, err)
+	}
+	diffs, err := h.canopenUC.DiffSnapshot(ctx.Request().Context(), getBus(params.Bus), int(id), version, params.Against)
+	if err != nil {
+		return problem(ctx, err)
+	}
+	response := []ParameterDiff{}
+	for _, diff := range diffs {
+		response = append(response, ParameterDiff{
+			Index:    diff.Index,
+			Subindex: diff.Subindex,
+			Name:     diff.Name,
+			Type:     diff.DataType,
+			Change:   string(diff.Change),
+			From:     diff.From,
+			To:       diff.To,
+		})
+	}
+	return ctx.JSON(http.StatusOK, response)
+}
+
+// ValidateEds handles the POST request checking an eds without storing it
+func (h *Handler) ValidateEds(ctx echo.Context) error {
+	bytesEDS, err := io.ReadAll(ctx.Request().Body)
+	if err != nil {
+		return badRequest(ctx, err)
+	}
+	return ctx.JSON(http.StatusOK, newEdsValidation(h.canopenUC.ValidateEds(bytesEDS)))
+}
+
+func newParameterSnapshot(snapshot entities.ParameterSnapshot) ParameterSnapshot {
+	response := ParameterSnapshot{
+		Bus:     snapshot.Bus,
+		Node:    snapshot.Id,
+		Version: snapshot.Version,
+		Created: snapshot.Created,
+		Comment: snapshot.Comment,
+		Count:   len(snapshot.Values),
+	}
+	for _, value := range snapshot.Values {
+		response.Values = append(response.Values, SdoValue{
+			Index:    value.Index,
+			Subindex: value.Subindex,
+			Name:     value.Name,
+			Type:     value.DataType,
+			Value:    value.Value,
+		})
+	}
+	return response
+}
+
+func newConfigurationReport(report entities.ConfigurationReport) ConfigurationReport {
+	response := ConfigurationReport{
+		Bus:        report.Bus,
+		Node:       report.Id,
+		Written:    report.Written,
+		Unchanged:  report.Unchanged,
+		Failed:     report.Failed,
+		Stored:     report.Stored,
+		StoreError: report.StoreError,
+		Objects:    []ConfigurationResult{},
+	}
+	for _, result := range report.Objects {
+		response.Objects = append(response.Objects, ConfigurationResult{
+			Index:    result.Index,
+			Subindex: result.Subindex,
+			Name:     result.Name,
+			Type:     result.DataType,
+			Value:    result.Value,
+			Previous: result.Previous,
+			Result:   string(result.Result),
+			Error:    result.Error,
+		})
+	}
+	return response
+}
+
+func newEdsValidation(issues []entities.EdsIssue) EdsValidation {
+	validation := EdsValidation{
+		Valid:  true,
+		Issues: []EdsIssue{},
+	}
+	for _, issue := range issues {
+		if issue.Severity == entities.EdsError {
+			validation.Valid = false
+		}
+		validation.Issues = append(validation.Issues, EdsIssue{
+			Severity: string(issue.Severity),
+			Section:  issue.Section,
+			Message:  issue.Message,
+		})
+	}
+	return validation
+}
+
+func newNodeInfo(node entities.NodeInfo) NodeInfo {
+	return NodeInfo{
+		Bus:              node.Bus,
+		Node:             node.Id,
+		FileName:         node.FileName,
+		FileVersion:      node.FileVersion,
+		Description:      node.Description,
+		ModificationDate: node.ModificationDate,
+		VendorName:       node.VendorName,
+		VendorNumber:     node.VendorNumber,
+		ProductName:      node.ProductName,
+		ProductNumber:    node.ProductNumber,
+		RevisionNumber:   node.RevisionNumber,
+	}
+}
+
+// GetNodeOD handles the GET request for the object dictionary of a node
+func (h *Handler) GetNodeOD(ctx echo.Context, node string, params apicanopenrest.GetNodeODParams) error {
+	id, err := h.getNodeId(node)
+	if err != nil {
+		return badRequest(ctx, err)
+	}
+	objects, err := h.canopenUC.GetObjectDictionary(ctx.Request().Context(), getBus(params.Bus), int(id))
+	if err != nil {
+		return problem(ctx, err)
+	}
+	return ctx.JSON(http.StatusOK, newOdObjects(objects))
+}
+
+// GetNodeEDS handles the GET request for the eds file of a node
+func (h *Handler) GetNodeEDS(ctx echo.Context, node string, params apicanopenrest.GetNodeEDSParams) error {
+	id, err := h.getNodeId(node)
+	if err != nil {
+		return badRequest(ctx, err)
+	}
+	edsFile, err := h.canopenUC.GetEds(ctx.Request().Context(), getBus(params.Bus), int(id))
+	if err != nil {
+		return problem(ctx, err)
+	}
+	return ctx.Blob(http.StatusOK, "application/octet-stream", edsFile)
+}
+
+func newOdObjects(objects []entities.OdObject) []OdObject {
+	result := []OdObject{}
+	for _, object := range objects {
+		result = append(result, OdObject{
+			Index:        object.Index,
+			Subindex:     object.Subindex,
+			Name:         object.Name,
+			ObjectType:   object.ObjectType,
+			DataType:     object.DataType,
+			AccessType:   object.AccessType,
+			DefaultValue: object.DefaultValue,
+			PdoMapping:   object.PdoMapping,
+			LowLimit:     object.LowLimit,
+			HighLimit:    object.HighLimit,
+			SubObjects:   newOdObjects(object.SubObjects),
+		})
+	}
+	return result
+}
+
+func (h *Handler) PostFlash(ctx echo.Context, params apicanopenrest.PostFlashParams) error {
+	id, err := h.getNodeId(params.Node)
 	if err != nil {
-		log.Error().Msg(err.Error())
-		return ctx.NoContent(http.StatusBadRequest)
+		return badRequest(ctx, err)
 	}
-	log.Debug().Msgf("flashFile size: %d", len(flashFile))
-	order, err := h.canopenUC.FlashNode(int(id), flashFile, params.Version)
+	requester := ctx.RealIP()
+	if params.Requester != nil {
+		requester = *params.Requester
+	}
+	order, err := h.canopenUC.FlashNode(ctx.Request().Context(), getBus(params.Bus), int(id), ctx.Request().Body, params.Version, requester)
 	if err != nil {
-		log.Error().Msg(err.Error())
-		return ctx.NoContent(http.StatusBadRequest)
+		return problem(ctx, err)
 	}
 	return ctx.String(http.StatusCreated, order.String())
 }
@@ -279,21 +1033,695 @@ func (h *Handler) PostFlash(ctx echo.Context, params apicanopenrest.PostFlashPar
 func (h *Handler) GetFlash(ctx echo.Context, params apicanopenrest.GetFlashParams) error {
 	testOrderId, err := uuid.Parse(params.Id)
 	if err != nil {
-		log.Error().Msg(err.Error())
-		return ctx.NoContent(http.StatusBadRequest)
+		return badRequest(ctx, err)
 	}
-	flashStates, err := h.canopenUC.GetFlashState(testOrderId)
+	flashStates, err := h.canopenUC.GetFlashState(ctx.Request().Context(), testOrderId)
 	if err != nil {
-		log.Error().Msg(err.Error())
-		return ctx.NoContent(http.StatusBadRequest)
+		return problem(ctx, err)
+	}
+	return ctx.JSON(http.StatusOK, newFlashOrderState(*flashStates))
+}
+
+// DeleteFlash cancels a queued or running flash order
+func (h *Handler) DeleteFlash(ctx echo.Context, params apicanopenrest.DeleteFlashParams) error {
+	flashOrderId, err := uuid.Parse(params.Id)
+	if err != nil {
+		return badRequest(ctx, err)
+	}
+	flashState, err := h.canopenUC.CancelFlash(ctx.Request().Context(), flashOrderId)
+	if err != nil {
+		return problem(ctx, err)
+	}
+	return ctx.JSON(http.StatusOK, newFlashOrderState(*flashState))
+}
+
+// GetFlashEvents streams the state transitions, progress updates and results of one or all flash orders
+// as server-sent events. The stream of a single order starts with its current state and ends with its result.
+func (h *Handler) GetFlashEvents(ctx echo.Context, params apicanopenrest.GetFlashEventsParams) error {
+	var orderId *uuid.UUID
+	if params.Id != nil {
+		id, err := uuid.Parse(*params.Id)
+		if err != nil {
+			return badRequest(ctx, err)
+		}
+		orderId = &id
+	}
+	events, unsubscribe := h.canopenUC.SubscribeFlashEvents()
+	defer unsubscribe()
+	var current *entities.FlashOrderState
+	if orderId != nil {
+		state, err := h.canopenUC.GetFlashState(ctx.Request().Context(), *orderId)
+		if err != nil {
+			return problem(ctx, err)
+		}
+		current = state
 	}
-	return ctx.JSON(http.StatusOK, &FlashOrderState{
-		Requested: flashStates.Requested,
-		Start:     flashStates.Start,
-		Finish:    flashStates.Finish,
-		State:     flashStates.State,
-		Error:     flashStates.Error,
+
+	response := ctx.Response()
+	response.Header().Set(echo.HeaderContentType, "text/event-stream")
+	response.Header().Set(echo.HeaderCacheControl, "no-cache")
+	response.WriteHeader(http.StatusOK)
+	if current != nil {
+		event := entities.FlashEvent{
+			Type:         entities.FlashStateChanged,
+			FlashOrderId: *orderId,
+			State:        *current,
+		}
+		if current.State.Finished() {
+			event.Type = entities.FlashFinished
+		}
+		if err := writeFlashEvent(response, event); err != nil || current.State.Finished() {
+			return nil
+		}
+	}
+	keepalive := time.NewTicker(FLASH_EVENTS_KEEPALIVE)
+	defer keepalive.Stop()
+	for {
+		select {
+		case <-ctx.Request().Context().Done():
+			return nil
+		case <-keepalive.C:
+			if _, err := fmt.Fprint(response, ": keepalive\n\n"); err != nil {
+				return nil
+			}
+			response.Flush()
+		case event, ok := <-events:
+			if !ok {
+				return nil
+			}
+			if orderId != nil && event.FlashOrderId != *orderId {
+				continue
+			}
+			if err := writeFlashEvent(response, event); err != nil {
+				return nil
+			}
+			if orderId != nil && event.Type == entities.FlashFinished {
+				return nil
+			}
+		}
+	}
+}
+
+// writeFlashEvent writes a flash event as server-sent event named by its type
+func writeFlashEvent(response *echo.Response, event entities.FlashEvent) error {
+	data, err := json.Marshal(FlashEvent{
+		Id:    event.FlashOrderId,
+		Type:  string(event.Type),
+		Order: newFlashOrderState(event.State),
 	})
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(response, "event: %s\ndata: %s\n\n", event.Type, data); err != nil {
+		return err
+	}
+	response.Flush()
+	return nil
+}
+
+// GetFlashOrders handles the GET request for the list of flash orders, newest first
+func (h *Handler) GetFlashOrders(ctx echo.Context, params apicanopenrest.GetFlashOrdersParams) error {
+	filter := entities.FlashOrderFilter{
+		From: params.From,
+		To:   params.To,
+	}
+	if params.Bus != nil {
+		filter.Bus = *params.Bus
+	}
+	if params.Node != nil {
+		id, err := h.getNodeId(*params.Node)
+		if err != nil {
+			return badRequest(ctx, err)
+		}
+		filter.Id = common.POINTER(int(id))
+	}
+	if params.State != nil {
+		filter.State = common.POINTER(entities.FlashState(*params.State))
+	}
+	offset, limit := 0, FLASH_ORDERS_DEFAULT_LIMIT
+	if params.Offset != nil {
+		offset = *params.Offset
+	}
+	if params.Limit != nil {
+		limit = *params.Limit
+	}
+	if offset < 0 || limit < 1 {
+		return badRequest(ctx, fmt.Errorf("invalid page offset %d limit %d", offset, limit))
+	}
+	orders, total, err := h.canopenUC.GetFlashOrders(ctx.Request().Context(), filter, offset, limit)
+	if err != nil {
+		return problem(ctx, err)
+	}
+	response := FlashOrders{
+		Total:  total,
+		Offset: offset,
+		Limit:  limit,
+		Orders: []FlashOrderState{},
+	}
+	for _, order := range orders {
+		response.Orders = append(response.Orders, newFlashOrderState(order))
+	}
+	return ctx.JSON(http.StatusOK, response)
+}
+
+func newFlashOrderState(state entities.FlashOrderState) FlashOrderState {
+	result := FlashOrderState{
+		Id:        state.Order.FlashOrderId,
+		Bus:       state.Order.Bus,
+		Node:      state.Order.Id,
+		Version:   state.Order.Version,
+		ImageHash: state.Order.ImageHash,
+		ImageId:   state.Order.ImageId,
+		Size:      state.Order.Size,
+		Requester: state.Order.Requester,
+		Requested: state.Requested,
+		Start:     state.Start,
+		Finish:    state.Finish,
+		State:     state.State,
+		Error:     state.Error,
+	}
+	if state.Progress != nil {
+		result.Progress = &FlashProgress{
+			Transferred: state.Progress.Transferred,
+			Total:       state.Progress.Total,
+			Percent:     state.Progress.Percent(),
+			Rate:        state.Progress.Rate,
+			Eta:         state.Progress.Eta.Seconds(),
+			Updated:     state.Progress.Updated,
+		}
+	}
+	return result
+}
+
+// PostImage handles the POST request uploading a firmware image
+func (h *Handler) PostImage(ctx echo.Context, params apicanopenrest.PostImageParams) error {
+	image := entities.FirmwareImage{
+		Version:   params.Version,
+		Signature: params.Signature,
+	}
+	if params.ProductCode != nil {
+		productCode, err := strconv.ParseUint(strings.Replace(*params.ProductCode, "0x", "", -1), 16, 32)
+		if err != nil {
+			return badRequest(ctx, err)
+		}
+		image.ProductCode = common.POINTER(uint32(productCode))
+	}
+	if params.Hash != nil {
+		image.Id = *params.Hash
+	}
+	stored, err := h.canopenUC.UploadImage(ctx.Request().Context(), image, ctx.Request().Body)
+	if err != nil {
+		return problem(ctx, err)
+	}
+	return ctx.JSON(http.StatusCreated, newFirmwareImage(*stored))
+}
+
+// GetImages handles the GET request for the list of firmware images, newest first
+func (h *Handler) GetImages(ctx echo.Context, params apicanopenrest.GetImagesParams) error {
+	var productCode *uint32
+	if params.ProductCode != nil {
+		code, err := strconv.ParseUint(strings.Replace(*params.ProductCode, "0x", "", -1), 16, 32)
+		if err != nil {
+			return badRequest(ctx, err)
+		}
+		productCode = common.POINTER(uint32(code))
+	}
+	images, err := h.canopenUC.GetImages(ctx.Request().Context(), productCode)
+	if err != nil {
+		return problem(ctx, err)
+	}
+	response := []FirmwareImage{}
+	for _, image := range images {
+		response = append(response, newFirmwareImage(image))
+	}
+	return ctx.JSON(http.StatusOK, response)
+}
+
+// GetImage handles the GET request for a firmware image
+func (h *Handler) GetImage(ctx echo.Context, id string) error {
+	image, err := h.canopenUC.GetImage(ctx.Request().Context(), id)
+	if err != nil {
+		return problem(ctx, err)
+	}
+	return ctx.JSON(http.StatusOK, newFirmwareImage(*image))
+}
+
+// DeleteImage handles the DELETE request for a firmware image
+func (h *Handler) DeleteImage(ctx echo.Context, id string) error {
+	err := h.canopenUC.DeleteImage(ctx.Request().Context(), id)
+	if err != nil {
+		return problem(ctx, err)
+	}
+	return ctx.NoContent(http.StatusOK)
+}
+
+// PostImageFlash handles the POST request flashing a firmware image to one or many nodes
+func (h *Handler) PostImageFlash(ctx echo.Context, id string) error {
+	var request ImageFlashRequest
+	err := json.NewDecoder(ctx.Request().Body).Decode(&request)
+	if err != nil {
+		return badRequest(ctx, err)
+	}
+	ids := []int{}
+	for _, node := range request.Nodes {
+		nodeId, err := h.getNodeId(node)
+		if err != nil {
+			return badRequest(ctx, err)
+		}
+		ids = append(ids, int(nodeId))
+	}
+	requester := ctx.RealIP()
+	if request.Requester != nil {
+		requester = *request.Requester
+	}
+	orders, err := h.canopenUC.FlashImage(ctx.Request().Context(), id, request.Bus, ids, requester)
+	if err != nil {
+		return problem(ctx, err)
+	}
+	response := []FlashOrderState{}
+	for _, order := range orders {
+		state, err := h.canopenUC.GetFlashState(ctx.Request().Context(), order)
+		if err != nil {
+			return problem(ctx, err)
+		}
+		response = append(response, newFlashOrderState(*state))
+	}
+	return ctx.JSON(http.StatusCreated, response)
+}
+
+func newFirmwareImage(image entities.FirmwareImage) FirmwareImage {
+	return FirmwareImage{
+		Id:          image.Id,
+		ProductCode: image.ProductCode,
+		Version:     image.Version,
+		Signature:   image.Signature,
+		Size:        image.Size,
+		Uploaded:    image.Uploaded,
+	}
+}
+
+// GetEmcy handles the GET request for the EMCY history of a node
+func (h *Handler) GetEmcy(ctx echo.Context, params apicanopenrest.GetEmcyParams) error {
+	id, err := h.getNodeId(params.Node)
+	if err != nil {
+		return badRequest(ctx, err)
+	}
+	history, err := h.canopenUC.GetEmcy(ctx.Request().Context(), getBus(params.Bus), int(id))
+	if err != nil {
+		return problem(ctx, err)
+	}
+	response := []Emcy{}
+	for _, emcy := range history {
+		response = append(response, Emcy{
+			Timestamp:        emcy.Timestamp,
+			ErrorCode:        emcy.ErrorCode,
+			ErrorRegister:    emcy.ErrorRegister,
+			ManufacturerData: fmt.Sprintf("%X", emcy.ManufacturerData),
+			Description:      emcy.Description(),
+		})
+	}
+	return ctx.JSON(http.StatusOK, response)
+}
+
+// DeleteEmcy handles the DELETE request for the EMCY history of a node
+func (h *Handler) DeleteEmcy(ctx echo.Context, params apicanopenrest.DeleteEmcyParams) error {
+	id, err := h.getNodeId(params.Node)
+	if err != nil {
+		return badRequest(ctx, err)
+	}
+	err = h.canopenUC.ClearEmcy(ctx.Request().Context(), getBus(params.Bus), int(id))
+	if err != nil {
+		return problem(ctx, err)
+	}
+	return ctx.NoContent(http.StatusOK)
+}
+
+// GetNodesStatus handles the GET request for the liveness of all nodes
+func (h *Handler) GetNodesStatus(ctx echo.Context, params apicanopenrest.GetNodesStatusParams) error {
+	statuses, err := h.canopenUC.GetNodesStatus(ctx.Request().Context(), getBus(params.Bus))
+	if err != nil {
+		return problem(ctx, err)
+	}
+	response := []NodeStatus{}
+	for _, status := range statuses {
+		response = append(response, NodeStatus{
+			Bus:           status.Bus,
+			Node:          status.Id,
+			Online:        status.Online,
+			State:         status.State,
+			LastSeen:      status.LastSeen,
+			HeartbeatTime: status.HeartbeatTime.Milliseconds(),
+		})
+	}
+	return ctx.JSON(http.StatusOK, response)
+}
+
+// GetNodesEvents streams nodes going online or offline and NMT state changes as server-sent events
+func (h *Handler) GetNodesEvents(ctx echo.Context, params apicanopenrest.GetNodesEventsParams) error {
+	if params.Bus != nil && !common.CONTAINS(h.canopenUC.GetBuses(), *params.Bus) {
+		return problem(ctx, &canopenuc.Error{Kind: canopenuc.ERROR_NOT_FOUND, Err: fmt.Errorf("bus %s not found", *params.Bus)})
+	}
+	events, unsubscribe := h.canopenUC.SubscribeNodeEvents()
+	defer unsubscribe()
+
+	response := ctx.Response()
+	response.Header().Set(echo.HeaderContentType, "text/event-stream")
+	response.Header().Set(echo.HeaderCacheControl, "no-cache")
+	response.WriteHeader(http.StatusOK)
+	response.Flush()
+	keepalive := time.NewTicker(FLASH_EVENTS_KEEPALIVE)
+	defer keepalive.Stop()
+	for {
+		select {
+		case <-ctx.Request().Context().Done():
+			return nil
+		case <-keepalive.C:
+			if _, err := fmt.Fprint(response, ": keepalive\n\n"); err != nil {
+				return nil
+			}
+			response.Flush()
+		case event, ok := <-events:
+			if !ok {
+				return nil
+			}
+			if params.Bus != nil && event.Bus != *params.Bus {
+				continue
+			}
+			data, err := json.Marshal(NodeEvent{
+				Bus:       event.Bus,
+				Node:      event.Id,
+				Type:      string(event.Type),
+				State:     event.State,
+				Timestamp: event.Timestamp,
+			})
+			if err != nil {
+				return nil
+			}
+			if _, err := fmt.Fprintf(response, "event: %s\ndata: %s\n\n", event.Type, data); err != nil {
+				return nil
+			}
+			response.Flush()
+		}
+	}
+}
+
+// ScanNodes handles the POST request to scan a bus for nodes
+func (h *Handler) ScanNodes(ctx echo.Context, params apicanopenrest.ScanNodesParams) error {
+	var timeout time.Duration
+	if params.Timeout != nil {
+		timeout = time.Duration(*params.Timeout) * time.Millisecond
+	}
+	results, err := h.canopenUC.ScanNetwork(ctx.Request().Context(), getBus(params.Bus), timeout)
+	if err != nil {
+		return problem(ctx, err)
+	}
+	response := []ScanResult{}
+	for _, result := range results {
+		response = append(response, ScanResult{
+			Node:           result.Id,
+			SdoResponse:    result.SdoResponse,
+			Heartbeat:      result.Heartbeat,
+			State:          result.State,
+			DeviceType:     result.DeviceType,
+			VendorId:       result.VendorId,
+			ProductCode:    result.ProductCode,
+			RevisionNumber: result.RevisionNumber,
+			SerialNumber:   result.SerialNumber,
+			HasEds:         result.HasEds,
+		})
+	}
+	return ctx.JSON(http.StatusOK, response)
+}
+
+// GetPDOs handles the GET request for all PDO configurations of a node
+func (h *Handler) GetPDOs(ctx echo.Context, params apicanopenrest.GetPDOsParams) error {
+	id, err := h.getNodeId(params.Node)
+	if err != nil {
+		return badRequest(ctx, err)
+	}
+	configs, err := h.canopenUC.ReadPdos(ctx.Request().Context(), getBus(params.Bus), int(id), entities.PdoDirection(params.Direction))
+	if err != nil {
+		return problem(ctx, err)
+	}
+	response := []PdoConfig{}
+	for _, config := range configs {
+		response = append(response, newPdoConfig(config))
+	}
+	return ctx.JSON(http.StatusOK, response)
+}
+
+// GetPDO handles the GET request for a PDO configuration
+func (h *Handler) GetPDO(ctx echo.Context, params apicanopenrest.GetPDOParams) error {
+	id, err := h.getNodeId(params.Node)
+	if err != nil {
+		return badRequest(ctx, err)
+	}
+	config, err := h.canopenUC.ReadPdo(ctx.Request().Context(), getBus(params.Bus), int(id), entities.PdoDirection(params.Direction), params.Number)
+	if err != nil {
+		return problem(ctx, err)
+	}
+	return ctx.JSON(http.StatusOK, newPdoConfig(*config))
+}
+
+// PutPDO handles the PUT request for a PDO configuration
+func (h *Handler) PutPDO(ctx echo.Context, params apicanopenrest.PutPDOParams) error {
+	// RTR is allowed unless explicitly disabled
+	request := PdoConfig{RtrAllowed: true}
+	err := json.NewDecoder(ctx.Request().Body).Decode(&request)
+	if err != nil {
+		return badRequest(ctx, err)
+	}
+	id, err := h.getNodeId(params.Node)
+	if err != nil {
+		return badRequest(ctx, err)
+	}
+	request.Direction = entities.PdoDirection(params.Direction)
+	request.Number = params.Number
+	config, err := h.canopenUC.WritePdo(ctx.Request().Context(), getBus(params.Bus), int(id), request.toEntity())
+	if err != nil {
+		return problem(ctx, err)
+	}
+	return ctx.JSON(http.StatusOK, newPdoConfig(*config))
+}
+
+// GetPDOValues handles the GET request for the latest values of a TPDO
+func (h *Handler) GetPDOValues(ctx echo.Context, params apicanopenrest.GetPDOValuesParams) error {
+	id, err := h.getNodeId(params.Node)
+	if err != nil {
+		return badRequest(ctx, err)
+	}
+	pdoValues, err := h.canopenUC.GetPdoValues(ctx.Request().Context(), getBus(params.Bus), int(id), params.Number)
+	if err != nil {
+		return problem(ctx, err)
+	}
+	response := PdoValues{
+		Number:    pdoValues.Number,
+		CobId:     pdoValues.CobId,
+		Received:  pdoValues.Received,
+		Timestamp: pdoValues.Timestamp,
+		Data:      fmt.Sprintf("%X", pdoValues.Data),
+		Values:    []PdoValue{},
+	}
+	for _, value := range pdoValues.Values {
+		response.Values = append(response.Values, PdoValue{
+			Index:    value.Index,
+			Subindex: value.Subindex,
+			Name:     value.Name,
+			Value:    value.Value,
+		})
+	}
+	return ctx.JSON(http.StatusOK, response)
+}
+
+// PostPDOTransmit handles the POST request for transmitting a RPDO
+func (h *Handler) PostPDOTransmit(ctx echo.Context, params apicanopenrest.PostPDOTransmitParams) error {
+	id, err := h.getNodeId(params.Node)
+	if err != nil {
+		return badRequest(ctx, err)
+	}
+	content := ctx.Request().Header.Get("Content-Type")
+	switch {
+	case strings.Contains(content, "application/json"):
+		var request []PdoValue
+		decoder := json.NewDecoder(ctx.Request().Body)
+		decoder.UseNumber()
+		err = decoder.Decode(&request)
+		if err != nil {
+			return badRequest(ctx, err)
+		}
+		values := []entities.PdoValue{}
+		for _, value := range request {
+			values = append(values, entities.PdoValue{
+				Index:    value.Index,
+				Subindex: value.Subindex,
+				Value:    value.Value,
+			})
+		}
+		err = h.canopenUC.TransmitPdoValues(ctx.Request().Context(), getBus(params.Bus), int(id), params.Number, values)
+	case strings.Contains(content, "application/octet-stream"):
+		var data []byte
+		data, err = io.ReadAll(ctx.Request().Body)
+		if err != nil {
+			return badRequest(ctx, err)
+		}
+		err = h.canopenUC.TransmitPdo(ctx.Request().Context(), getBus(params.Bus), int(id), params.Number, data)
+	case strings.Contains(content, "text/plain"):
+		var requestBytes, data []byte
+		requestBytes, err = io.ReadAll(ctx.Request().Body)
+		if err != nil {
+			return badRequest(ctx, err)
+		}
+		data, err = hex.DecodeString(strings.ReplaceAll(string(requestBytes), ":", ""))
+		if err != nil {
+			return badRequest(ctx, err)
+		}
+		err = h.canopenUC.TransmitPdo(ctx.Request().Context(), getBus(params.Bus), int(id), params.Number, data)
+	default:
+		return badRequest(ctx, fmt.Errorf("unsupported content type %q", content))
+	}
+	if err != nil {
+		return problem(ctx, err)
+	}
+	return ctx.NoContent(http.StatusOK)
+}
+
+func newPdoConfig(config entities.PdoConfig) PdoConfig {
+	pdoConfig := PdoConfig{
+		Direction:        config.Direction,
+		Number:           config.Number,
+		CobId:            config.CobId,
+		Enabled:          config.Enabled,
+		RtrAllowed:       config.RtrAllowed,
+		TransmissionType: config.TransmissionType,
+		InhibitTime:      config.InhibitTime,
+		EventTimer:       config.EventTimer,
+		SyncStart:        config.SyncStart,
+		Mapping:          []PdoMapping{},
+	}
+	for _, entry := range config.Mapping {
+		pdoConfig.Mapping = append(pdoConfig.Mapping, PdoMapping{
+			Index:     entry.Index,
+			Subindex:  entry.Subindex,
+			BitLength: entry.BitLength,
+			Name:      entry.Name,
+			DataType:  entry.DataType,
+		})
+	}
+	return pdoConfig
+}
+
+func (p PdoConfig) toEntity() entities.PdoConfig {
+	config := entities.PdoConfig{
+		Direction:        p.Direction,
+		Number:           p.Number,
+		CobId:            p.CobId,
+		Enabled:          p.Enabled,
+		RtrAllowed:       p.RtrAllowed,
+		TransmissionType: p.TransmissionType,
+		InhibitTime:      p.InhibitTime,
+		EventTimer:       p.EventTimer,
+		SyncStart:        p.SyncStart,
+		Mapping:          []entities.PdoMapping{},
+	}
+	for _, entry := range p.Mapping {
+		config.Mapping = append(config.Mapping, entities.PdoMapping{
+			Index:     entry.Index,
+			Subindex:  entry.Subindex,
+			BitLength: entry.BitLength,
+		})
+	}
+	return config
+}
+
+// problemTitles names the problem types by error kind
+var problemTitles = map[canopenuc.ErrorKind]string{
+	canopenuc.ERROR_NOT_FOUND:  "Not found",
+	canopenuc.ERROR_TIMEOUT:    "Timeout",
+	canopenuc.ERROR_SDO_ABORT:  "SDO transfer aborted",
+	canopenuc.ERROR_BUS:        "CAN bus error",
+	canopenuc.ERROR_VALIDATION: "Invalid request",
+	canopenuc.ERROR_CONFLICT:   "Conflict",
+}
+
+// problem logs err and answers with a problem details body, the status follows the kind of err.
+// Errors without kind are internal errors.
+func problem(ctx echo.Context, err error) error {
+	log.Error().Msg(err.Error())
+	kind := canopenuc.KindOf(err)
+	response := Problem{
+		Type:     "about:blank",
+		Title:    http.StatusText(http.StatusInternalServerError),
+		Status:   http.StatusInternalServerError,
+		Detail:   err.Error(),
+		Instance: ctx.Request().URL.Path,
+	}
+	if title, ok := problemTitles[kind]; ok {
+		response.Type = PROBLEM_TYPE_PREFIX + string(kind)
+		response.Title = title
+		response.Kind = string(kind)
+	}
+	switch kind {
+	case canopenuc.ERROR_NOT_FOUND:
+		response.Status = http.StatusNotFound
+	case canopenuc.ERROR_TIMEOUT:
+		response.Status = http.StatusGatewayTimeout
+	case canopenuc.ERROR_BUS:
+		response.Status = http.StatusServiceUnavailable
+	case canopenuc.ERROR_VALIDATION:
+		response.Status = http.StatusBadRequest
+	case canopenuc.ERROR_CONFLICT:
+		response.Status = http.StatusConflict
+	}
+	var sdoAbort *canopenuc.SdoAbortError
+	if errors.As(err, &sdoAbort) {
+		if kind == canopenuc.ERROR_SDO_ABORT {
+			response.Status = sdoAbortStatus(sdoAbort.Code)
+		}
+		response.SdoAbortCode = fmt.Sprintf("0x%08X", sdoAbort.Code)
+		response.SdoAbortText = sdoAbort.Text()
+		response.Index = common.POINTER(sdoAbort.Index)
+		response.Subindex = common.POINTER(sdoAbort.Subindex)
+	}
+	var validationErr *entities.EdsValidationError
+	if errors.As(err, &validationErr) {
+		response.Issues = newEdsValidation(validationErr.Issues).Issues
+	}
+	return writeProblem(ctx, response)
+}
+
+// badRequest answers a request the handler cannot parse with a problem details body
+func badRequest(ctx echo.Context, err error) error {
+	log.Error().Msg(err.Error())
+	return writeProblem(ctx, Problem{
+		Type:     PROBLEM_TYPE_PREFIX + string(canopenuc.ERROR_VALIDATION),
+		Title:    problemTitles[canopenuc.ERROR_VALIDATION],
+		Status:   http.StatusBadRequest,
+		Detail:   err.Error(),
+		Instance: ctx.Request().URL.Path,
+		Kind:     string(canopenuc.ERROR_VALIDATION),
+	})
+}
+
+func writeProblem(ctx echo.Context, response Problem) error {
+	body, err := json.Marshal(response)
+	if err != nil {
+		return err
+	}
+	return ctx.Blob(response.Status, PROBLEM_CONTENT_TYPE, body)
+}
+
+// sdoAbortStatus maps the abort code of a node to a status. Requests to missing objects are not
+// found, rejected access and values are bad requests, others are failures of the node.
+func sdoAbortStatus(code uint32) int {
+	switch {
+	case code == 0x06020000 || code == 0x06090011:
+		return http.StatusNotFound
+	case code == 0x08000021 || code == 0x08000022:
+		return http.StatusConflict
+	case code>>16 == 0x0601 || code>>16 == 0x0604 || code>>16 == 0x0607 || code>>16 == 0x0609:
+		return http.StatusBadRequest
+	}
+	return http.StatusBadGateway
 }
 
 func (h *Handler) getIntFromHex(hexStr string) (int64, error) {
@@ -301,6 +1729,51 @@ func (h *Handler) getIntFromHex(hexStr string) (int64, error) {
 	return strconv.ParseInt(numberStr, 16, 64)
 }
 
+// getNodeId parses a node id as hex and checks it is in the range 1-127
+func (h *Handler) getNodeId(hexStr string) (int64, error) {
+	id, err := h.getIntFromHex(hexStr)
+	if err != nil {
+		return 0, err
+	}
+	if id < 1 || id > 127 {
+		return 0, fmt.Errorf("node id %s out of range 0x01-0x7F", hexStr)
+	}
+	return id, nil
+}
+
+// getObjectAddress returns index and subindex of the query or resolves the parameter name of the eds
+func (h *Handler) getObjectAddress(ctx context.Context, bus string, id int, index *string, subindex *int, name *string) (uint16, uint8, error) {
+	if name != nil {
+		return h.canopenUC.FindObjectByName(ctx, bus, id, *name)
+	}
+	if index == nil {
+		return 0, 0, &canopenuc.Error{Kind: canopenuc.ERROR_VALIDATION, Err: errors.New("index or name required")}
+	}
+	i, err := h.getIntFromHex(*index)
+	if err != nil {
+		return 0, 0, &canopenuc.Error{Kind: canopenuc.ERROR_VALIDATION, Err: err}
+	}
+	if i < 0 || i > 0xFFFF {
+		return 0, 0, &canopenuc.Error{Kind: canopenuc.ERROR_VALIDATION, Err: fmt.Errorf("index %s out of range 0x0000-0xFFFF", *index)}
+	}
+	sub := uint8(0)
+	if subindex != nil {
+		if *subindex < 0 || *subindex > 0xFF {
+			return 0, 0, &canopenuc.Error{Kind: canopenuc.ERROR_VALIDATION, Err: fmt.Errorf("subindex %d out of range 0-255", *subindex)}
+		}
+		sub = uint8(*subindex)
+	}
+	return uint16(i), sub, nil
+}
+
+// getBus returns the requested bus name, empty selects the default bus
+func getBus(bus *string) string {
+	if bus == nil {
+		return ""
+	}
+	return *bus
+}
+
 func addSpacerToHex(hexString string, spacer string) string {
 	var result strings.Builder
 	for i := 0; i < len(hexString); i += 2 {
@@ -0,0 +1,245 @@
+package canopenrest
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jaster-prj/canopenrest/entities"
+	"github.com/jaster-prj/canopenrest/external/echoserver"
+	"github.com/jaster-prj/canopenrest/external/simulator/simtest"
+	"github.com/jaster-prj/canopenrest/usecases/canopenuc"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	TEST_BASE_URL = "/canopenrest/api/v1"
+	TEST_NODE     = 0x10
+)
+
+// newTestServer returns a server of the REST api for a virtual bus with one simulated node
+func newTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	uc := simtest.NewCanOpenUC(t, simtest.NewBus(t, TEST_NODE))
+	handler, err := NewHandler(uc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	echoServer := echo.New()
+	echoserver.NewService(echoServer, []echoserver.IRegisterer{
+		CreateEndpointRegisterer(handler, TEST_BASE_URL),
+	}).
+		WithRequestTimeout().
+		RegisterUrls()
+	server := httptest.NewServer(echoServer)
+	t.Cleanup(server.Close)
+	return server
+}
+
+// request sends a request to the api and returns the status, content type and body of the response
+func request(t *testing.T, server *httptest.Server, method string, url string, header http.Header, body io.Reader) (int, string, []byte) {
+	t.Helper()
+	req, err := http.NewRequest(method, server.URL+TEST_BASE_URL+url, body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	resp, err := server.Client().Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp.StatusCode, resp.Header.Get(echo.HeaderContentType), respBody
+}
+
+func TestProblemDetails(t *testing.T) {
+	server := newTestServer(t)
+	jsonHeader := http.Header{echo.HeaderAccept: []string{echo.MIMEApplicationJSON}}
+
+	tests := []struct {
+		name         string
+		method       string
+		url          string
+		header       http.Header
+		status       int
+		kind         string
+		sdoAbortCode string
+	}{
+		{
+			name:         "missing object",
+			method:       http.MethodGet,
+			url:          "/sdo?node=10&index=2FFF&subindex=0",
+			header:       http.Header{echo.HeaderAccept: []string{echo.MIMETextPlain}},
+			status:       http.StatusNotFound,
+			kind:         string(canopenuc.ERROR_SDO_ABORT),
+			sdoAbortCode: "0x06020000",
+		},
+		{
+			name:   "unknown bus",
+			method: http.MethodGet,
+			url:    "/sdo?node=10&index=1017&subindex=0&bus=unknown",
+			header: jsonHeader,
+			status: http.StatusNotFound,
+			kind:   string(canopenuc.ERROR_NOT_FOUND),
+		},
+		{
+			name:   "node out of range",
+			method: http.MethodGet,
+			url:    "/nmt?node=80",
+			status: http.StatusBadRequest,
+			kind:   string(canopenuc.ERROR_VALIDATION),
+		},
+		{
+			name:   "index out of range",
+			method: http.MethodGet,
+			url:    "/sdo?node=10&index=11000&subindex=0",
+			header: jsonHeader,
+			status: http.StatusBadRequest,
+			kind:   string(canopenuc.ERROR_VALIDATION),
+		},
+		{
+			name:   "invalid request timeout",
+			method: http.MethodGet,
+			url:    "/nmt?node=10",
+			header: http.Header{"Request-Timeout": []string{"soon"}},
+			status: http.StatusBadRequest,
+			kind:   string(canopenuc.ERROR_VALIDATION),
+		},
+		{
+			name:   "unknown flash order",
+			method: http.MethodGet,
+			url:    "/flash?id=6f1c1a52-0a8e-4d53-9a55-1f0c1d6e2b11",
+			status: http.StatusNotFound,
+			kind:   string(canopenuc.ERROR_NOT_FOUND),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, contentType, body := request(t, server, tt.method, tt.url, tt.header, nil)
+			if status != tt.status {
+				t.Errorf("status %d, want %d: %s", status, tt.status, body)
+			}
+			if !strings.HasPrefix(contentType, "application/problem+json") {
+				t.Errorf("content type %s, want application/problem+json", contentType)
+			}
+			problem := Problem{}
+			err := json.Unmarshal(body, &problem)
+			if err != nil {
+				t.Fatalf("decode problem %s: %v", body, err)
+			}
+			if problem.Status != tt.status {
+				t.Errorf("problem status %d, want %d", problem.Status, tt.status)
+			}
+			if problem.Kind != tt.kind {
+				t.Errorf("problem kind %s, want %s", problem.Kind, tt.kind)
+			}
+			if problem.SdoAbortCode != tt.sdoAbortCode {
+				t.Errorf("sdo abort code %s, want %s", problem.SdoAbortCode, tt.sdoAbortCode)
+			}
+		})
+	}
+}
+
+func TestSdoWriteRead(t *testing.T) {
+	server := newTestServer(t)
+
+	header := http.Header{echo.HeaderContentType: []string{echo.MIMEApplicationJSON}}
+	status, _, body := request(t, server, http.MethodPost, "/sdo?node=10&index=1017&subindex=0", header, strings.NewReader(`{"value":750}`))
+	if status != http.StatusOK {
+		t.Fatalf("write status %d: %s", status, body)
+	}
+	header = http.Header{echo.HeaderAccept: []string{echo.MIMEApplicationJSON}}
+	status, _, body = request(t, server, http.MethodGet, "/sdo?node=10&index=1017&subindex=0", header, nil)
+	if status != http.StatusOK {
+		t.Fatalf("read status %d: %s", status, body)
+	}
+	value := SdoValue{}
+	err := json.Unmarshal(body, &value)
+	if err != nil {
+		t.Fatalf("decode %s: %v", body, err)
+	}
+	if value.Value != float64(750) {
+		t.Errorf("read %v, want 750", value.Value)
+	}
+}
+
+func TestNmtWriteRead(t *testing.T) {
+	server := newTestServer(t)
+
+	// wait for the boot-up before switching the state
+	status, _, body := request(t, server, http.MethodGet, "/nmt?node=10", nil, nil)
+	if status != http.StatusOK {
+		t.Fatalf("read status %d: %s", status, body)
+	}
+	status, _, body = request(t, server, http.MethodPost, "/nmt?node=10", nil, strings.NewReader("OPERATIONAL"))
+	if status != http.StatusOK {
+		t.Fatalf("write status %d: %s", status, body)
+	}
+	status, _, body = request(t, server, http.MethodGet, "/nmt?node=10", nil, nil)
+	if status != http.StatusOK {
+		t.Fatalf("read status %d: %s", status, body)
+	}
+	state := ""
+	err := json.Unmarshal(body, &state)
+	if err != nil {
+		t.Fatalf("decode %s: %v", body, err)
+	}
+	if state != "OPERATIONAL" {
+		t.Errorf("read %s, want OPERATIONAL", state)
+	}
+}
+
+func TestFlashCancel(t *testing.T) {
+	server := newTestServer(t)
+
+	header := http.Header{echo.HeaderContentType: []string{echo.MIMEOctetStream}}
+	flashData := bytes.Repeat([]byte{0x5A}, 1<<20)
+	status, _, body := request(t, server, http.MethodPost, "/flash?node=10", header, bytes.NewReader(flashData))
+	if status != http.StatusCreated {
+		t.Fatalf("flash status %d: %s", status, body)
+	}
+	id := string(body)
+
+	status, _, body = request(t, server, http.MethodDelete, "/flash?id="+id, nil, nil)
+	if status != http.StatusOK {
+		t.Fatalf("cancel status %d: %s", status, body)
+	}
+	deadline := time.Now().Add(20 * time.Second)
+	order := FlashOrderState{}
+	for !order.State.Finished() {
+		if time.Now().After(deadline) {
+			t.Fatalf("flash order %s not finished, state %s", id, order.State.String())
+		}
+		time.Sleep(50 * time.Millisecond)
+		status, _, body = request(t, server, http.MethodGet, "/flash?id="+id, nil, nil)
+		if status != http.StatusOK {
+			t.Fatalf("state status %d: %s", status, body)
+		}
+		err := json.Unmarshal(body, &order)
+		if err != nil {
+			t.Fatalf("decode %s: %v", body, err)
+		}
+	}
+	if order.State != entities.FlashCancelled {
+		t.Errorf("state %s, want %s", order.State.String(), entities.FlashState(entities.FlashCancelled).String())
+	}
+
+	status, contentType, body := request(t, server, http.MethodDelete, "/flash?id="+id, nil, nil)
+	if status != http.StatusConflict {
+		t.Errorf("cancel finished order status %d, want %d: %s", status, http.StatusConflict, body)
+	}
+	if !strings.HasPrefix(contentType, "application/problem+json") {
+		t.Errorf("content type %s, want application/problem+json", contentType)
+	}
+}
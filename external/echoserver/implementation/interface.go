@@ -1,17 +1,72 @@
 package implementation
 
 import (
+	"context"
+	"io"
+	"time"
+
 	"github.com/google/uuid"
 	"github.com/jaster-prj/canopenrest/entities"
 )
 
 // ICanopenRest interface represents use case handlers for the CanOpenRest UseCase
 type ICanopenRest interface {
-	ReadNmt(node int) (*string, error)
-	WriteNmt(node int, state string) error
-	ReadSDO(node int, index uint16, subindex uint8) ([]byte, error)
-	WriteSDO(node int, index uint16, subindex uint8, data []byte) error
-	CreateNode(id int, edsFile []byte) error
-	FlashNode(id int, flashFile []byte, version *string) (*uuid.UUID, error)
-	GetFlashState(id uuid.UUID) (*entities.FlashOrderState, error)
+	GetBuses() []string
+	ReadNmt(ctx context.Context, bus string, node int) (*string, error)
+	WriteNmt(ctx context.Context, bus string, node int, state string) error
+	LssSwitchStateGlobal(ctx context.Context, bus string, mode entities.LssMode) error
+	LssSwitchStateSelective(ctx context.Context, bus string, identity entities.LssIdentity) error
+	LssConfigureNodeId(ctx context.Context, bus string, id int) error
+	LssConfigureBitTiming(ctx context.Context, bus string, bitrate int) error
+	LssActivateBitTiming(ctx context.Context, bus string, switchDelay time.Duration) error
+	LssStoreConfiguration(ctx context.Context, bus string) error
+	LssInquireIdentity(ctx context.Context, bus string) (*entities.LssIdentity, error)
+	LssInquireNodeId(ctx context.Context, bus string) (int, error)
+	LssFastscan(ctx context.Context, bus string) (*entities.LssIdentity, error)
+	ReadSDO(ctx context.Context, bus string, node int, index uint16, subindex uint8) ([]byte, error)
+	WriteSDO(ctx context.Context, bus string, node int, index uint16, subindex uint8, data []byte) error
+	ReadSDOStream(ctx context.Context, bus string, node int, index uint16, subindex uint8, writer io.Writer, progress entities.SdoProgress) (int64, error)
+	WriteSDOStream(ctx context.Context, bus string, node int, index uint16, subindex uint8, reader io.Reader, size int64, progress entities.SdoProgress) error
+	ReadSDOValue(ctx context.Context, bus string, node int, index uint16, subindex uint8) (*entities.SdoValue, error)
+	WriteSDOValue(ctx context.Context, bus string, node int, index uint16, subindex uint8, value any, dataType string) error
+	CreateNode(ctx context.Context, bus string, id int, edsFile []byte) error
+	GetNodes(ctx context.Context, bus string) ([]entities.NodeInfo, error)
+	GetNode(ctx context.Context, bus string, id int) (*entities.NodeInfo, error)
+	ReplaceNode(ctx context.Context, bus string, id int, edsFile []byte) error
+	DeleteNode(ctx context.Context, bus string, id int) error
+	ValidateEds(edsFile []byte) []entities.EdsIssue
+	GetParameterCapabilities(ctx context.Context, bus string, id int) ([]entities.ParameterCapability, error)
+	StoreParameters(ctx context.Context, bus string, id int, group entities.ParameterGroup, reset entities.ParameterReset) error
+	RestoreDefaultParameters(ctx context.Context, bus string, id int, group entities.ParameterGroup, reset entities.ParameterReset) error
+	BackupNode(ctx context.Context, bus string, id int, comment string) (*entities.ParameterSnapshot, error)
+	GetSnapshots(ctx context.Context, bus string, id int) ([]entities.ParameterSnapshot, error)
+	GetSnapshot(ctx context.Context, bus string, id int, version int) (*entities.ParameterSnapshot, error)
+	DeleteSnapshot(ctx context.Context, bus string, id int, version int) error
+	RestoreSnapshot(ctx context.Context, bus string, id int, version int, store bool) (*entities.ConfigurationReport, error)
+	DiffSnapshot(ctx context.Context, bus string, id int, version int, against *int) ([]entities.ParameterDiff, error)
+	DownloadConfiguration(ctx context.Context, bus string, id int, dcfFile []byte, overrides []entities.ConfigurationValue, store bool) (*entities.ConfigurationReport, error)
+	GetEds(ctx context.Context, bus string, node int) ([]byte, error)
+	GetObjectDictionary(ctx context.Context, bus string, node int) ([]entities.OdObject, error)
+	FindObjectByName(ctx context.Context, bus string, node int, name string) (uint16, uint8, error)
+	FlashNode(ctx context.Context, bus string, id int, flashFile io.Reader, version *string, requester string) (*uuid.UUID, error)
+	GetFlashState(ctx context.Context, id uuid.UUID) (*entities.FlashOrderState, error)
+	CancelFlash(ctx context.Context, id uuid.UUID) (*entities.FlashOrderState, error)
+	GetFlashOrders(ctx context.Context, filter entities.FlashOrderFilter, offset int, limit int) ([]entities.FlashOrderState, int, error)
+	SubscribeFlashEvents() (<-chan entities.FlashEvent, func())
+	UploadImage(ctx context.Context, image entities.FirmwareImage, content io.Reader) (*entities.FirmwareImage, error)
+	GetImages(ctx context.Context, productCode *uint32) ([]entities.FirmwareImage, error)
+	GetImage(ctx context.Context, id string) (*entities.FirmwareImage, error)
+	DeleteImage(ctx context.Context, id string) error
+	FlashImage(ctx context.Context, imageId string, bus string, ids []int, requester string) ([]uuid.UUID, error)
+	GetNodesStatus(ctx context.Context, bus string) ([]entities.NodeStatus, error)
+	SubscribeNodeEvents() (<-chan entities.NodeEvent, func())
+	ScanNetwork(ctx context.Context, bus string, timeout time.Duration) ([]entities.ScanResult, error)
+	GetEmcy(ctx context.Context, bus string, node int) ([]entities.Emcy, error)
+	ClearEmcy(ctx context.Context, bus string, node int) error
+	ReadPdos(ctx context.Context, bus string, node int, direction entities.PdoDirection) ([]entities.PdoConfig, error)
+	ReadPdo(ctx context.Context, bus string, node int, direction entities.PdoDirection, number int) (*entities.PdoConfig, error)
+	WritePdo(ctx context.Context, bus string, node int, config entities.PdoConfig) (*entities.PdoConfig, error)
+	GetPdoValues(ctx context.Context, bus string, node int, number int) (*entities.PdoValues, error)
+	TransmitPdo(ctx context.Context, bus string, node int, number int, data []byte) error
+	TransmitPdoValues(ctx context.Context, bus string, node int, number int, values []entities.PdoValue) error
 }
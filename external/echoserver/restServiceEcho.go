@@ -1,9 +1,14 @@
 package echoserver
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
 	"os"
 	"path/filepath"
+	"strconv"
+	"time"
 
 	log "github.com/rs/zerolog/log"
 
@@ -11,6 +16,14 @@ import (
 	echomiddleware "github.com/labstack/echo/v4/middleware"
 )
 
+const (
+	// REQUEST_TIMEOUT_HEADER sets the deadline of a request in milliseconds
+	REQUEST_TIMEOUT_HEADER = "Request-Timeout"
+	// REQUEST_TIMEOUT_PARAM sets the deadline of a request in milliseconds if the header is missing
+	REQUEST_TIMEOUT_PARAM = "requestTimeout"
+	PROBLEM_CONTENT_TYPE  = "application/problem+json"
+)
+
 // EndpointGroup manage single api endpoint
 type EndpointGroup struct {
 	group   *echo.Group
@@ -81,7 +94,7 @@ func (s *Service) WithSwaggerUi(baseUrl string) *Service {
 // WithCors adds CORS middleware to the service
 func (s *Service) WithCors() *Service {
 	corsConfig := echomiddleware.CORSConfig{
-		AllowHeaders: []string{echo.HeaderOrigin, echo.HeaderContentType, echo.HeaderAccept},
+		AllowHeaders: []string{echo.HeaderOrigin, echo.HeaderContentType, echo.HeaderAccept, REQUEST_TIMEOUT_HEADER},
 		AllowOrigins: []string{"*"},
 	}
 	s.server.Use(
@@ -90,6 +103,51 @@ func (s *Service) WithCors() *Service {
 	return s
 }
 
+// WithRequestTimeout lets clients set a deadline for their requests by the Request-Timeout header
+// or the requestTimeout query parameter in milliseconds. CAN transactions still running at the
+// deadline are aborted.
+func (s *Service) WithRequestTimeout() *Service {
+	for _, endpointGroup := range s.endpointGroups {
+		endpointGroup.group.Use(
+			func(next echo.HandlerFunc) echo.HandlerFunc {
+				return func(c echo.Context) error {
+					timeout := c.Request().Header.Get(REQUEST_TIMEOUT_HEADER)
+					if timeout == "" {
+						timeout = c.QueryParam(REQUEST_TIMEOUT_PARAM)
+					}
+					if timeout == "" {
+						return next(c)
+					}
+					ms, err := strconv.Atoi(timeout)
+					if err != nil || ms <= 0 {
+						log.Error().Msgf("invalid request timeout %q", timeout)
+						return c.Blob(http.StatusBadRequest, PROBLEM_CONTENT_TYPE, invalidRequestTimeout(c, timeout))
+					}
+					ctx, cancel := context.WithTimeout(c.Request().Context(), time.Duration(ms)*time.Millisecond)
+					defer cancel()
+					c.SetRequest(c.Request().WithContext(ctx))
+					return next(c)
+				}
+			},
+		)
+	}
+	return s
+}
+
+// invalidRequestTimeout returns the problem details (RFC 7807) of a request with an invalid timeout,
+// like the validation problems of the handlers
+func invalidRequestTimeout(c echo.Context, timeout string) []byte {
+	body, _ := json.Marshal(map[string]any{
+		"type":     "urn:canopenrest:problem:validation",
+		"title":    "Invalid request",
+		"status":   http.StatusBadRequest,
+		"detail":   fmt.Sprintf("invalid request timeout %q: positive milliseconds expected", timeout),
+		"instance": c.Request().URL.Path,
+		"kind":     "validation",
+	})
+	return body
+}
+
 // Add middleware to log request content
 func (s *Service) WithDebug() *Service {
 	s.server.Use(
@@ -1,13 +1,17 @@
 package filestorage
 
 import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
-	"fmt"
 	"io"
 	"io/fs"
 	"os"
 	"path"
+	"sort"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -48,32 +52,37 @@ func NewFilestorage() (*Filestorage, error) {
 	}, nil
 }
 
-func (f *Filestorage) SafeNode(id int, odsFile []byte) error {
-	nodeDir := path.Join(f.configDir, strconv.Itoa(id))
+func (f *Filestorage) SafeNode(bus string, id int, odsFile []byte) error {
+	nodeDir := f.nodeDir(bus, id)
 	_, err := os.Stat(nodeDir)
 	if errors.Is(err, fs.ErrNotExist) {
-		os.Mkdir(nodeDir, 0700)
+		err = os.MkdirAll(nodeDir, 0700)
+		if err != nil {
+			return err
+		}
 	} else if err != nil {
 		return err
 	}
-	nodeFile := path.Join(nodeDir, "objdict.eds")
-	file, err := os.OpenFile(nodeFile, os.O_CREATE|os.O_WRONLY, 0644)
-	if err != nil {
-		return err
-	}
-	defer file.Close()
+	_, err = writeFile(path.Join(nodeDir, "objdict.eds"), bytes.NewReader(odsFile))
+	return err
+}
 
-	// Write the bytes to the file
-	if _, err := file.Write(odsFile); err != nil {
+// DeleteNode removes the stored eds of a node
+func (f *Filestorage) DeleteNode(bus string, id int) error {
+	nodeDir := f.nodeDir(bus, id)
+	_, err := os.Stat(nodeDir)
+	if err != nil {
 		return err
 	}
-	return nil
+	return os.RemoveAll(nodeDir)
 }
 
-func (f *Filestorage) GetNodes() ([]int, error) {
+func (f *Filestorage) GetNodes(bus string) ([]int, error) {
 	nodes := []int{}
-	entries, err := os.ReadDir(f.configDir)
-	if err != nil {
+	entries, err := os.ReadDir(f.busDir(bus))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nodes, nil
+	} else if err != nil {
 		return nodes, err
 	}
 	for _, entry := range entries {
@@ -88,8 +97,8 @@ func (f *Filestorage) GetNodes() ([]int, error) {
 	return nodes, nil
 }
 
-func (f *Filestorage) GetObjDict(id int) ([]byte, error) {
-	nodeDir := path.Join(f.configDir, strconv.Itoa(id))
+func (f *Filestorage) GetObjDict(bus string, id int) ([]byte, error) {
+	nodeDir := f.nodeDir(bus, id)
 	_, err := os.Stat(nodeDir)
 	if err != nil {
 		return []byte{}, err
@@ -107,98 +116,581 @@ func (f *Filestorage) GetObjDict(id int) ([]byte, error) {
 	return objdict, nil
 }
 
-func (f *Filestorage) GetFlashState(id uuid.UUID) (*entities.FlashOrderState, error) {
-	flashDir := path.Join(f.configDir, "flash")
-	_, err := os.Stat(flashDir)
+// MigrateNodes moves node directories of the single bus layout into the directory of the given bus
+func (f *Filestorage) MigrateNodes(bus string) error {
+	entries, err := os.ReadDir(f.configDir)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	var flash persistence.FlashPersistence
-	filePath := path.Join(flashDir, id.String())
-	_, err = os.Stat(filePath)
-	if err != nil {
-		return nil, err
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		nodeId, err := strconv.Atoi(entry.Name())
+		if err != nil {
+			continue
+		}
+		nodeDir := f.nodeDir(bus, nodeId)
+		if _, err := os.Stat(nodeDir); err == nil {
+			continue
+		}
+		err = os.MkdirAll(f.busDir(bus), 0700)
+		if err != nil {
+			return err
+		}
+		err = os.Rename(path.Join(f.configDir, entry.Name()), nodeDir)
+		if err != nil {
+			return err
+		}
 	}
-	file, err := os.Open(filePath)
+	return nil
+}
+
+// CreateFlashOrder records a new flash order in state FlashRequested
+func (f *Filestorage) CreateFlashOrder(order entities.FlashOrder) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.writeFlash(order.FlashOrderId, &persistence.FlashPersistence{
+		Bus:       order.Bus,
+		Node:      order.Id,
+		Version:   order.Version,
+		ImageHash: order.ImageHash,
+		ImageId:   order.ImageId,
+		Size:      order.Size,
+		Requester: order.Requester,
+		Requested: time.Now(),
+		State:     entities.FlashRequested,
+	})
+}
+
+func (f *Filestorage) GetFlashState(id uuid.UUID) (*entities.FlashOrderState, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	flash, err := f.readFlash(id)
 	if err != nil {
 		return nil, err
 	}
-	defer file.Close()
-	data, err := io.ReadAll(file)
-	if err != nil {
+	return newFlashOrderState(id, flash), nil
+}
+
+// GetFlashStates returns the states of all flash orders in no particular order
+func (f *Filestorage) GetFlashStates() ([]entities.FlashOrderState, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	states := []entities.FlashOrderState{}
+	entries, err := os.ReadDir(path.Join(f.configDir, "flash"))
+	if errors.Is(err, fs.ErrNotExist) {
+		return states, nil
+	} else if err != nil {
 		return nil, err
 	}
-	err = yaml.Unmarshal(data, &flash)
-	if err != nil {
-		return nil, err
+	for _, entry := range entries {
+		// Flash files (.bin) and temporary files are stored next to the orders
+		id, err := uuid.Parse(entry.Name())
+		if err != nil || entry.IsDir() {
+			continue
+		}
+		flash, err := f.readFlash(id)
+		if err != nil {
+			return nil, err
+		}
+		states = append(states, *newFlashOrderState(id, flash))
 	}
-	return &entities.FlashOrderState{
+	return states, nil
+}
+
+func newFlashOrderState(id uuid.UUID, flash *persistence.FlashPersistence) *entities.FlashOrderState {
+	state := &entities.FlashOrderState{
+		Order: entities.FlashOrder{
+			FlashOrderId: id,
+			Bus:          flash.Bus,
+			Id:           flash.Node,
+			Size:         flash.Size,
+			Version:      flash.Version,
+			ImageHash:    flash.ImageHash,
+			ImageId:      flash.ImageId,
+			Requester:    flash.Requester,
+		},
 		Requested: flash.Requested,
 		Start:     flash.Start,
 		Finish:    flash.Finish,
 		State:     flash.State,
 		Error:     flash.Error,
-	}, nil
+	}
+	if flash.Progress != nil {
+		state.Progress = &entities.FlashProgress{
+			Transferred: flash.Progress.Transferred,
+			Total:       flash.Progress.Total,
+			Rate:        flash.Progress.Rate,
+			Eta:         flash.Progress.Eta,
+			Updated:     flash.Progress.Updated,
+		}
+	}
+	return state
 }
 
 func (f *Filestorage) SetFlashState(id uuid.UUID, state entities.FlashState, errState *error) error {
 	f.mu.Lock()
 	defer f.mu.Unlock()
 
-	flashDir := path.Join(f.configDir, "flash")
-	_, err := os.Stat(flashDir)
+	flash, err := f.readFlash(id)
 	if errors.Is(err, fs.ErrNotExist) {
-		os.Mkdir(flashDir, 0700)
+		flash = &persistence.FlashPersistence{}
 	} else if err != nil {
 		return err
 	}
-	var flash persistence.FlashPersistence
-	filePath := path.Join(flashDir, id.String())
-	_, err = os.Stat(filePath)
-	if err == nil {
-		file, err := os.Open(filePath)
-		if err != nil {
-			return err
-		}
-		data, err := io.ReadAll(file)
-		if err != nil {
-			file.Close()
-			return err
-		}
-		file.Close()
-		err = yaml.Unmarshal(data, &flash)
-		if err != nil {
-			return err
-		}
-	}
-
 	switch state {
 	case entities.FlashRequested:
 		flash.Requested = time.Now()
 	case entities.FlashProgramStopBefore:
 		flash.Start = common.POINTER(time.Now())
-	case entities.FlashProgramFinish:
-		flash.Finish = common.POINTER(time.Now())
-	case entities.FlashProgramError:
+	case entities.FlashProgramFinish, entities.FlashProgramError, entities.FlashCancelled:
 		flash.Finish = common.POINTER(time.Now())
 	}
 	flash.State = state
 	if errState != nil {
-		flash.Error = common.POINTER(fmt.Sprintf("%w", *errState))
+		flash.Error = common.POINTER((*errState).Error())
+	}
+	return f.writeFlash(id, flash)
+}
+
+// SetFlashProgress records the download progress of a flash order
+func (f *Filestorage) SetFlashProgress(id uuid.UUID, progress entities.FlashProgress) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	flash, err := f.readFlash(id)
+	if err != nil {
+		return err
+	}
+	flash.Progress = &persistence.FlashProgressPersistence{
+		Transferred: progress.Transferred,
+		Total:       progress.Total,
+		Rate:        progress.Rate,
+		Eta:         progress.Eta,
+		Updated:     progress.Updated,
+	}
+	return f.writeFlash(id, flash)
+}
+
+func (f *Filestorage) readFlash(id uuid.UUID) (*persistence.FlashPersistence, error) {
+	data, err := os.ReadFile(path.Join(f.configDir, "flash", id.String()))
+	if err != nil {
+		return nil, err
+	}
+	var flash persistence.FlashPersistence
+	err = yaml.Unmarshal(data, &flash)
+	if err != nil {
+		return nil, err
+	}
+	return &flash, nil
+}
+
+func (f *Filestorage) writeFlash(id uuid.UUID, flash *persistence.FlashPersistence) error {
+	err := os.MkdirAll(path.Join(f.configDir, "flash"), 0700)
+	if err != nil {
+		return err
 	}
 	data, err := yaml.Marshal(flash)
 	if err != nil {
 		return err
 	}
-	file, err := os.OpenFile(filePath, os.O_CREATE|os.O_WRONLY, 0644)
+	_, err = writeFile(path.Join(f.configDir, "flash", id.String()), bytes.NewReader(data))
+	return err
+}
+
+// StoreFlashFile stores the flash file of an order and returns its size in bytes
+func (f *Filestorage) StoreFlashFile(id uuid.UUID, flashFile io.Reader) (int64, error) {
+	err := os.MkdirAll(path.Join(f.configDir, "flash"), 0700)
+	if err != nil {
+		return 0, err
+	}
+	return writeFile(f.flashFile(id), flashFile)
+}
+
+// OpenFlashFile opens the flash file of an order for reading
+func (f *Filestorage) OpenFlashFile(id uuid.UUID) (io.ReadCloser, error) {
+	return os.Open(f.flashFile(id))
+}
+
+// DeleteFlashFile removes the flash file of an order, a missing file is no error
+func (f *Filestorage) DeleteFlashFile(id uuid.UUID) error {
+	err := os.Remove(f.flashFile(id))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+// StoreImage stores a firmware image under the SHA-256 of its content. The content of an image stored
+// before is kept, its metadata is replaced by the metadata of image.
+func (f *Filestorage) StoreImage(image entities.FirmwareImage, content io.Reader) (*entities.FirmwareImage, error) {
+	err := os.MkdirAll(f.imageDir(), 0700)
+	if err != nil {
+		return nil, err
+	}
+	// Write to a temporary file first, the address of the content is known when it is read completely
+	file, err := os.CreateTemp(f.imageDir(), "upload-*.tmp")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(file.Name())
+	hash := sha256.New()
+	size, err := io.Copy(file, io.TeeReader(content, hash))
+	if err == nil {
+		err = file.Sync()
+	}
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	if err := file.Close(); err != nil {
+		return nil, err
+	}
+	image.Id = hex.EncodeToString(hash.Sum(nil))
+	image.Size = size
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, err := os.Stat(f.imageFile(image.Id)); errors.Is(err, fs.ErrNotExist) {
+		err = os.Rename(file.Name(), f.imageFile(image.Id))
+		if err != nil {
+			return nil, err
+		}
+	} else if err != nil {
+		return nil, err
+	}
+	data, err := yaml.Marshal(persistence.ImagePersistence{
+		ProductCode: image.ProductCode,
+		Version:     image.Version,
+		Signature:   image.Signature,
+		Size:        image.Size,
+		Uploaded:    image.Uploaded,
+	})
+	if err != nil {
+		return nil, err
+	}
+	_, err = writeFile(f.imageMetaFile(image.Id), bytes.NewReader(data))
+	return &image, err
+}
+
+// GetImages returns all firmware images in no particular order
+func (f *Filestorage) GetImages() ([]entities.FirmwareImage, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	images := []entities.FirmwareImage{}
+	entries, err := os.ReadDir(f.imageDir())
+	if errors.Is(err, fs.ErrNotExist) {
+		return images, nil
+	} else if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		id, ok := strings.CutSuffix(entry.Name(), ".yaml")
+		if !ok || entry.IsDir() {
+			continue
+		}
+		image, err := f.readImage(id)
+		if err != nil {
+			return nil, err
+		}
+		images = append(images, *image)
+	}
+	return images, nil
+}
+
+// GetImage returns the metadata of a firmware image
+func (f *Filestorage) GetImage(id string) (*entities.FirmwareImage, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.readImage(id)
+}
+
+func (f *Filestorage) readImage(id string) (*entities.FirmwareImage, error) {
+	data, err := os.ReadFile(f.imageMetaFile(id))
+	if err != nil {
+		return nil, err
+	}
+	var stored persistence.ImagePersistence
+	err = yaml.Unmarshal(data, &stored)
+	if err != nil {
+		return nil, err
+	}
+	return &entities.FirmwareImage{
+		Id:          id,
+		ProductCode: stored.ProductCode,
+		Version:     stored.Version,
+		Signature:   stored.Signature,
+		Size:        stored.Size,
+		Uploaded:    stored.Uploaded,
+	}, nil
+}
+
+// OpenImage opens the content of a firmware image for reading
+func (f *Filestorage) OpenImage(id string) (io.ReadCloser, error) {
+	return os.Open(f.imageFile(id))
+}
+
+// DeleteImage removes a firmware image with its content
+func (f *Filestorage) DeleteImage(id string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	err := os.Remove(f.imageMetaFile(id))
 	if err != nil {
 		return err
 	}
-	defer file.Close()
+	err = os.Remove(f.imageFile(id))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+// GetEmcyHistory returns the stored emergency messages of a node, oldest first
+func (f *Filestorage) GetEmcyHistory(bus string, id int) ([]entities.Emcy, error) {
+	history := []entities.Emcy{}
+	data, err := os.ReadFile(f.emcyFile(bus, id))
+	if errors.Is(err, fs.ErrNotExist) {
+		return history, nil
+	} else if err != nil {
+		return nil, err
+	}
+	var emcys []persistence.EmcyPersistence
+	err = yaml.Unmarshal(data, &emcys)
+	if err != nil {
+		return nil, err
+	}
+	for _, emcy := range emcys {
+		manufacturerData, err := hex.DecodeString(emcy.ManufacturerData)
+		if err != nil {
+			return nil, err
+		}
+		history = append(history, entities.Emcy{
+			Timestamp:        emcy.Timestamp,
+			ErrorCode:        emcy.ErrorCode,
+			ErrorRegister:    emcy.ErrorRegister,
+			ManufacturerData: manufacturerData,
+		})
+	}
+	return history, nil
+}
 
-	// Write the bytes to the file
-	if _, err := file.Write(data); err != nil {
+// SetEmcyHistory replaces the stored emergency messages of a node, an empty history removes them
+func (f *Filestorage) SetEmcyHistory(bus string, id int, history []entities.Emcy) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	filePath := f.emcyFile(bus, id)
+	if len(history) == 0 {
+		err := os.Remove(filePath)
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
 		return err
 	}
-	return nil
+	emcys := []persistence.EmcyPersistence{}
+	for _, emcy := range history {
+		emcys = append(emcys, persistence.EmcyPersistence{
+			Timestamp:        emcy.Timestamp,
+			ErrorCode:        emcy.ErrorCode,
+			ErrorRegister:    emcy.ErrorRegister,
+			ManufacturerData: hex.EncodeToString(emcy.ManufacturerData),
+		})
+	}
+	data, err := yaml.Marshal(emcys)
+	if err != nil {
+		return err
+	}
+	err = os.MkdirAll(path.Dir(filePath), 0700)
+	if err != nil {
+		return err
+	}
+	_, err = writeFile(filePath, bytes.NewReader(data))
+	return err
+}
+
+// AddSnapshot stores a parameter snapshot of a node under the next version and returns the version
+func (f *Filestorage) AddSnapshot(bus string, id int, snapshot entities.ParameterSnapshot) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	versions, err := f.snapshotVersions(bus, id)
+	if err != nil {
+		return 0, err
+	}
+	version := 1
+	if len(versions) > 0 {
+		version = versions[len(versions)-1] + 1
+	}
+	values := []persistence.SnapshotValuePersistence{}
+	for _, value := range snapshot.Values {
+		values = append(values, persistence.SnapshotValuePersistence{
+			Index:    value.Index,
+			Subindex: value.Subindex,
+			Name:     value.Name,
+			DataType: value.DataType,
+			Data:     hex.EncodeToString(value.Data),
+		})
+	}
+	data, err := yaml.Marshal(persistence.SnapshotPersistence{
+		Created: snapshot.Created,
+		Comment: snapshot.Comment,
+		Values:  values,
+	})
+	if err != nil {
+		return 0, err
+	}
+	err = os.MkdirAll(f.snapshotDir(bus, id), 0700)
+	if err != nil {
+		return 0, err
+	}
+	_, err = writeFile(f.snapshotFile(bus, id, version), bytes.NewReader(data))
+	return version, err
+}
+
+// GetSnapshots returns all parameter snapshots of a node, oldest first
+func (f *Filestorage) GetSnapshots(bus string, id int) ([]entities.ParameterSnapshot, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	versions, err := f.snapshotVersions(bus, id)
+	if err != nil {
+		return nil, err
+	}
+	snapshots := []entities.ParameterSnapshot{}
+	for _, version := range versions {
+		snapshot, err := f.readSnapshot(bus, id, version)
+		if err != nil {
+			return nil, err
+		}
+		snapshots = append(snapshots, *snapshot)
+	}
+	return snapshots, nil
+}
+
+// GetSnapshot returns a parameter snapshot of a node
+func (f *Filestorage) GetSnapshot(bus string, id int, version int) (*entities.ParameterSnapshot, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.readSnapshot(bus, id, version)
+}
+
+func (f *Filestorage) readSnapshot(bus string, id int, version int) (*entities.ParameterSnapshot, error) {
+	data, err := os.ReadFile(f.snapshotFile(bus, id, version))
+	if err != nil {
+		return nil, err
+	}
+	var stored persistence.SnapshotPersistence
+	err = yaml.Unmarshal(data, &stored)
+	if err != nil {
+		return nil, err
+	}
+	snapshot := &entities.ParameterSnapshot{
+		Bus:     bus,
+		Id:      id,
+		Version: version,
+		Created: stored.Created,
+		Comment: stored.Comment,
+		Values:  []entities.ParameterValue{},
+	}
+	for _, value := range stored.Values {
+		valueData, err := hex.DecodeString(value.Data)
+		if err != nil {
+			return nil, err
+		}
+		snapshot.Values = append(snapshot.Values, entities.ParameterValue{
+			Index:    value.Index,
+			Subindex: value.Subindex,
+			Name:     value.Name,
+			DataType: value.DataType,
+			Data:     valueData,
+		})
+	}
+	return snapshot, nil
+}
+
+// DeleteSnapshot removes a parameter snapshot of a node
+func (f *Filestorage) DeleteSnapshot(bus string, id int, version int) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return os.Remove(f.snapshotFile(bus, id, version))
+}
+
+func (f *Filestorage) snapshotVersions(bus string, id int) ([]int, error) {
+	versions := []int{}
+	entries, err := os.ReadDir(f.snapshotDir(bus, id))
+	if errors.Is(err, fs.ErrNotExist) {
+		return versions, nil
+	} else if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		version, err := strconv.Atoi(strings.TrimSuffix(entry.Name(), ".yaml"))
+		if err != nil || entry.IsDir() {
+			continue
+		}
+		versions = append(versions, version)
+	}
+	sort.Ints(versions)
+	return versions, nil
+}
+
+// writeFile writes content to a temporary file next to filePath and renames it once it is written
+// completely, a crash never leaves filePath partially written. It returns the number of bytes written.
+func writeFile(filePath string, content io.Reader) (int64, error) {
+	file, err := os.CreateTemp(path.Dir(filePath), "."+path.Base(filePath)+"-*.tmp")
+	if err != nil {
+		return 0, err
+	}
+	defer os.Remove(file.Name())
+	size, err := io.Copy(file, content)
+	if err == nil {
+		err = file.Chmod(0644)
+	}
+	if err == nil {
+		err = file.Sync()
+	}
+	if err != nil {
+		file.Close()
+		return 0, err
+	}
+	if err := file.Close(); err != nil {
+		return 0, err
+	}
+	return size, os.Rename(file.Name(), filePath)
+}
+
+func (f *Filestorage) snapshotDir(bus string, id int) string {
+	return path.Join(f.configDir, "backup", bus, strconv.Itoa(id))
+}
+
+func (f *Filestorage) snapshotFile(bus string, id int, version int) string {
+	return path.Join(f.snapshotDir(bus, id), strconv.Itoa(version)+".yaml")
+}
+
+func (f *Filestorage) flashFile(id uuid.UUID) string {
+	return path.Join(f.configDir, "flash", id.String()+".bin")
+}
+
+func (f *Filestorage) imageDir() string {
+	return path.Join(f.configDir, "images")
+}
+
+func (f *Filestorage) imageFile(id string) string {
+	return path.Join(f.imageDir(), id+".bin")
+}
+
+func (f *Filestorage) imageMetaFile(id string) string {
+	return path.Join(f.imageDir(), id+".yaml")
+}
+
+func (f *Filestorage) emcyFile(bus string, id int) string {
+	return path.Join(f.configDir, "emcy", bus, strconv.Itoa(id)+".yaml")
+}
+
+func (f *Filestorage) busDir(bus string) string {
+	return path.Join(f.configDir, "bus", bus)
+}
+
+func (f *Filestorage) nodeDir(bus string, id int) string {
+	return path.Join(f.busDir(bus), strconv.Itoa(id))
 }
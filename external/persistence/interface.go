@@ -1,14 +1,34 @@
 package persistence
 
 import (
+	"io"
+
 	"github.com/google/uuid"
 	"github.com/jaster-prj/canopenrest/entities"
 )
 
 type IPersistence interface {
-	SafeNode(id int, odsFile []byte) error
-	GetNodes() ([]int, error)
-	GetObjDict(id int) ([]byte, error)
+	SafeNode(bus string, id int, odsFile []byte) error
+	GetNodes(bus string) ([]int, error)
+	GetObjDict(bus string, id int) ([]byte, error)
+	DeleteNode(bus string, id int) error
+	CreateFlashOrder(order entities.FlashOrder) error
 	GetFlashState(id uuid.UUID) (*entities.FlashOrderState, error)
+	GetFlashStates() ([]entities.FlashOrderState, error)
 	SetFlashState(id uuid.UUID, state entities.FlashState, errState *error) error
+	SetFlashProgress(id uuid.UUID, progress entities.FlashProgress) error
+	StoreFlashFile(id uuid.UUID, flashFile io.Reader) (int64, error)
+	OpenFlashFile(id uuid.UUID) (io.ReadCloser, error)
+	DeleteFlashFile(id uuid.UUID) error
+	StoreImage(image entities.FirmwareImage, content io.Reader) (*entities.FirmwareImage, error)
+	GetImages() ([]entities.FirmwareImage, error)
+	GetImage(id string) (*entities.FirmwareImage, error)
+	OpenImage(id string) (io.ReadCloser, error)
+	DeleteImage(id string) error
+	GetEmcyHistory(bus string, id int) ([]entities.Emcy, error)
+	SetEmcyHistory(bus string, id int, history []entities.Emcy) error
+	AddSnapshot(bus string, id int, snapshot entities.ParameterSnapshot) (int, error)
+	GetSnapshots(bus string, id int) ([]entities.ParameterSnapshot, error)
+	GetSnapshot(bus string, id int, version int) (*entities.ParameterSnapshot, error)
+	DeleteSnapshot(bus string, id int, version int) error
 }
@@ -7,9 +7,54 @@ import (
 )
 
 type FlashPersistence struct {
-	Requested time.Time           `yaml:"requested"`
-	Start     *time.Time          `yaml:"start,omitempty"`
-	Finish    *time.Time          `yaml:"finish,omitempty"`
-	State     entities.FlashState `yaml:"state"`
-	Error     *string             `yaml:"error,omitempty"`
+	Bus       string                    `yaml:"bus,omitempty"`
+	Node      int                       `yaml:"node,omitempty"`
+	Version   *string                   `yaml:"version,omitempty"`
+	ImageHash string                    `yaml:"imageHash,omitempty"`
+	ImageId   string                    `yaml:"imageId,omitempty"`
+	Size      int64                     `yaml:"size,omitempty"`
+	Requester string                    `yaml:"requester,omitempty"`
+	Requested time.Time                 `yaml:"requested"`
+	Start     *time.Time                `yaml:"start,omitempty"`
+	Finish    *time.Time                `yaml:"finish,omitempty"`
+	State     entities.FlashState       `yaml:"state"`
+	Error     *string                   `yaml:"error,omitempty"`
+	Progress  *FlashProgressPersistence `yaml:"progress,omitempty"`
+}
+
+type FlashProgressPersistence struct {
+	Transferred int64         `yaml:"transferred"`
+	Total       int64         `yaml:"total"`
+	Rate        float64       `yaml:"rate"`
+	Eta         time.Duration `yaml:"eta"`
+	Updated     time.Time     `yaml:"updated"`
+}
+
+type ImagePersistence struct {
+	ProductCode *uint32   `yaml:"productCode,omitempty"`
+	Version     *string   `yaml:"version,omitempty"`
+	Signature   *string   `yaml:"signature,omitempty"`
+	Size        int64     `yaml:"size"`
+	Uploaded    time.Time `yaml:"uploaded"`
+}
+
+type EmcyPersistence struct {
+	Timestamp        time.Time `yaml:"timestamp"`
+	ErrorCode        uint16    `yaml:"errorCode"`
+	ErrorRegister    uint8     `yaml:"errorRegister"`
+	ManufacturerData string    `yaml:"manufacturerData,omitempty"`
+}
+
+type SnapshotPersistence struct {
+	Created time.Time                  `yaml:"created"`
+	Comment string                     `yaml:"comment,omitempty"`
+	Values  []SnapshotValuePersistence `yaml:"values"`
+}
+
+type SnapshotValuePersistence struct {
+	Index    uint16 `yaml:"index"`
+	Subindex uint8  `yaml:"subindex"`
+	Name     string `yaml:"name,omitempty"`
+	DataType string `yaml:"dataType"`
+	Data     string `yaml:"data"`
 }
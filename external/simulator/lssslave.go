@@ -0,0 +1,162 @@
+package simulator
+
+import (
+	"encoding/binary"
+)
+
+const (
+	LSS_MASTER_COB_ID        = 0x7E5
+	LSS_SLAVE_COB_ID         = 0x7E4
+	LSS_UNCONFIGURED_NODE_ID = 0xFF
+)
+
+const (
+	lssSwitchStateGlobal      = 0x04
+	lssConfigureNodeId        = 0x11
+	lssConfigureBitTiming     = 0x13
+	lssActivateBitTiming      = 0x15
+	lssStoreConfiguration     = 0x17
+	lssSwitchSelectiveVendor  = 0x40
+	lssSwitchSelectiveSerial  = 0x43
+	lssSwitchSelectiveResult  = 0x44
+	lssIdentifySlave          = 0x4F
+	lssFastscan               = 0x51
+	lssInquireVendor          = 0x5A
+	lssInquireSerial          = 0x5D
+	lssInquireNodeId          = 0x5E
+	lssFastscanConfirm        = 0x80
+	lssBitTimingTableSize     = 10
+	lssBitTimingTableReserved = 5
+)
+
+// lssSlave holds the LSS state of the slave
+type lssSlave struct {
+	configuration bool
+	// pendingNodeId is activated with the next reset communication
+	pendingNodeId int
+	storedNodeId  int
+	// selective counts the matched identity parts of switch state selective
+	selective int
+	// fastscanPos is the identity part checked by fastscan
+	fastscanPos int
+}
+
+// handleLss answers LSS requests, node id changes become active with reset communication
+// or, for an unconfigured node, when leaving the configuration state
+func (s *Slave) handleLss(request [8]byte) {
+	s.mu.Lock()
+	command := request[0]
+	identity := s.lssIdentity()
+	var response []byte
+	activate := false
+	switch {
+	case command == lssSwitchStateGlobal:
+		s.lss.configuration = request[1] == 1
+		activate = !s.lss.configuration && s.nodeId == LSS_UNCONFIGURED_NODE_ID && s.lss.pendingNodeId != LSS_UNCONFIGURED_NODE_ID
+	case command >= lssSwitchSelectiveVendor && command <= lssSwitchSelectiveSerial:
+		part := int(command - lssSwitchSelectiveVendor)
+		if part == 0 {
+			s.lss.selective = 0
+		}
+		if s.lss.selective == part && binary.LittleEndian.Uint32(request[1:5]) == identity[part] {
+			s.lss.selective++
+		} else {
+			s.lss.selective = 0
+		}
+		if s.lss.selective == 4 {
+			s.lss.selective = 0
+			s.lss.configuration = true
+			response = []byte{lssSwitchSelectiveResult}
+		}
+	case command == lssFastscan:
+		response = s.fastscan(request, identity)
+	case !s.lss.configuration:
+	case command == lssConfigureNodeId:
+		nodeId := int(request[1])
+		if (nodeId < 1 || nodeId > 127) && nodeId != LSS_UNCONFIGURED_NODE_ID {
+			response = []byte{command, 1}
+		} else {
+			s.lss.pendingNodeId = nodeId
+			response = []byte{command, 0}
+		}
+	case command == lssConfigureBitTiming:
+		if request[1] != 0 || request[2] >= lssBitTimingTableSize || request[2] == lssBitTimingTableReserved {
+			response = []byte{command, 1}
+		} else {
+			response = []byte{command, 0}
+		}
+	case command == lssActivateBitTiming:
+		// the virtual bus has no bit timing
+	case command == lssStoreConfiguration:
+		s.lss.storedNodeId = s.lss.pendingNodeId
+		response = []byte{command, 0}
+	case command >= lssInquireVendor && command <= lssInquireSerial:
+		response = binary.LittleEndian.AppendUint32([]byte{command}, identity[command-lssInquireVendor])
+	case command == lssInquireNodeId:
+		response = []byte{command, byte(s.nodeId)}
+	}
+	s.mu.Unlock()
+
+	if response != nil {
+		buf := make([]byte, 8)
+		copy(buf, response)
+		s.Send(LSS_SLAVE_COB_ID, buf)
+	}
+	if activate {
+		s.activateNodeId()
+		s.reset(true)
+	}
+}
+
+// fastscan answers if the checked bits of the identity part match, mu must be held.
+// Only unconfigured nodes take part in the fastscan.
+func (s *Slave) fastscan(request [8]byte, identity [4]uint32) []byte {
+	if s.lss.configuration || s.nodeId != LSS_UNCONFIGURED_NODE_ID {
+		return nil
+	}
+	idNumber := binary.LittleEndian.Uint32(request[1:5])
+	bitCheck, lssSub, lssNext := request[5], int(request[6]), int(request[7])
+	if bitCheck == lssFastscanConfirm {
+		s.lss.fastscanPos = 0
+		return []byte{lssIdentifySlave}
+	}
+	if bitCheck > 31 || lssSub > 3 || lssSub != s.lss.fastscanPos {
+		return nil
+	}
+	if (idNumber^identity[lssSub])&(0xFFFFFFFF<<bitCheck) != 0 {
+		return nil
+	}
+	s.lss.fastscanPos = lssNext
+	if bitCheck == 0 && lssNext < lssSub {
+		s.lss.configuration = true
+	}
+	return []byte{lssIdentifySlave}
+}
+
+// lssIdentity returns vendor id, product code, revision and serial number of 0x1018, mu must be held
+func (s *Slave) lssIdentity() [4]uint32 {
+	identity := [4]uint32{}
+	for i := range identity {
+		if obj, ok := s.objects[objectKey(IDENTITY, uint8(i+1))]; ok && len(obj.value) == 4 {
+			identity[i] = binary.LittleEndian.Uint32(obj.value)
+		}
+	}
+	return identity
+}
+
+// activateNodeId switches to the node id configured by LSS. The object dictionary is
+// reloaded as the $NODEID dependent defaults change.
+func (s *Slave) activateNodeId() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.lss.pendingNodeId == s.nodeId {
+		return
+	}
+	objects, indexes, err := loadObjects(s.edsFile, s.lss.pendingNodeId)
+	if err != nil {
+		return
+	}
+	s.nodeId = s.lss.pendingNodeId
+	s.objects = objects
+	s.indexes = indexes
+}
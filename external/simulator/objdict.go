@@ -0,0 +1,160 @@
+package simulator
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	canopen "github.com/jaster-prj/go-canopen"
+)
+
+// object is a single entry of the simulated object dictionary
+type object struct {
+	dataType     byte
+	access       string
+	defaultValue []byte
+	// storedValue is the value saved by 0x1010, nil if the default applies
+	storedValue []byte
+	value       []byte
+}
+
+func objectKey(index uint16, subindex uint8) uint32 {
+	return uint32(index)<<8 | uint32(subindex)
+}
+
+func (o *object) readable() bool {
+	return o.access != "wo"
+}
+
+// resetValue returns the value the object takes on a reset
+func (o *object) resetValue() []byte {
+	if o.storedValue != nil {
+		return append([]byte{}, o.storedValue...)
+	}
+	return append([]byte{}, o.defaultValue...)
+}
+
+func (o *object) writable() bool {
+	return o.access == "rw" || o.access == "wo" || o.access == "rww" || o.access == "rwr"
+}
+
+// loadObjects parses the eds file and returns all variables with their default values
+func loadObjects(edsFile []byte, nodeId int) (map[uint32]*object, map[uint16]bool, error) {
+	dic, err := canopen.DicEDSParse(edsFile)
+	if err != nil {
+		return nil, nil, err
+	}
+	if dic == nil {
+		return nil, nil, errors.New("eds file could not be parsed")
+	}
+	objects := map[uint32]*object{}
+	indexes := map[uint16]bool{}
+	for index, dicObject := range dic.Indexes {
+		indexes[index] = true
+		variables := []*canopen.DicVariable{}
+		switch dicObj := dicObject.(type) {
+		case *canopen.DicVariable:
+			variables = append(variables, dicObj)
+		case *canopen.DicArray:
+			for _, sub := range dicObj.SubIndexes {
+				variables = append(variables, sub.(*canopen.DicVariable))
+			}
+		case *canopen.DicRecord:
+			for _, sub := range dicObj.SubIndexes {
+				variables = append(variables, sub.(*canopen.DicVariable))
+			}
+		}
+		for _, variable := range variables {
+			defaultValue, err := encodeDefault(variable.DataType, string(variable.Default), nodeId)
+			if err != nil {
+				return nil, nil, fmt.Errorf("0x%04Xsub%d: %w", variable.Index, variable.SubIndex, err)
+			}
+			objects[objectKey(variable.Index, variable.SubIndex)] = &object{
+				dataType:     variable.DataType,
+				access:       variable.AccessType,
+				defaultValue: defaultValue,
+				value:        append([]byte{}, defaultValue...),
+			}
+		}
+	}
+	return objects, indexes, nil
+}
+
+// dataTypeSize returns the encoded size of fixed size data types, 0 for strings and domains
+func dataTypeSize(dataType byte) int {
+	switch dataType {
+	case canopen.Boolean, canopen.Integer8, canopen.Unsigned8:
+		return 1
+	case canopen.Integer16, canopen.Unsigned16:
+		return 2
+	case canopen.Integer32, canopen.Unsigned32, canopen.Real32:
+		return 4
+	case canopen.Integer64, canopen.Unsigned64, canopen.Real64:
+		return 8
+	}
+	return 0
+}
+
+// encodeDefault converts an eds DefaultValue to its little endian representation
+func encodeDefault(dataType byte, value string, nodeId int) ([]byte, error) {
+	value = strings.TrimSpace(value)
+	size := dataTypeSize(dataType)
+	switch {
+	case canopen.IsStringType(dataType):
+		return []byte(value), nil
+	case dataType == canopen.Domain:
+		return []byte{}, nil
+	case size == 0:
+		return []byte{}, nil
+	}
+	data := make([]byte, 8)
+	if value == "" {
+		return data[:size], nil
+	}
+	switch dataType {
+	case canopen.Real32:
+		f, err := strconv.ParseFloat(value, 32)
+		if err != nil {
+			return nil, err
+		}
+		binary.LittleEndian.PutUint32(data, math.Float32bits(float32(f)))
+	case canopen.Real64:
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, err
+		}
+		binary.LittleEndian.PutUint64(data, math.Float64bits(f))
+	default:
+		i, err := evaluateInteger(value, nodeId)
+		if err != nil {
+			return nil, err
+		}
+		binary.LittleEndian.PutUint64(data, uint64(i))
+	}
+	return data[:size], nil
+}
+
+// evaluateInteger parses integer values including the $NODEID term of eds files, e.g. $NODEID+0x180
+func evaluateInteger(value string, nodeId int) (int64, error) {
+	result := int64(0)
+	for _, term := range strings.Split(value, "+") {
+		term = strings.TrimSpace(term)
+		if strings.EqualFold(term, "$NODEID") {
+			result += int64(nodeId)
+			continue
+		}
+		i, err := strconv.ParseInt(term, 0, 64)
+		if err != nil {
+			u, uerr := strconv.ParseUint(term, 0, 64)
+			if uerr != nil {
+				return 0, err
+			}
+			i = int64(u)
+		}
+		result += i
+	}
+	return result, nil
+}
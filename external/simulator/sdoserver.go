@@ -0,0 +1,416 @@
+package simulator
+
+import (
+	"encoding/binary"
+)
+
+const (
+	SDO_ABORT_TOGGLE_BIT         uint32 = 0x05030000
+	SDO_ABORT_INVALID_COMMAND    uint32 = 0x05040001
+	SDO_ABORT_INVALID_BLOCK_SIZE uint32 = 0x05040002
+	SDO_ABORT_INVALID_SEQUENCE   uint32 = 0x05040003
+	SDO_ABORT_CRC_ERROR          uint32 = 0x05040004
+	SDO_ABORT_WRITE_ONLY         uint32 = 0x06010001
+	SDO_ABORT_READ_ONLY          uint32 = 0x06010002
+	SDO_ABORT_OBJECT_NOT_EXIST   uint32 = 0x06020000
+	SDO_ABORT_LENGTH_MISMATCH    uint32 = 0x06070010
+	SDO_ABORT_LENGTH_TOO_HIGH    uint32 = 0x06070012
+	SDO_ABORT_LENGTH_TOO_LOW     uint32 = 0x06070013
+	SDO_ABORT_SUBINDEX_NOT_EXIST uint32 = 0x06090011
+	SDO_ABORT_VALUE_RANGE        uint32 = 0x06090030
+	SDO_ABORT_DATA_STORE         uint32 = 0x08000020
+	SDO_ABORT_DEVICE_STATE       uint32 = 0x08000022
+)
+
+const blockSize = 127
+
+type sdoTransfer int
+
+const (
+	sdoIdle sdoTransfer = iota
+	sdoSegmentedDownload
+	sdoSegmentedUpload
+	sdoBlockDownload
+	sdoBlockDownloadEnd
+	sdoBlockUploadInitiated
+	sdoBlockUpload
+	sdoBlockUploadEnd
+)
+
+// sdoServer holds the state of the running SDO transfer
+type sdoServer struct {
+	transfer sdoTransfer
+	index    uint16
+	subindex uint8
+	toggle   byte
+	data     []byte
+	pos      int
+	crc      bool
+	// block transfers
+	blockSize byte
+	sequence  byte
+	blockPos  int
+}
+
+func (s *Slave) handleSdo(request [8]byte) {
+	command := request[0]
+	ccs := command >> 5
+	// Segments of a block download start with a sequence number instead of a command specifier,
+	// the last segment of a block with sequence number below 32 looks like an abort otherwise
+	if s.sdo.transfer == sdoBlockDownload && command != 0x80 {
+		s.blockDownloadSegment(request)
+		return
+	}
+	if ccs == 4 {
+		// abort from client
+		s.sdo = sdoServer{}
+		return
+	}
+	switch s.sdo.transfer {
+	case sdoBlockDownloadEnd:
+		if ccs == 6 && command&0x1 == 1 {
+			s.blockDownloadEnd(request)
+			return
+		}
+	case sdoBlockUploadInitiated, sdoBlockUpload, sdoBlockUploadEnd:
+		if ccs == 5 {
+			s.blockUpload(request)
+			return
+		}
+	}
+	switch ccs {
+	case 1:
+		s.initiateDownload(request)
+	case 0:
+		s.downloadSegment(request)
+	case 2:
+		s.initiateUpload(request)
+	case 3:
+		s.uploadSegment(request)
+	case 5, 6:
+		switch s.blockTransfer {
+		case BLOCK_TRANSFER_REJECTED:
+			s.abortObject(binary.LittleEndian.Uint16(request[1:]), request[3], SDO_ABORT_INVALID_COMMAND)
+		case BLOCK_TRANSFER_IGNORED:
+		case BLOCK_TRANSFER_SUPPORTED:
+			if ccs == 6 {
+				s.initiateBlockDownload(request)
+			} else {
+				s.initiateBlockUpload(request)
+			}
+		}
+	default:
+		s.abort(SDO_ABORT_INVALID_COMMAND)
+	}
+}
+
+func (s *Slave) sendSdo(response []byte) {
+	buf := make([]byte, 8)
+	copy(buf, response)
+	s.Send(uint32(0x580+s.nodeId), buf)
+}
+
+func (s *Slave) abort(code uint32) {
+	s.abortObject(s.sdo.index, s.sdo.subindex, code)
+}
+
+func (s *Slave) abortObject(index uint16, subindex uint8, code uint32) {
+	buf := make([]byte, 8)
+	buf[0] = 0x80
+	binary.LittleEndian.PutUint16(buf[1:], index)
+	buf[3] = subindex
+	binary.LittleEndian.PutUint32(buf[4:], code)
+	s.sdo = sdoServer{}
+	s.sendSdo(buf)
+}
+
+func (s *Slave) multiplexer(index uint16, subindex uint8) []byte {
+	buf := make([]byte, 4)
+	binary.LittleEndian.PutUint16(buf[1:], index)
+	buf[3] = subindex
+	return buf
+}
+
+func (s *Slave) initiateDownload(request [8]byte) {
+	command := request[0]
+	index := binary.LittleEndian.Uint16(request[1:])
+	subindex := request[3]
+	s.sdo = sdoServer{index: index, subindex: subindex}
+	response := s.multiplexer(index, subindex)
+	response[0] = 0x60
+	if command&0x2 != 0 {
+		// expedited
+		size := 4
+		if command&0x1 != 0 {
+			size = 4 - int((command>>2)&0x3)
+		}
+		if code := s.writeObject(index, subindex, request[4:4+size]); code != 0 {
+			s.abort(code)
+			return
+		}
+		s.sdo = sdoServer{}
+		s.sendSdo(response)
+		return
+	}
+	if _, code := s.checkWritable(index, subindex); code != 0 {
+		s.abort(code)
+		return
+	}
+	s.sdo.transfer = sdoSegmentedDownload
+	s.sdo.data = []byte{}
+	s.sendSdo(response)
+}
+
+func (s *Slave) downloadSegment(request [8]byte) {
+	if s.sdo.transfer != sdoSegmentedDownload {
+		s.abort(SDO_ABORT_INVALID_COMMAND)
+		return
+	}
+	command := request[0]
+	toggle := command & 0x10
+	if toggle != s.sdo.toggle {
+		s.abort(SDO_ABORT_TOGGLE_BIT)
+		return
+	}
+	size := 7 - int((command>>1)&0x7)
+	s.sdo.data = append(s.sdo.data, request[1:1+size]...)
+	if command&0x1 != 0 {
+		if code := s.writeObject(s.sdo.index, s.sdo.subindex, s.sdo.data); code != 0 {
+			s.abort(code)
+			return
+		}
+		s.sdo = sdoServer{}
+	} else {
+		s.sdo.toggle ^= 0x10
+	}
+	s.sendSdo([]byte{0x20 | toggle})
+}
+
+func (s *Slave) initiateUpload(request [8]byte) {
+	index := binary.LittleEndian.Uint16(request[1:])
+	subindex := request[3]
+	s.sdo = sdoServer{index: index, subindex: subindex}
+	data, code := s.readObject(index, subindex)
+	if code != 0 {
+		s.abort(code)
+		return
+	}
+	response := s.multiplexer(index, subindex)
+	if len(data) > 0 && len(data) <= 4 {
+		response[0] = 0x43 | byte(4-len(data))<<2
+		response = append(response, data...)
+		s.sdo = sdoServer{}
+		s.sendSdo(response)
+		return
+	}
+	response[0] = 0x41
+	response = binary.LittleEndian.AppendUint32(response, uint32(len(data)))
+	s.sdo.transfer = sdoSegmentedUpload
+	s.sdo.data = data
+	s.sendSdo(response)
+}
+
+func (s *Slave) uploadSegment(request [8]byte) {
+	if s.sdo.transfer != sdoSegmentedUpload {
+		s.abort(SDO_ABORT_INVALID_COMMAND)
+		return
+	}
+	toggle := request[0] & 0x10
+	if toggle != s.sdo.toggle {
+		s.abort(SDO_ABORT_TOGGLE_BIT)
+		return
+	}
+	size := min(len(s.sdo.data)-s.sdo.pos, 7)
+	response := make([]byte, 8)
+	response[0] = toggle | byte(7-size)<<1
+	copy(response[1:], s.sdo.data[s.sdo.pos:s.sdo.pos+size])
+	s.sdo.pos += size
+	if s.sdo.pos >= len(s.sdo.data) {
+		response[0] |= 0x1
+		s.sdo = sdoServer{}
+	} else {
+		s.sdo.toggle ^= 0x10
+	}
+	s.sendSdo(response)
+}
+
+func (s *Slave) initiateBlockDownload(request [8]byte) {
+	command := request[0]
+	index := binary.LittleEndian.Uint16(request[1:])
+	subindex := request[3]
+	s.sdo = sdoServer{index: index, subindex: subindex}
+	if command&0x1 != 0 {
+		// block download end outside of a transfer
+		s.abort(SDO_ABORT_INVALID_COMMAND)
+		return
+	}
+	if _, code := s.checkWritable(index, subindex); code != 0 {
+		s.abort(code)
+		return
+	}
+	s.sdo.transfer = sdoBlockDownload
+	s.sdo.crc = command&0x4 != 0
+	s.sdo.blockSize = blockSize
+	s.sdo.data = []byte{}
+	response := s.multiplexer(index, subindex)
+	response[0] = 0xA4
+	response = append(response, s.sdo.blockSize)
+	s.sendSdo(response)
+}
+
+func (s *Slave) blockDownloadSegment(request [8]byte) {
+	sequence := request[0] & 0x7F
+	last := request[0]&0x80 != 0
+	if sequence == s.sdo.sequence+1 {
+		s.sdo.sequence = sequence
+		s.sdo.data = append(s.sdo.data, request[1:8]...)
+	}
+	if sequence < s.sdo.blockSize && !last {
+		return
+	}
+	// acknowledge the block with the last correctly received sequence number
+	s.sendSdo([]byte{0xA2, s.sdo.sequence, s.sdo.blockSize})
+	if last && sequence == s.sdo.sequence {
+		s.sdo.transfer = sdoBlockDownloadEnd
+	}
+	s.sdo.sequence = 0
+}
+
+func (s *Slave) blockDownloadEnd(request [8]byte) {
+	unused := int((request[0] >> 2) & 0x7)
+	data := s.sdo.data[:len(s.sdo.data)-unused]
+	if s.sdo.crc && binary.LittleEndian.Uint16(request[1:]) != crc16(data) {
+		s.abort(SDO_ABORT_CRC_ERROR)
+		return
+	}
+	if code := s.writeObject(s.sdo.index, s.sdo.subindex, data); code != 0 {
+		s.abort(code)
+		return
+	}
+	s.sdo = sdoServer{}
+	s.sendSdo([]byte{0xA1})
+}
+
+func (s *Slave) initiateBlockUpload(request [8]byte) {
+	command := request[0]
+	if command&0x3 != 0 {
+		s.abort(SDO_ABORT_INVALID_COMMAND)
+		return
+	}
+	index := binary.LittleEndian.Uint16(request[1:])
+	subindex := request[3]
+	s.sdo = sdoServer{index: index, subindex: subindex}
+	size := request[4]
+	if size < 1 || size > 127 {
+		s.abort(SDO_ABORT_INVALID_BLOCK_SIZE)
+		return
+	}
+	data, code := s.readObject(index, subindex)
+	if code != 0 {
+		s.abort(code)
+		return
+	}
+	s.sdo.transfer = sdoBlockUploadInitiated
+	s.sdo.crc = command&0x4 != 0
+	s.sdo.blockSize = size
+	s.sdo.data = data
+	response := s.multiplexer(index, subindex)
+	response[0] = 0xC6
+	response = binary.LittleEndian.AppendUint32(response, uint32(len(data)))
+	s.sendSdo(response)
+}
+
+func (s *Slave) blockUpload(request [8]byte) {
+	switch request[0] & 0x3 {
+	case 3:
+		// start upload
+		if s.sdo.transfer != sdoBlockUploadInitiated {
+			s.abort(SDO_ABORT_INVALID_COMMAND)
+			return
+		}
+		s.sdo.transfer = sdoBlockUpload
+		s.sendBlock()
+	case 2:
+		// block acknowledge
+		if s.sdo.transfer != sdoBlockUpload {
+			s.abort(SDO_ABORT_INVALID_COMMAND)
+			return
+		}
+		acked := int(request[1])
+		if acked > int(s.sdo.sequence) {
+			s.abort(SDO_ABORT_INVALID_SEQUENCE)
+			return
+		}
+		s.sdo.pos = s.sdo.blockPos + acked*7
+		if request[2] >= 1 && request[2] <= 127 {
+			s.sdo.blockSize = request[2]
+		}
+		if s.sdo.pos >= len(s.sdo.data) {
+			s.sdo.transfer = sdoBlockUploadEnd
+			unused := (7 - len(s.sdo.data)%7) % 7
+			if len(s.sdo.data) == 0 {
+				// an empty object is sent as a single segment without data
+				unused = 7
+			}
+			response := []byte{0xC1 | byte(unused)<<2}
+			response = binary.LittleEndian.AppendUint16(response, crc16(s.sdo.data))
+			s.sendSdo(response)
+			return
+		}
+		s.sendBlock()
+	case 1:
+		// end acknowledge
+		s.sdo = sdoServer{}
+	default:
+		s.abort(SDO_ABORT_INVALID_COMMAND)
+	}
+}
+
+func (s *Slave) sendBlock() {
+	s.sdo.blockPos = s.sdo.pos
+	s.sdo.sequence = 0
+	pos := s.sdo.pos
+	for sequence := byte(1); sequence <= s.sdo.blockSize; sequence++ {
+		segment := make([]byte, 8)
+		segment[0] = sequence
+		end := min(pos+7, len(s.sdo.data))
+		copy(segment[1:], s.sdo.data[pos:end])
+		pos = end
+		s.sdo.sequence = sequence
+		if pos >= len(s.sdo.data) {
+			segment[0] |= 0x80
+			s.sendSdo(segment)
+			return
+		}
+		s.sendSdo(segment)
+	}
+}
+
+func (s *Slave) checkWritable(index uint16, subindex uint8) (*object, uint32) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	obj, code := s.findObject(index, subindex)
+	if code != 0 {
+		return nil, code
+	}
+	if !obj.writable() {
+		return nil, SDO_ABORT_READ_ONLY
+	}
+	return obj, 0
+}
+
+// crc16 calculates the CRC-16-CCITT (XMODEM) used by SDO block transfers
+func crc16(data []byte) uint16 {
+	crc := uint16(0)
+	for _, b := range data {
+		crc ^= uint16(b) << 8
+		for i := 0; i < 8; i++ {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return crc
+}
@@ -0,0 +1,114 @@
+// Package simtest runs simulated nodes on a virtual bus for tests of the gateway.
+package simtest
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/jaster-prj/canopenrest/external/cantransport"
+	"github.com/jaster-prj/canopenrest/external/persistence/filestorage"
+	"github.com/jaster-prj/canopenrest/external/simulator"
+	"github.com/jaster-prj/canopenrest/usecases/canopenuc"
+	can "github.com/jaster-prj/go-can"
+)
+
+// BUS is the name of the simulated bus
+const BUS = "sim"
+
+// Bus is a virtual CAN bus with simulated nodes, the nodes are stopped at the end of the test
+type Bus struct {
+	// EdsFile is the eds of the simulated nodes, config/simulator.eds
+	EdsFile    []byte
+	virtualBus *cantransport.VirtualBus
+	slaves     map[int]*simulator.Slave
+}
+
+// NewBus starts simulated nodes with the given node ids on a new virtual bus
+func NewBus(t testing.TB, ids ...int) *Bus {
+	t.Helper()
+	_, file, _, _ := runtime.Caller(0)
+	edsFile, err := os.ReadFile(filepath.Join(filepath.Dir(file), "..", "..", "..", "config", "simulator.eds"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	bus := &Bus{
+		EdsFile:    edsFile,
+		virtualBus: cantransport.NewVirtualBus(),
+		slaves:     map[int]*simulator.Slave{},
+	}
+	for _, id := range ids {
+		bus.AddSlave(t, simulator.SlaveConfig{NodeId: id})
+	}
+	return bus
+}
+
+// AddSlave starts a simulated node, the eds and transport of config default to the bus
+func (b *Bus) AddSlave(t testing.TB, config simulator.SlaveConfig) *simulator.Slave {
+	t.Helper()
+	if config.EdsFile == nil {
+		config.EdsFile = b.EdsFile
+	}
+	if config.Transport == nil {
+		config.Transport = b.NewTransport()
+	}
+	slave, err := config.CreateSlave()
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = slave.Start()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		slave.Stop()
+	})
+	b.slaves[config.NodeId] = slave
+	return slave
+}
+
+// Slave returns the simulated node started with the node id
+func (b *Bus) Slave(id int) *simulator.Slave {
+	return b.slaves[id]
+}
+
+// NewTransport returns a new transport on the bus
+func (b *Bus) NewTransport() can.Transport {
+	return b.virtualBus.NewTransport()
+}
+
+// NewCanOpenUC returns a use case serving the bus as BUS with a file storage in a temporary
+// directory. The eds of every simulated node with a node id is stored.
+func NewCanOpenUC(t testing.TB, bus *Bus) *canopenuc.CanOpenUC {
+	t.Helper()
+	t.Setenv("CANOPEN_STORAGE", t.TempDir())
+	storage, err := filestorage.NewFilestorage()
+	if err != nil {
+		t.Fatal(err)
+	}
+	config := canopenuc.CanOpenUCConfig{
+		Persistence: storage,
+		Buses: []canopenuc.BusConfig{
+			{
+				Name:      BUS,
+				Transport: bus.NewTransport(),
+			},
+		},
+	}
+	uc, err := config.CreateCanOpenUC()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for id := range bus.slaves {
+		if id < 1 || id > 127 {
+			continue
+		}
+		err = uc.CreateNode(context.Background(), BUS, id, bus.EdsFile)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	return uc
+}
@@ -0,0 +1,478 @@
+// Package simulator provides a simulated CANopen slave driven by an eds file.
+// Together with the virtual transport of the cantransport package it allows
+// running the gateway without CAN hardware.
+package simulator
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	can "github.com/jaster-prj/go-can"
+)
+
+const (
+	NMT_STATE_INITIALISING    = 0
+	NMT_STATE_STOPPED         = 4
+	NMT_STATE_OPERATIONAL     = 5
+	NMT_STATE_PRE_OPERATIONAL = 127
+)
+
+const (
+	ERROR_REGISTER          = 0x1001
+	IDENTITY                = 0x1018
+	PRODUCER_HEARTBEAT_TIME = 0x1017
+	STORE_PARAMETERS        = 0x1010
+	RESTORE_PARAMETERS      = 0x1011
+	PROGRAM_DATA            = 0x1F50
+	PROGRAM_CONTROL         = 0x1F51
+)
+
+const (
+	parameterStoreSignature   = 0x65766173 //"save"
+	parameterRestoreSignature = 0x64616F6C //"load"
+)
+
+const (
+	programControlStop  = 0
+	programControlStart = 1
+	programControlReset = 2
+	programControlClear = 3
+	programControlAck   = 128
+)
+
+// BlockTransferMode selects how a simulated node answers SDO block transfers
+type BlockTransferMode int
+
+const (
+	BLOCK_TRANSFER_SUPPORTED BlockTransferMode = iota
+	// BLOCK_TRANSFER_REJECTED aborts block transfers like a node without block transfer support
+	BLOCK_TRANSFER_REJECTED
+	// BLOCK_TRANSFER_IGNORED leaves block transfer requests unanswered
+	BLOCK_TRANSFER_IGNORED
+)
+
+// SlaveConfig describes a simulated node
+type SlaveConfig struct {
+	// NodeId is 1-127 or LSS_UNCONFIGURED_NODE_ID for a node waiting for its node id by LSS
+	NodeId        int
+	EdsFile       []byte
+	Transport     can.Transport
+	BlockTransfer BlockTransferMode
+}
+
+// CreateSlave parses the eds file and returns a stopped Slave
+func (sc *SlaveConfig) CreateSlave() (*Slave, error) {
+	if (sc.NodeId < 1 || sc.NodeId > 127) && sc.NodeId != LSS_UNCONFIGURED_NODE_ID {
+		return nil, fmt.Errorf("invalid node id %d", sc.NodeId)
+	}
+	if sc.Transport == nil {
+		return nil, errors.New("no can transport configured")
+	}
+	objects, indexes, err := loadObjects(sc.EdsFile, sc.NodeId)
+	if err != nil {
+		return nil, err
+	}
+	return &Slave{
+		nodeId:           sc.NodeId,
+		edsFile:          sc.EdsFile,
+		transport:        sc.Transport,
+		blockTransfer:    sc.BlockTransfer,
+		objects:          objects,
+		indexes:          indexes,
+		state:            NMT_STATE_INITIALISING,
+		programRunning:   true,
+		heartbeatChanged: make(chan bool, 1),
+		lss: lssSlave{
+			pendingNodeId: sc.NodeId,
+		},
+	}, nil
+}
+
+// Slave is a simulated CANopen node answering NMT, SDO and program download requests
+type Slave struct {
+	mu        sync.Mutex
+	nodeId    int
+	edsFile   []byte
+	transport can.Transport
+	objects   map[uint32]*object
+	indexes   map[uint16]bool
+	state     int
+	// sdoMu guards sdo, the state of the running SDO transfer
+	sdoMu sync.Mutex
+	sdo   sdoServer
+	// lss is the LSS slave state, guarded by mu
+	lss           lssSlave
+	blockTransfer BlockTransferMode
+
+	programRunning   bool
+	heartbeatChanged chan bool
+	stop             chan bool
+
+	// OnProgramStart is called with the downloaded program when the program is started via 0x1F51
+	OnProgramStart func(program []byte)
+}
+
+// Start opens the transport, sends the boot-up message and starts answering requests.
+// An unconfigured node stays silent until it got a node id by LSS.
+func (s *Slave) Start() error {
+	if err := s.transport.Open(); err != nil {
+		return err
+	}
+	s.stop = make(chan bool)
+	s.reset(true)
+	go s.receive()
+	go s.heartbeat()
+	return nil
+}
+
+// Stop stops the simulation and closes the transport
+func (s *Slave) Stop() error {
+	close(s.stop)
+	return s.transport.Close()
+}
+
+// GetNodeId returns the node id of the slave
+func (s *Slave) GetNodeId() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.nodeId
+}
+
+// GetState returns the NMT state of the slave
+func (s *Slave) GetState() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.state
+}
+
+// GetValue returns the current value of an object
+func (s *Slave) GetValue(index uint16, subindex uint8) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	obj, ok := s.objects[objectKey(index, subindex)]
+	if !ok {
+		return nil, fmt.Errorf("object 0x%04X sub %d does not exist", index, subindex)
+	}
+	return append([]byte{}, obj.value...), nil
+}
+
+// SetValue changes the value of an object without access or size checks
+func (s *Slave) SetValue(index uint16, subindex uint8, data []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	obj, ok := s.objects[objectKey(index, subindex)]
+	if !ok {
+		return fmt.Errorf("object 0x%04X sub %d does not exist", index, subindex)
+	}
+	obj.value = append([]byte{}, data...)
+	if index == PRODUCER_HEARTBEAT_TIME {
+		s.notifyHeartbeat()
+	}
+	return nil
+}
+
+// Program returns the program downloaded to 0x1F50 sub 1
+func (s *Slave) Program() []byte {
+	data, _ := s.GetValue(PROGRAM_DATA, 1)
+	return data
+}
+
+// Send transmits a frame on behalf of the slave, e.g. to inject EMCY or PDO messages
+func (s *Slave) Send(cobId uint32, data []byte) error {
+	frm := &can.Frame{
+		ArbitrationID: cobId,
+		DLC:           uint8(len(data)),
+	}
+	if len(data) > 8 {
+		return errors.New("frame data exceeds 8 bytes")
+	}
+	copy(frm.Data[:], data)
+	return s.transport.Write(frm)
+}
+
+// Emergency sets the error register 0x1001 and sends an EMCY message
+func (s *Slave) Emergency(errorCode uint16, errorRegister uint8, manufacturerData []byte) error {
+	if len(manufacturerData) > 5 {
+		return errors.New("manufacturer data exceeds 5 bytes")
+	}
+	err := s.SetValue(ERROR_REGISTER, 0, []byte{errorRegister})
+	if err != nil {
+		return err
+	}
+	data := binary.LittleEndian.AppendUint16(nil, errorCode)
+	data = append(data, errorRegister)
+	data = append(data, manufacturerData...)
+	return s.Send(uint32(0x80+s.GetNodeId()), append(data, make([]byte, 8-len(data))...))
+}
+
+func (s *Slave) receive() {
+	for {
+		select {
+		case <-s.stop:
+			return
+		case frm, ok := <-s.transport.ReadChan():
+			if !ok {
+				return
+			}
+			s.handleFrame(frm)
+		}
+	}
+}
+
+func (s *Slave) handleFrame(frm *can.Frame) {
+	if frm.ArbitrationID == LSS_MASTER_COB_ID && frm.DLC == 8 {
+		s.handleLss(frm.Data)
+		return
+	}
+	if s.GetNodeId() == LSS_UNCONFIGURED_NODE_ID {
+		return
+	}
+	switch {
+	case frm.ArbitrationID == 0 && frm.DLC >= 2:
+		if int(frm.Data[1]) == 0 || int(frm.Data[1]) == s.nodeId {
+			s.handleNmt(frm.Data[0])
+		}
+	case frm.ArbitrationID == uint32(0x600+s.nodeId) && frm.DLC == 8:
+		s.mu.Lock()
+		stopped := s.state == NMT_STATE_STOPPED
+		s.mu.Unlock()
+		if !stopped {
+			s.sdoMu.Lock()
+			s.handleSdo(frm.Data)
+			s.sdoMu.Unlock()
+		}
+	}
+}
+
+func (s *Slave) handleNmt(command byte) {
+	switch command {
+	case 1:
+		s.setState(NMT_STATE_OPERATIONAL)
+	case 2:
+		s.setState(NMT_STATE_STOPPED)
+	case 128:
+		s.setState(NMT_STATE_PRE_OPERATIONAL)
+	case 129:
+		s.reset(true)
+	case 130:
+		s.activateNodeId()
+		s.reset(false)
+	}
+}
+
+func (s *Slave) setState(state int) {
+	s.mu.Lock()
+	s.state = state
+	s.mu.Unlock()
+}
+
+// reset restores default values (application reset only) and sends the boot-up message
+func (s *Slave) reset(application bool) {
+	s.mu.Lock()
+	if application {
+		for key, obj := range s.objects {
+			if key>>8 == PROGRAM_DATA {
+				continue
+			}
+			obj.value = obj.resetValue()
+		}
+	}
+	s.state = NMT_STATE_PRE_OPERATIONAL
+	s.mu.Unlock()
+	s.sdoMu.Lock()
+	s.sdo = sdoServer{}
+	s.sdoMu.Unlock()
+	nodeId := s.GetNodeId()
+	if nodeId == LSS_UNCONFIGURED_NODE_ID {
+		return
+	}
+	s.Send(uint32(0x700+nodeId), []byte{NMT_STATE_INITIALISING})
+	s.notifyHeartbeat()
+}
+
+func (s *Slave) notifyHeartbeat() {
+	select {
+	case s.heartbeatChanged <- true:
+	default:
+	}
+}
+
+func (s *Slave) heartbeatPeriod() time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	obj, ok := s.objects[objectKey(PRODUCER_HEARTBEAT_TIME, 0)]
+	if !ok || len(obj.value) < 2 {
+		return 0
+	}
+	return time.Duration(binary.LittleEndian.Uint16(obj.value)) * time.Millisecond
+}
+
+func (s *Slave) heartbeat() {
+	for {
+		period := s.heartbeatPeriod()
+		var timer *time.Timer
+		var tick <-chan time.Time
+		if period > 0 {
+			timer = time.NewTimer(period)
+			tick = timer.C
+		}
+		select {
+		case <-s.stop:
+			if timer != nil {
+				timer.Stop()
+			}
+			return
+		case <-s.heartbeatChanged:
+			if timer != nil {
+				timer.Stop()
+			}
+		case <-tick:
+			if nodeId := s.GetNodeId(); nodeId != LSS_UNCONFIGURED_NODE_ID {
+				s.Send(uint32(0x700+nodeId), []byte{byte(s.GetState())})
+			}
+		}
+	}
+}
+
+// writeObject stores data after access and size checks, returns an SDO abort code on failure
+func (s *Slave) writeObject(index uint16, subindex uint8, data []byte) uint32 {
+	s.mu.Lock()
+	obj, abortCode := s.findObject(index, subindex)
+	if abortCode != 0 {
+		s.mu.Unlock()
+		return abortCode
+	}
+	if !obj.writable() {
+		s.mu.Unlock()
+		return SDO_ABORT_READ_ONLY
+	}
+	if size := dataTypeSize(obj.dataType); size != 0 {
+		if len(data) > size {
+			s.mu.Unlock()
+			return SDO_ABORT_LENGTH_TOO_HIGH
+		}
+		if len(data) < size {
+			s.mu.Unlock()
+			return SDO_ABORT_LENGTH_TOO_LOW
+		}
+	}
+	if index == STORE_PARAMETERS || index == RESTORE_PARAMETERS {
+		abortCode := s.parameterCommand(index, subindex, data)
+		s.mu.Unlock()
+		return abortCode
+	}
+	if index == PROGRAM_DATA && s.programRunning {
+		s.mu.Unlock()
+		return SDO_ABORT_DEVICE_STATE
+	}
+	var program []byte
+	startProgram := false
+	if index == PROGRAM_CONTROL && len(data) > 0 {
+		switch data[0] {
+		case programControlStop:
+			s.programRunning = false
+		case programControlStart:
+			s.programRunning = true
+			startProgram = true
+			if dataObj, ok := s.objects[objectKey(PROGRAM_DATA, subindex)]; ok {
+				program = append([]byte{}, dataObj.value...)
+			}
+		case programControlReset:
+			startProgram = true
+		case programControlClear:
+			if s.programRunning {
+				s.mu.Unlock()
+				return SDO_ABORT_DEVICE_STATE
+			}
+			if dataObj, ok := s.objects[objectKey(PROGRAM_DATA, subindex)]; ok {
+				dataObj.value = []byte{}
+			}
+		case programControlAck:
+		default:
+			s.mu.Unlock()
+			return SDO_ABORT_VALUE_RANGE
+		}
+	}
+	obj.value = append([]byte{}, data...)
+	s.mu.Unlock()
+
+	if index == PRODUCER_HEARTBEAT_TIME {
+		s.notifyHeartbeat()
+	}
+	if startProgram {
+		if program != nil && s.OnProgramStart != nil {
+			s.OnProgramStart(program)
+		}
+		// The node boots into the application after the SDO response was sent
+		go func() {
+			time.Sleep(10 * time.Millisecond)
+			s.reset(false)
+		}()
+	}
+	return 0
+}
+
+// parameterCommand saves the current values (0x1010) or drops the saved values (0x1011) of
+// a parameter group, the values apply with the next application reset. mu must be held.
+func (s *Slave) parameterCommand(index uint16, subindex uint8, data []byte) uint32 {
+	signature := uint32(parameterStoreSignature)
+	if index == RESTORE_PARAMETERS {
+		signature = parameterRestoreSignature
+	}
+	if binary.LittleEndian.Uint32(data) != signature {
+		return SDO_ABORT_DATA_STORE
+	}
+	for key, obj := range s.objects {
+		objIndex := uint16(key >> 8)
+		if objIndex == PROGRAM_DATA || objIndex == STORE_PARAMETERS || objIndex == RESTORE_PARAMETERS || !inParameterGroup(objIndex, subindex) {
+			continue
+		}
+		if index == STORE_PARAMETERS {
+			obj.storedValue = append([]byte{}, obj.value...)
+		} else {
+			obj.storedValue = nil
+		}
+	}
+	return 0
+}
+
+// inParameterGroup tells if an index belongs to a group of 0x1010 and 0x1011
+func inParameterGroup(index uint16, subindex uint8) bool {
+	switch subindex {
+	case 1:
+		return true
+	case 2:
+		return index >= 0x1000 && index < 0x2000
+	case 3:
+		return index >= 0x6000 && index < 0xA000
+	}
+	return index >= 0x2000 && index < 0x6000
+}
+
+// readObject returns the value after access checks, returns an SDO abort code on failure
+func (s *Slave) readObject(index uint16, subindex uint8) ([]byte, uint32) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	obj, abortCode := s.findObject(index, subindex)
+	if abortCode != 0 {
+		return nil, abortCode
+	}
+	if !obj.readable() {
+		return nil, SDO_ABORT_WRITE_ONLY
+	}
+	return append([]byte{}, obj.value...), 0
+}
+
+func (s *Slave) findObject(index uint16, subindex uint8) (*object, uint32) {
+	obj, ok := s.objects[objectKey(index, subindex)]
+	if !ok {
+		if s.indexes[index] {
+			return nil, SDO_ABORT_SUBINDEX_NOT_EXIST
+		}
+		return nil, SDO_ABORT_OBJECT_NOT_EXIST
+	}
+	return obj, 0
+}
@@ -0,0 +1,249 @@
+package canopenuc
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"time"
+
+	"github.com/jaster-prj/canopenrest/entities"
+	canopen "github.com/jaster-prj/go-canopen"
+	"github.com/rs/zerolog/log"
+)
+
+// BackupNode reads all readable, non-constant variables of the eds of a node and stores them as
+// new snapshot version. Objects the node fails to read are left out of the snapshot.
+func (c *CanOpenUC) BackupNode(ctx context.Context, bus string, id int, comment string) (*entities.ParameterSnapshot, error) {
+	canBus, err := c.getBus(bus)
+	if err != nil {
+		return nil, err
+	}
+	values, err := c.readParameters(ctx, canBus.name, id)
+	if err != nil {
+		return nil, err
+	}
+	snapshot := entities.ParameterSnapshot{
+		Bus:     canBus.name,
+		Id:      id,
+		Created: time.Now(),
+		Comment: comment,
+		Values:  values,
+	}
+	snapshot.Version, err = c.persistence.AddSnapshot(canBus.name, id, snapshot)
+	if err != nil {
+		return nil, err
+	}
+	return &snapshot, nil
+}
+
+// GetSnapshots returns the snapshots of a node, oldest first
+func (c *CanOpenUC) GetSnapshots(ctx context.Context, bus string, id int) ([]entities.ParameterSnapshot, error) {
+	canBus, err := c.getBus(bus)
+	if err != nil {
+		return nil, err
+	}
+	return c.persistence.GetSnapshots(canBus.name, id)
+}
+
+// GetSnapshot returns a snapshot of a node with values
+func (c *CanOpenUC) GetSnapshot(ctx context.Context, bus string, id int, version int) (*entities.ParameterSnapshot, error) {
+	canBus, err := c.getBus(bus)
+	if err != nil {
+		return nil, err
+	}
+	snapshot, err := c.persistence.GetSnapshot(canBus.name, id, version)
+	if err != nil {
+		return nil, missing(err, "no snapshot %d of node %d on bus %s", version, id, canBus.name)
+	}
+	for i := range snapshot.Values {
+		snapshot.Values[i].Value = decodeParameter(snapshot.Values[i])
+	}
+	return snapshot, nil
+}
+
+// DeleteSnapshot removes a snapshot of a node
+func (c *CanOpenUC) DeleteSnapshot(ctx context.Context, bus string, id int, version int) error {
+	canBus, err := c.getBus(bus)
+	if err != nil {
+		return err
+	}
+	err = c.persistence.DeleteSnapshot(canBus.name, id, version)
+	if err != nil {
+		return missing(err, "no snapshot %d of node %d on bus %s", version, id, canBus.name)
+	}
+	return nil
+}
+
+// RestoreSnapshot writes the writable objects of a snapshot back to the node like a configuration
+// download. Objects no longer writable in the eds of the node are skipped.
+func (c *CanOpenUC) RestoreSnapshot(ctx context.Context, bus string, id int, version int, store bool) (*entities.ConfigurationReport, error) {
+	snapshot, err := c.GetSnapshot(ctx, bus, id, version)
+	if err != nil {
+		return nil, err
+	}
+	node, err := c.getNode(snapshot.Bus, id)
+	if err != nil {
+		return nil, err
+	}
+	entries := []configurationEntry{}
+	for _, value := range snapshot.Values {
+		variable, err := findVariable(node, value.Index, value.Subindex)
+		if err != nil || !writableAccessType(variable.AccessType) || configurationExcluded[value.Index] {
+			continue
+		}
+		entries = append(entries, configurationEntry{
+			index:      value.Index,
+			subindex:   value.Subindex,
+			name:       variable.Name,
+			dataType:   variable.DataType,
+			accessType: variable.AccessType,
+			value:      decodeValue(variable.DataType, value.Data),
+			data:       value.Data,
+		})
+	}
+	sortConfiguration(entries)
+	return c.downloadEntries(ctx, snapshot.Bus, id, entries, store)
+}
+
+// DiffSnapshot compares a snapshot with another snapshot or, if against is nil, with the values
+// read from the node. From is the value of the snapshot, To the value of the other side.
+func (c *CanOpenUC) DiffSnapshot(ctx context.Context, bus string, id int, version int, against *int) ([]entities.ParameterDiff, error) {
+	snapshot, err := c.GetSnapshot(ctx, bus, id, version)
+	if err != nil {
+		return nil, err
+	}
+	var values []entities.ParameterValue
+	if against != nil {
+		other, err := c.GetSnapshot(ctx, bus, id, *against)
+		if err != nil {
+			return nil, err
+		}
+		values = other.Values
+	} else {
+		values, err = c.readParameters(ctx, snapshot.Bus, id)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return diffParameters(snapshot.Values, values), nil
+}
+
+// readParameters reads the readable, non-constant variables of the eds of a node
+func (c *CanOpenUC) readParameters(ctx context.Context, bus string, id int) ([]entities.ParameterValue, error) {
+	objects, err := c.GetObjectDictionary(ctx, bus, id)
+	if err != nil {
+		return nil, err
+	}
+	node, err := c.getNode(bus, id)
+	if err != nil {
+		return nil, err
+	}
+	values := []entities.ParameterValue{}
+	failed := 0
+	for _, object := range objects {
+		if configurationExcluded[object.Index] {
+			continue
+		}
+		variables := object.SubObjects
+		if len(variables) == 0 {
+			variables = []entities.OdObject{object}
+		}
+		for _, variable := range variables {
+			dicVariable, err := findVariable(node, variable.Index, variable.Subindex)
+			if err != nil || dicVariable.DataType == canopen.Domain {
+				continue
+			}
+			switch variable.AccessType {
+			case "ro", "rw", "rwr", "rww":
+			default:
+				continue
+			}
+			data, err := c.ReadSDO(ctx, bus, id, variable.Index, variable.Subindex)
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			if err != nil {
+				log.Warn().Str("Function", "readParameters").Msgf("0x%04X sub %d: %v", variable.Index, variable.Subindex, err)
+				failed++
+				continue
+			}
+			values = append(values, entities.ParameterValue{
+				Index:    variable.Index,
+				Subindex: variable.Subindex,
+				Name:     variable.Name,
+				DataType: dataTypeName(dicVariable.DataType),
+				Data:     data,
+				Value:    decodeValue(dicVariable.DataType, data),
+			})
+		}
+	}
+	if len(values) == 0 && failed > 0 {
+		return nil, timeoutError("node %d did not answer any of %d objects", id, failed)
+	}
+	if len(values) == 0 {
+		return nil, validationError("no readable objects in eds")
+	}
+	return values, nil
+}
+
+// diffParameters lists the objects differing between from and to ordered by index and sub-index
+func diffParameters(from []entities.ParameterValue, to []entities.ParameterValue) []entities.ParameterDiff {
+	type key struct {
+		index    uint16
+		subindex uint8
+	}
+	toValues := map[key]entities.ParameterValue{}
+	for _, value := range to {
+		toValues[key{value.Index, value.Subindex}] = value
+	}
+	diffs := []entities.ParameterDiff{}
+	for _, value := range from {
+		k := key{value.Index, value.Subindex}
+		other, ok := toValues[k]
+		delete(toValues, k)
+		if ok && bytes.Equal(value.Data, other.Data) {
+			continue
+		}
+		diff := newParameterDiff(value)
+		diff.From = decodeParameter(value)
+		if ok {
+			diff.Change = entities.ParameterChanged
+			diff.To = decodeParameter(other)
+		} else {
+			diff.Change = entities.ParameterRemoved
+		}
+		diffs = append(diffs, diff)
+	}
+	for _, value := range toValues {
+		diff := newParameterDiff(value)
+		diff.Change = entities.ParameterAdded
+		diff.To = decodeParameter(value)
+		diffs = append(diffs, diff)
+	}
+	sort.Slice(diffs, func(i, j int) bool {
+		if diffs[i].Index != diffs[j].Index {
+			return diffs[i].Index < diffs[j].Index
+		}
+		return diffs[i].Subindex < diffs[j].Subindex
+	})
+	return diffs
+}
+
+func newParameterDiff(value entities.ParameterValue) entities.ParameterDiff {
+	return entities.ParameterDiff{
+		Index:    value.Index,
+		Subindex: value.Subindex,
+		Name:     value.Name,
+		DataType: value.DataType,
+	}
+}
+
+// decodeParameter decodes the data of a snapshot value with the data type recorded in the snapshot
+func decodeParameter(value entities.ParameterValue) any {
+	for dataType, name := range dataTypeNames {
+		if name == value.DataType {
+			return decodeValue(dataType, value.Data)
+		}
+	}
+	return decodeValue(canopen.Domain, value.Data)
+}
@@ -0,0 +1,73 @@
+package canopenuc
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sync"
+
+	can "github.com/jaster-prj/go-can"
+	canopen "github.com/jaster-prj/go-canopen"
+)
+
+var busNameRegexp = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// Bus is a single CAN network with its own node cache
+type Bus struct {
+	name    string
+	network *canopen.Network
+	nodes   map[int]*canopen.Node
+	// nodeLocks serialize the requests per node, guarded by CanOpenUC.nodesMu
+	nodeLocks map[int]*nodeLock
+	// lssMu serializes the LSS services of the bus
+	lssMu sync.Mutex
+	pdos  map[int]*nodePdos
+	// liveness is guarded by CanOpenUC.livenessMu
+	liveness map[int]*nodeLiveness
+}
+
+type BusConfig struct {
+	Name      string
+	Transport can.Transport
+}
+
+func (bc *BusConfig) CreateBus() (*Bus, error) {
+	if !busNameRegexp.MatchString(bc.Name) {
+		return nil, fmt.Errorf("invalid bus name %q", bc.Name)
+	}
+	if bc.Transport == nil {
+		return nil, errors.New("no can transport configured")
+	}
+
+	// Open bus
+	bus := can.NewBus(bc.Transport)
+
+	if err := bus.Open(); err != nil {
+		return nil, err
+	}
+	network, err := canopen.NewNetwork(*bus)
+	if err != nil {
+		bus.Close()
+		return nil, err
+	}
+	err = network.Run()
+	if err != nil {
+		network.Stop()
+		bus.Close()
+		return nil, err
+	}
+	return &Bus{
+		name:      bc.Name,
+		network:   network,
+		nodes:     map[int]*canopen.Node{},
+		nodeLocks: map[int]*nodeLock{},
+		pdos:      map[int]*nodePdos{},
+		liveness:  map[int]*nodeLiveness{},
+	}, nil
+}
+
+// close stops the network of the bus and closes its transport
+func (b *Bus) close() error {
+	err := b.network.Stop()
+	return errors.Join(err, b.network.Bus.Close())
+}
@@ -1,7 +1,12 @@
 package canopenuc
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
 	"fmt"
+	"io"
 	"sync"
 	"time"
 
@@ -33,219 +38,382 @@ const (
 )
 
 type CanOpenUC struct {
-	mu          sync.Mutex
-	flashOrders chan entities.FlashOrder
 	persistence persistence.IPersistence
-	network     *canopen.Network
-	nodes       map[int]*canopen.Node
+	buses       map[string]*Bus
+	busNames    []string
+	// nodesMu guards the node caches and node locks of the buses
+	nodesMu sync.Mutex
+	// pdoMu guards the PDO caches of the buses
+	pdoMu sync.Mutex
+	// emcyMu guards the EMCY histories and the histories not yet persisted
+	emcyMu        sync.Mutex
+	emcyHistories map[nodeKey][]entities.Emcy
+	emcyDirty     map[nodeKey]bool
+	// livenessMu guards the heartbeat supervision of the buses and the event subscribers
+	livenessMu           sync.Mutex
+	nodeEventSubscribers map[uuid.UUID]chan entities.NodeEvent
+	// flashEventsMu guards the subscribers of flash order events
+	flashEventsMu         sync.Mutex
+	flashEventSubscribers map[uuid.UUID]chan entities.FlashEvent
+	// flashMu guards the flash scheduler: the queued and running orders and the nodes being flashed
+	flashMu          sync.Mutex
+	flashConcurrency int
+	flashQueue       []entities.FlashOrder
+	flashRunning     map[uuid.UUID]context.CancelFunc
+	flashNodes       map[nodeKey]bool
 }
 
-func (c *CanOpenUC) RunFlashTask() {
-	go func() {
-		for {
-			select {
-			case flashOrder, ok := <-c.flashOrders:
-				if !ok {
-					break
-				}
-				c.flashNode(flashOrder)
-			}
-		}
-	}()
+// GetBuses returns the names of all served buses in configuration order
+func (c *CanOpenUC) GetBuses() []string {
+	return append([]string{}, c.busNames...)
 }
 
-func (c *CanOpenUC) ReadNmt(id int) (*string, error) {
-	node, err := c.getNode(id)
+func (c *CanOpenUC) ReadNmt(ctx context.Context, bus string, id int) (*string, error) {
+	canBus, err := c.getBus(bus)
 	if err != nil {
 		return nil, err
 	}
-	timestamp := node.NMTMaster.Timestamp
-	timeout := time.Now().Add(time.Second * 3)
-	for {
-		if time.Now().After(timeout) {
-			break
-		}
-		if timestamp != node.NMTMaster.Timestamp {
-			break
-		}
+	_, err = c.getNode(canBus.name, id)
+	if err != nil {
+		return nil, err
 	}
-	c.mu.Lock()
-	defer c.mu.Unlock()
-	status := node.NMTMaster.GetStateString()
+	c.waitForHeartbeat(ctx, canBus, id, time.Second*3)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	c.livenessMu.Lock()
+	defer c.livenessMu.Unlock()
+	liveness := c.getLiveness(canBus, id)
+	if liveness.lastSeen.IsZero() {
+		return nil, notFoundError("no heartbeat of node %d received on bus %s", id, canBus.name)
+	}
+	state := liveness.state
+	// A node is pre-operational after its boot-up
+	if state == NMT_STATE_BOOTUP {
+		state = NMT_STATE_PRE_OPERATIONAL
+	}
+	status := nmtStateString(state)
 	return &status, nil
 }
-func (c *CanOpenUC) WriteNmt(id int, state string) error {
-	node, err := c.getNode(id)
+func (c *CanOpenUC) WriteNmt(ctx context.Context, bus string, id int, state string) error {
+	node, err := c.getNode(bus, id)
+	if err != nil {
+		return err
+	}
+	canBus, _ := c.getBus(bus)
+	unlock, err := c.lockNode(ctx, canBus, id)
 	if err != nil {
 		return err
 	}
-	c.mu.Lock()
-	defer c.mu.Unlock()
-	return node.NMTMaster.SetState(state)
+	defer unlock()
+	if _, ok := canopen.NMTCommands[state]; !ok {
+		return validationError("invalid NMT state %s", state)
+	}
+	err = node.NMTMaster.SetState(state)
+	if err != nil {
+		return busError("send NMT command to node %d: %v", id, err)
+	}
+	return nil
 }
-func (c *CanOpenUC) ReadSDO(id int, index uint16, subindex uint8) ([]byte, error) {
-	node, err := c.getNode(id)
+func (c *CanOpenUC) ReadSDO(ctx context.Context, bus string, id int, index uint16, subindex uint8) ([]byte, error) {
+	node, err := c.getNode(bus, id)
 	if err != nil {
 		return nil, err
 	}
-	c.mu.Lock()
-	defer c.mu.Unlock()
-	data, err := node.SDOClient.Read(index, subindex)
+	canBus, _ := c.getBus(bus)
+	unlock, err := c.lockNode(ctx, canBus, id)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	data, err := readSDO(ctx, node, index, subindex)
 	if err == nil {
 		log.Debug().Str("Function", "ReadSDO").Msgf("data: %v", data)
 	}
 	return data, err
 }
-func (c *CanOpenUC) WriteSDO(id int, index uint16, subindex uint8, data []byte) error {
-	node, err := c.getNode(id)
+func (c *CanOpenUC) WriteSDO(ctx context.Context, bus string, id int, index uint16, subindex uint8, data []byte) error {
+	node, err := c.getNode(bus, id)
 	if err != nil {
 		return err
 	}
 	log.Debug().Str("Function", "WriteSDO").Msgf("data: %v", data)
-	c.mu.Lock()
-	defer c.mu.Unlock()
-	return node.SDOClient.Write(index, subindex, false, data)
+	canBus, _ := c.getBus(bus)
+	unlock, err := c.lockNode(ctx, canBus, id)
+	if err != nil {
+		return err
+	}
+	err = writeSDO(ctx, node, index, subindex, data)
+	unlock()
+	if err == nil && index == PRODUCER_HEARTBEAT_TIME && subindex == 0 && len(data) >= 2 {
+		c.setHeartbeatTime(canBus, id, time.Duration(binary.LittleEndian.Uint16(data))*time.Millisecond)
+	}
+	if err == nil && index == CONSUMER_HEARTBEAT_TIME {
+		c.readConsumerTimes(ctx, canBus, id)
+	}
+	return err
 }
 
-func (c *CanOpenUC) CreateNode(id int, edsFile []byte) error {
-	err := c.persistence.SafeNode(id, edsFile)
+// CreateNode stores the eds of a node, an existing eds is replaced
+func (c *CanOpenUC) CreateNode(ctx context.Context, bus string, id int, edsFile []byte) error {
+	canBus, err := c.getBus(bus)
 	if err != nil {
 		return err
 	}
-	_, err = c.getNode(id)
-	return err
+	return c.storeNode(canBus, id, edsFile)
 }
 
-func (c *CanOpenUC) FlashNode(id int, flashFile []byte, version *string) (*uuid.UUID, error) {
+// FlashNode stores the flash file read from flashFile and queues the flash order.
+// requester names the client of the order for the order history.
+func (c *CanOpenUC) FlashNode(ctx context.Context, bus string, id int, flashFile io.Reader, version *string, requester string) (*uuid.UUID, error) {
+	canBus, err := c.getBus(bus)
+	if err != nil {
+		return nil, err
+	}
 	order, err := uuid.NewUUID()
 	if err != nil {
 		return nil, err
 	}
+	hash := sha256.New()
+	size, err := c.persistence.StoreFlashFile(order, io.TeeReader(flashFile, hash))
+	if err != nil {
+		return nil, err
+	}
+	log.Debug().Str("Function", "FlashNode").Msgf("flashFile size: %d", size)
 	flashOrder := entities.FlashOrder{
 		FlashOrderId: order,
+		Bus:          canBus.name,
 		Id:           id,
-		FlashFile:    flashFile,
+		Size:         size,
 		Version:      version,
+		ImageHash:    hex.EncodeToString(hash.Sum(nil)),
+		Requester:    requester,
 	}
-	log.Debug().Str("Function", "FlashNode").Msgf("SetFlashState %s", order.String())
-	err = c.persistence.SetFlashState(order, entities.FlashRequested, nil)
+	log.Debug().Str("Function", "FlashNode").Msgf("Add to Queue %s", order.String())
+	err = c.queueFlashOrder(flashOrder)
 	if err != nil {
+		c.persistence.DeleteFlashFile(order)
 		return nil, err
 	}
-	log.Debug().Str("Function", "FlashNode").Msgf("Add to Channel %s", order.String())
-	c.flashOrders <- flashOrder
 	log.Debug().Str("Function", "FlashNode").Msgf("Finished %s", order.String())
 	return common.POINTER(order), nil
 }
 
-func (c *CanOpenUC) GetFlashState(id uuid.UUID) (*entities.FlashOrderState, error) {
-	return c.persistence.GetFlashState(id)
+func (c *CanOpenUC) GetFlashState(ctx context.Context, id uuid.UUID) (*entities.FlashOrderState, error) {
+	state, err := c.persistence.GetFlashState(id)
+	if err != nil {
+		return nil, missing(err, "flash order %s not found", id.String())
+	}
+	return state, nil
 }
 
-func (c *CanOpenUC) flashNode(flashOrder entities.FlashOrder) {
-	node, err := c.getNode(flashOrder.Id)
+// flashNode runs a flash order. When ctx is done the running CAN transaction is aborted and the
+// order is cancelled. The flash file uploaded with the order is removed, its image is kept.
+func (c *CanOpenUC) flashNode(ctx context.Context, flashOrder entities.FlashOrder) {
+	defer c.persistence.DeleteFlashFile(flashOrder.FlashOrderId)
+	// An order cancelled while it was handed over to its worker is not flashed
+	orderState, err := c.persistence.GetFlashState(flashOrder.FlashOrderId)
+	if err == nil && orderState.State.Finished() {
+		log.Info().Str("Function", "flashNode").Msgf("%s: skipped, already %s", flashOrder.FlashOrderId.String(), orderState.State.String())
+		return
+	}
+	canBus, err := c.getBus(flashOrder.Bus)
+	if err != nil {
+		c.setFlashState(flashOrder.FlashOrderId, entities.FlashProgramError, common.POINTER(err))
+		return
+	}
+	node, err := c.getNode(canBus.name, flashOrder.Id)
+	if err != nil {
+		c.setFlashState(flashOrder.FlashOrderId, entities.FlashProgramError, common.POINTER(err))
+		return
+	}
+	unlock, err := c.lockNode(ctx, canBus, flashOrder.Id)
+	if err != nil {
+		c.abortFlashNode(flashOrder, node, entities.FlashRequested)
+		return
+	}
+	defer unlock()
+	// The flash file is opened before the node leaves its state, a node is never cleared without an image
+	flashFile, err := c.openFlashFile(flashOrder)
 	if err != nil {
-		c.persistence.SetFlashState(flashOrder.FlashOrderId, entities.FlashProgramError, common.POINTER(err))
+		c.setFlashState(flashOrder.FlashOrderId, entities.FlashProgramError, common.POINTER(fmt.Errorf("Open flash file failed: %v", err)))
+		return
+	}
+	defer flashFile.Close()
+	// step enters the next state of the order, a cancelled order is aborted instead
+	state := entities.FlashState(entities.FlashRequested)
+	step := func(next entities.FlashState) bool {
+		if ctx.Err() != nil {
+			c.abortFlashNode(flashOrder, node, state)
+			return false
+		}
+		state = next
+		c.setFlashState(flashOrder.FlashOrderId, next, nil)
+		return true
+	}
+	// fail records the error of the current step, an error caused by the cancellation aborts the order
+	fail := func(err error, reset bool) {
+		if ctx.Err() != nil {
+			c.abortFlashNode(flashOrder, node, state)
+			return
+		}
+		c.setFlashState(flashOrder.FlashOrderId, entities.FlashProgramError, common.POINTER(err))
+		if reset {
+			node.NMTMaster.SetState("RESET")
+		}
+	}
+	err = c.checkProductCode(ctx, flashOrder, node)
+	if err != nil {
+		fail(fmt.Errorf("Check product code failed: %v", err), false)
+		return
+	}
+	if !step(entities.FlashPreOperational) {
 		return
 	}
-	c.mu.Lock()
-	defer c.mu.Unlock()
-	c.persistence.SetFlashState(flashOrder.FlashOrderId, entities.FlashPreOperational, nil)
 	err = node.NMTMaster.SetState("PRE-OPERATIONAL")
 	if err != nil {
-		c.persistence.SetFlashState(flashOrder.FlashOrderId, entities.FlashProgramError, common.POINTER(fmt.Errorf("Set PRE-OPERATIONAL failed: %v", err)))
+		fail(fmt.Errorf("Set PRE-OPERATIONAL failed: %v", err), false)
+		return
+	}
+	if !step(entities.FlashProgramStopBefore) {
 		return
 	}
-	c.persistence.SetFlashState(flashOrder.FlashOrderId, entities.FlashProgramStopBefore, nil)
-	err = node.SDOClient.Write(PROGRAM_CONTROL, 1, false, []byte{byte(PROGRAM_CONTROL_STOP)})
+	err = writeSDO(ctx, node, PROGRAM_CONTROL, 1, []byte{byte(PROGRAM_CONTROL_STOP)})
 	if err != nil {
-		c.persistence.SetFlashState(flashOrder.FlashOrderId, entities.FlashProgramError, common.POINTER(fmt.Errorf("PROGRAM_CONTROL_STOP failed: %v", err)))
+		fail(fmt.Errorf("PROGRAM_CONTROL_STOP failed: %v", err), false)
 		return
 	}
-	c.persistence.SetFlashState(flashOrder.FlashOrderId, entities.FlashProgramClear, nil)
-	err = node.SDOClient.Write(PROGRAM_CONTROL, 1, false, []byte{byte(PROGRAM_CONTROL_CLEAR)})
+	if !step(entities.FlashProgramClear) {
+		return
+	}
+	err = writeSDO(ctx, node, PROGRAM_CONTROL, 1, []byte{byte(PROGRAM_CONTROL_CLEAR)})
 	if err != nil {
-		c.persistence.SetFlashState(flashOrder.FlashOrderId, entities.FlashProgramError, common.POINTER(fmt.Errorf("PROGRAM_CONTROL_CLEAR failed: %v", err)))
+		fail(fmt.Errorf("PROGRAM_CONTROL_CLEAR failed: %v", err), false)
 		return
 	}
-	c.persistence.SetFlashState(flashOrder.FlashOrderId, entities.FlashProgramWriteData, nil)
-	err = node.SDOClient.Write(PROGRAM_DATA, 1, false, flashOrder.FlashFile)
+	if !step(entities.FlashProgramWriteData) {
+		return
+	}
+	writeStart := time.Now()
+	err = writeSDOStream(ctx, canBus.network, node, PROGRAM_DATA, 1, flashFile, flashOrder.Size, func(transferred int64, total int64) {
+		log.Debug().Str("Function", "flashNode").Msgf("%s: %d of %d bytes written", flashOrder.FlashOrderId.String(), transferred, total)
+		c.setFlashProgress(flashOrder.FlashOrderId, newFlashProgress(writeStart, transferred, total))
+	})
 	if err != nil {
-		c.persistence.SetFlashState(flashOrder.FlashOrderId, entities.FlashProgramError, common.POINTER(fmt.Errorf("PROGRAM_DATA failed: %v", err)))
+		fail(fmt.Errorf("PROGRAM_DATA failed: %v", err), false)
+		return
+	}
+	if !step(entities.FlashProgramWriteFinish) {
 		return
 	}
-	c.persistence.SetFlashState(flashOrder.FlashOrderId, entities.FlashProgramWriteFinish, nil)
-	flashStatus, err := node.SDOClient.Read(FLASH_STATUS_IDENT, 1)
+	flashStatus, err := readSDO(ctx, node, FLASH_STATUS_IDENT, 1)
 	if err != nil {
-		c.persistence.SetFlashState(flashOrder.FlashOrderId, entities.FlashProgramError, common.POINTER(fmt.Errorf("Read FLASH_STATUS_IDENT failed: %v", err)))
-		node.NMTMaster.SetState("RESET")
+		fail(fmt.Errorf("Read FLASH_STATUS_IDENT failed: %v", err), true)
 		return
 	}
 	if int(flashStatus[0]) != 0 {
-		c.persistence.SetFlashState(flashOrder.FlashOrderId, entities.FlashProgramError, common.POINTER(fmt.Errorf("FlashStatus failed: %d", int(flashStatus[0]))))
-		node.NMTMaster.SetState("RESET")
+		fail(fmt.Errorf("FlashStatus failed: %d", int(flashStatus[0])), true)
+		return
+	}
+	if !step(entities.FlashProgramStopAfter) {
 		return
 	}
-	c.persistence.SetFlashState(flashOrder.FlashOrderId, entities.FlashProgramStopAfter, nil)
-	err = node.SDOClient.Write(PROGRAM_CONTROL, 1, false, []byte{byte(PROGRAM_CONTROL_STOP)})
+	err = writeSDO(ctx, node, PROGRAM_CONTROL, 1, []byte{byte(PROGRAM_CONTROL_STOP)})
 	if err != nil {
-		c.persistence.SetFlashState(flashOrder.FlashOrderId, entities.FlashProgramError, common.POINTER(fmt.Errorf("PROGRAM_CONTROL_STOP2 failed: %v", err)))
-		node.NMTMaster.SetState("RESET")
+		fail(fmt.Errorf("PROGRAM_CONTROL_STOP2 failed: %v", err), true)
 		return
 	}
-	time.Sleep(time.Second * 1)
-	c.persistence.SetFlashState(flashOrder.FlashOrderId, entities.FlashProgramStart, nil)
-	err = node.SDOClient.Write(PROGRAM_CONTROL, 1, false, []byte{byte(PROGRAM_CONTROL_START)})
+	sleep(ctx, time.Second*1)
+	if !step(entities.FlashProgramStart) {
+		return
+	}
+	err = writeSDO(ctx, node, PROGRAM_CONTROL, 1, []byte{byte(PROGRAM_CONTROL_START)})
 	if err != nil {
-		c.persistence.SetFlashState(flashOrder.FlashOrderId, entities.FlashProgramError, common.POINTER(fmt.Errorf("PROGRAM_CONTROL_START failed: %v", err)))
-		node.NMTMaster.SetState("RESET")
+		fail(fmt.Errorf("PROGRAM_CONTROL_START failed: %v", err), true)
+		return
+	}
+	sleep(ctx, time.Second*10)
+	if !step(entities.FlashProgramCheckError) {
 		return
 	}
-	time.Sleep(time.Second * 10)
-	c.persistence.SetFlashState(flashOrder.FlashOrderId, entities.FlashProgramCheckError, nil)
-	response, err := node.SDOClient.Read(ERROR_REGISTER, 0)
+	response, err := readSDO(ctx, node, ERROR_REGISTER, 0)
 	if err != nil {
-		c.persistence.SetFlashState(flashOrder.FlashOrderId, entities.FlashProgramError, common.POINTER(fmt.Errorf("Read ERROR_REGISTER failed: %v", err)))
-		node.NMTMaster.SetState("RESET")
+		fail(fmt.Errorf("Read ERROR_REGISTER failed: %v", err), true)
 		return
 	}
 	if response[0] != 0 {
-		c.persistence.SetFlashState(flashOrder.FlashOrderId, entities.FlashProgramError, common.POINTER(fmt.Errorf("Read ERROR_REGISTER not 0: %d", response[0])))
-		node.NMTMaster.SetState("RESET")
+		fail(fmt.Errorf("Read ERROR_REGISTER not 0: %d", response[0]), true)
+		return
+	}
+	if !step(entities.FlashProgramCheckVersion) {
 		return
 	}
-	c.persistence.SetFlashState(flashOrder.FlashOrderId, entities.FlashProgramCheckVersion, nil)
-	response, err = node.SDOClient.Read(MANUFACTURER_SOFTWARE_VERSION, 0)
+	response, err = readSDO(ctx, node, MANUFACTURER_SOFTWARE_VERSION, 0)
 	if err != nil {
-		c.persistence.SetFlashState(flashOrder.FlashOrderId, entities.FlashProgramError, common.POINTER(fmt.Errorf("Read MANUFACTURER_SOFTWARE_VERSION failed: %v", err)))
-		node.NMTMaster.SetState("RESET")
+		fail(fmt.Errorf("Read MANUFACTURER_SOFTWARE_VERSION failed: %v", err), true)
+		return
+	}
+	if !step(entities.FlashProgramAck) {
 		return
 	}
-	c.persistence.SetFlashState(flashOrder.FlashOrderId, entities.FlashProgramAck, nil)
 	log.Debug().Str("Function", "flashNode").Msgf("New Version: %s", string(response))
 	if flashOrder.Version != nil {
 		if string(response) == *flashOrder.Version {
-			err = node.SDOClient.Write(PROGRAM_CONTROL, 1, false, []byte{byte(PROGRAM_CONTROL_ACK)})
+			err = writeSDO(ctx, node, PROGRAM_CONTROL, 1, []byte{byte(PROGRAM_CONTROL_ACK)})
 			if err != nil {
-				c.persistence.SetFlashState(flashOrder.FlashOrderId, entities.FlashProgramError, common.POINTER(fmt.Errorf("PROGRAM_CONTROL_ACK failed: %v", err)))
-				node.NMTMaster.SetState("RESET")
+				fail(fmt.Errorf("PROGRAM_CONTROL_ACK failed: %v", err), true)
 				return
 			}
 		}
 	}
-	c.persistence.SetFlashState(flashOrder.FlashOrderId, entities.FlashProgramFinish, nil)
+	c.setFlashState(flashOrder.FlashOrderId, entities.FlashProgramFinish, nil)
 	log.Debug().Str("Function", "flashNode").Msgf("Flash Success")
 }
 
-func (c *CanOpenUC) getNode(id int) (*canopen.Node, error) {
+// newFlashProgress calculates the average rate and the remaining time of a download begun at start
+func newFlashProgress(start time.Time, transferred int64, total int64) entities.FlashProgress {
+	now := time.Now()
+	progress := entities.FlashProgress{
+		Transferred: transferred,
+		Total:       total,
+		Updated:     now,
+	}
+	elapsed := now.Sub(start).Seconds()
+	if elapsed > 0 && transferred > 0 {
+		progress.Rate = float64(transferred) / elapsed
+		if total > transferred {
+			progress.Eta = time.Duration(float64(total-transferred) / progress.Rate * float64(time.Second))
+		}
+	}
+	return progress
+}
+
+func (c *CanOpenUC) getBus(name string) (*Bus, error) {
+	if name == "" {
+		name = c.busNames[0]
+	}
+	bus, ok := c.buses[name]
+	if !ok {
+		return nil, notFoundError("bus %s not found", name)
+	}
+	return bus, nil
+}
+
+func (c *CanOpenUC) getNode(busName string, id int) (*canopen.Node, error) {
+	bus, err := c.getBus(busName)
+	if err != nil {
+		return nil, err
+	}
+	c.nodesMu.Lock()
+	defer c.nodesMu.Unlock()
 	var node *canopen.Node
-	if _, ok := c.nodes[id]; !ok {
-		odsFile, err := c.persistence.GetObjDict(id)
+	if _, ok := bus.nodes[id]; !ok {
+		odsFile, err := c.persistence.GetObjDict(bus.name, id)
 		if err != nil {
-			return nil, err
+			return nil, missing(err, "no eds stored for node %d on bus %s", id, bus.name)
 		}
 		config := NodeConfig{
-			c.network,
+			bus.network,
 			id,
 			odsFile,
 		}
@@ -253,9 +421,9 @@ func (c *CanOpenUC) getNode(id int) (*canopen.Node, error) {
 		if err != nil {
 			return nil, err
 		}
-		c.nodes[id] = node
+		bus.nodes[id] = node
 	} else {
-		node = c.nodes[id]
+		node = bus.nodes[id]
 	}
 	return node, nil
 }
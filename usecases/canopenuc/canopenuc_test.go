@@ -0,0 +1,243 @@
+package canopenuc_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jaster-prj/canopenrest/entities"
+	"github.com/jaster-prj/canopenrest/external/simulator"
+	"github.com/jaster-prj/canopenrest/external/simulator/simtest"
+	"github.com/jaster-prj/canopenrest/usecases/canopenuc"
+)
+
+const TEST_NODE = 0x10
+
+func testContext(t *testing.T) context.Context {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	t.Cleanup(cancel)
+	return ctx
+}
+
+func TestSdoReadWrite(t *testing.T) {
+	bus := simtest.NewBus(t, TEST_NODE)
+	uc := simtest.NewCanOpenUC(t, bus)
+	ctx := testContext(t)
+
+	data := binary.LittleEndian.AppendUint16(nil, 500)
+	err := uc.WriteSDO(ctx, simtest.BUS, TEST_NODE, canopenuc.PRODUCER_HEARTBEAT_TIME, 0, data)
+	if err != nil {
+		t.Fatalf("write heartbeat time: %v", err)
+	}
+	value, err := bus.Slave(TEST_NODE).GetValue(canopenuc.PRODUCER_HEARTBEAT_TIME, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(value, data) {
+		t.Errorf("simulator holds %x, want %x", value, data)
+	}
+	read, err := uc.ReadSDO(ctx, simtest.BUS, TEST_NODE, canopenuc.PRODUCER_HEARTBEAT_TIME, 0)
+	if err != nil {
+		t.Fatalf("read heartbeat time: %v", err)
+	}
+	if !bytes.Equal(read, data) {
+		t.Errorf("read %x, want %x", read, data)
+	}
+
+	sdoValue, err := uc.ReadSDOValue(ctx, simtest.BUS, TEST_NODE, canopenuc.PRODUCER_HEARTBEAT_TIME, 0)
+	if err != nil {
+		t.Fatalf("read heartbeat time value: %v", err)
+	}
+	if sdoValue.DataType != "UNSIGNED16" {
+		t.Errorf("type %s, want UNSIGNED16", sdoValue.DataType)
+	}
+}
+
+func TestSdoAbort(t *testing.T) {
+	uc := simtest.NewCanOpenUC(t, simtest.NewBus(t, TEST_NODE))
+	ctx := testContext(t)
+
+	tests := []struct {
+		name     string
+		write    bool
+		index    uint16
+		subindex uint8
+		code     uint32
+	}{
+		{name: "read missing object", index: 0x2FFF, subindex: 0, code: 0x06020000},
+		{name: "write read only object", write: true, index: 0x1018, subindex: 1, code: 0x06010002},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var err error
+			if tt.write {
+				err = uc.WriteSDO(ctx, simtest.BUS, TEST_NODE, tt.index, tt.subindex, []byte{1, 0, 0, 0})
+			} else {
+				_, err = uc.ReadSDO(ctx, simtest.BUS, TEST_NODE, tt.index, tt.subindex)
+			}
+			var abortErr *canopenuc.SdoAbortError
+			if !errors.As(err, &abortErr) {
+				t.Fatalf("got %v, want an sdo abort", err)
+			}
+			if abortErr.Code != tt.code {
+				t.Errorf("abort code 0x%08X, want 0x%08X", abortErr.Code, tt.code)
+			}
+			if canopenuc.KindOf(err) != canopenuc.ERROR_SDO_ABORT {
+				t.Errorf("kind %s, want %s", canopenuc.KindOf(err), canopenuc.ERROR_SDO_ABORT)
+			}
+		})
+	}
+}
+
+func TestUnknownBusAndNode(t *testing.T) {
+	uc := simtest.NewCanOpenUC(t, simtest.NewBus(t, TEST_NODE))
+	ctx := testContext(t)
+
+	_, err := uc.ReadSDO(ctx, "unknown", TEST_NODE, canopenuc.PRODUCER_HEARTBEAT_TIME, 0)
+	if canopenuc.KindOf(err) != canopenuc.ERROR_NOT_FOUND {
+		t.Errorf("unknown bus: got %v, want not found", err)
+	}
+	_, err = uc.ReadSDO(ctx, simtest.BUS, TEST_NODE+1, canopenuc.PRODUCER_HEARTBEAT_TIME, 0)
+	if canopenuc.KindOf(err) != canopenuc.ERROR_NOT_FOUND {
+		t.Errorf("node without eds: got %v, want not found", err)
+	}
+}
+
+func TestNmtWriteRead(t *testing.T) {
+	bus := simtest.NewBus(t, TEST_NODE)
+	uc := simtest.NewCanOpenUC(t, bus)
+	ctx := testContext(t)
+
+	// a node is pre-operational after its boot-up
+	read, err := uc.ReadNmt(ctx, simtest.BUS, TEST_NODE)
+	if err != nil {
+		t.Fatalf("read after boot-up: %v", err)
+	}
+	if *read != "PRE-OPERATIONAL" {
+		t.Errorf("read %s after boot-up", *read)
+	}
+	for _, state := range []string{"OPERATIONAL", "STOPPED", "PRE-OPERATIONAL"} {
+		err := uc.WriteNmt(ctx, simtest.BUS, TEST_NODE, state)
+		if err != nil {
+			t.Fatalf("write %s: %v", state, err)
+		}
+		read, err := uc.ReadNmt(ctx, simtest.BUS, TEST_NODE)
+		if err != nil {
+			t.Fatalf("read after %s: %v", state, err)
+		}
+		if *read != state {
+			t.Errorf("read %s after writing %s", *read, state)
+		}
+	}
+	if bus.Slave(TEST_NODE).GetState() != simulator.NMT_STATE_PRE_OPERATIONAL {
+		t.Errorf("simulator state %d, want %d", bus.Slave(TEST_NODE).GetState(), simulator.NMT_STATE_PRE_OPERATIONAL)
+	}
+
+	err = uc.WriteNmt(ctx, simtest.BUS, TEST_NODE, "UNKNOWN")
+	if canopenuc.KindOf(err) != canopenuc.ERROR_VALIDATION {
+		t.Errorf("unknown state: got %v, want a validation error", err)
+	}
+}
+
+// waitForFlashEvent waits for the first event of a flash order matching the condition
+func waitForFlashEvent(t *testing.T, events <-chan entities.FlashEvent, id uuid.UUID, match func(entities.FlashEvent) bool) entities.FlashEvent {
+	t.Helper()
+	timeout := time.After(20 * time.Second)
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				t.Fatal("flash events closed")
+			}
+			if event.FlashOrderId == id && match(event) {
+				return event
+			}
+		case <-timeout:
+			t.Fatalf("no matching event for flash order %s", id.String())
+		}
+	}
+}
+
+func TestFlashCancel(t *testing.T) {
+	uc := simtest.NewCanOpenUC(t, simtest.NewBus(t, TEST_NODE))
+	ctx := testContext(t)
+	events, unsubscribe := uc.SubscribeFlashEvents()
+	defer unsubscribe()
+
+	flashData := bytes.Repeat([]byte{0x5A}, 1<<20)
+	running, err := uc.FlashNode(ctx, simtest.BUS, TEST_NODE, bytes.NewReader(flashData), nil, "test")
+	if err != nil {
+		t.Fatalf("flash: %v", err)
+	}
+	// a second order of the same node waits until the first one finished
+	queued, err := uc.FlashNode(ctx, simtest.BUS, TEST_NODE, bytes.NewReader(flashData), nil, "test")
+	if err != nil {
+		t.Fatalf("flash queued: %v", err)
+	}
+
+	_, err = uc.CancelFlash(ctx, *queued)
+	if err != nil {
+		t.Fatalf("cancel queued: %v", err)
+	}
+	state, err := uc.GetFlashState(ctx, *queued)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if state.State != entities.FlashCancelled {
+		t.Errorf("queued order state %s, want %s", state.State.String(), entities.FlashState(entities.FlashCancelled).String())
+	}
+
+	waitForFlashEvent(t, events, *running, func(event entities.FlashEvent) bool {
+		return event.State.State == entities.FlashProgramWriteData
+	})
+	_, err = uc.CancelFlash(ctx, *running)
+	if err != nil {
+		t.Fatalf("cancel running: %v", err)
+	}
+	result := waitForFlashEvent(t, events, *running, func(event entities.FlashEvent) bool {
+		return event.Type == entities.FlashFinished
+	})
+	if result.State.State != entities.FlashCancelled {
+		t.Errorf("running order state %s, want %s", result.State.State.String(), entities.FlashState(entities.FlashCancelled).String())
+	}
+
+	_, err = uc.CancelFlash(ctx, *running)
+	if canopenuc.KindOf(err) != canopenuc.ERROR_CONFLICT {
+		t.Errorf("cancel finished order: got %v, want a conflict", err)
+	}
+	_, err = uc.CancelFlash(ctx, uuid.New())
+	if canopenuc.KindOf(err) != canopenuc.ERROR_NOT_FOUND {
+		t.Errorf("cancel unknown order: got %v, want not found", err)
+	}
+}
+
+func TestFlashNode(t *testing.T) {
+	bus := simtest.NewBus(t, TEST_NODE)
+	uc := simtest.NewCanOpenUC(t, bus)
+	ctx := testContext(t)
+	events, unsubscribe := uc.SubscribeFlashEvents()
+	defer unsubscribe()
+
+	flashData := make([]byte, 4096)
+	for i := range flashData {
+		flashData[i] = byte(i * 7)
+	}
+	id, err := uc.FlashNode(ctx, simtest.BUS, TEST_NODE, bytes.NewReader(flashData), nil, "test")
+	if err != nil {
+		t.Fatalf("flash: %v", err)
+	}
+	result := waitForFlashEvent(t, events, *id, func(event entities.FlashEvent) bool {
+		return event.Type == entities.FlashFinished
+	})
+	if result.State.State != entities.FlashProgramFinish {
+		t.Fatalf("state %s, want %s", result.State.State.String(), entities.FlashState(entities.FlashProgramFinish).String())
+	}
+	if !bytes.Equal(bus.Slave(TEST_NODE).Program(), flashData) {
+		t.Errorf("simulator holds a program of %d bytes, want the flashed %d bytes", len(bus.Slave(TEST_NODE).Program()), len(flashData))
+	}
+}
@@ -1,52 +1,84 @@
 package canopenuc
 
 import (
-	"sync"
+	"context"
+	"errors"
+	"fmt"
 
+	"github.com/google/uuid"
 	"github.com/jaster-prj/canopenrest/entities"
 	"github.com/jaster-prj/canopenrest/external/persistence"
-	can "github.com/jaster-prj/go-can"
 	transports "github.com/jaster-prj/go-can/transports"
-	canopen "github.com/jaster-prj/go-canopen"
+	"github.com/rs/zerolog/log"
 )
 
 type CanOpenUCConfig struct {
 	Persistence persistence.IPersistence
-	CanPort     string
+	// Buses to serve, the first one is used when a request names no bus
+	Buses []BusConfig
+	// FlashConcurrency limits the flash orders running at once, 0 selects FLASH_DEFAULT_CONCURRENCY
+	FlashConcurrency int
 }
 
 func (cc *CanOpenUCConfig) CreateCanOpenUC() (*CanOpenUC, error) {
-	// Configure transport
-	tr := &transports.SocketCan{
-		Interface: cc.CanPort,
+	if len(cc.Buses) == 0 {
+		return nil, errors.New("no bus configured")
 	}
-
-	// Open bus
-	bus := can.NewBus(tr)
-
-	if err := bus.Open(); err != nil {
-		return nil, err
+	buses := map[string]*Bus{}
+	busNames := []string{}
+	// closeBuses closes the buses opened before a bus failed
+	closeBuses := func() {
+		for _, bus := range buses {
+			if err := bus.close(); err != nil {
+				log.Warn().Str("Function", "CreateCanOpenUC").Msgf("close bus %s: %v", bus.name, err)
+			}
+		}
 	}
-	network, err := canopen.NewNetwork(*bus)
-	if err != nil {
-		return nil, err
+	for _, busConfig := range cc.Buses {
+		if _, ok := buses[busConfig.Name]; ok {
+			closeBuses()
+			return nil, fmt.Errorf("bus %s configured twice", busConfig.Name)
+		}
+		bus, err := busConfig.CreateBus()
+		if err != nil {
+			closeBuses()
+			return nil, fmt.Errorf("bus %s: %w", busConfig.Name, err)
+		}
+		buses[bus.name] = bus
+		busNames = append(busNames, bus.name)
 	}
-	err = network.Run()
-	if err != nil {
-		return nil, err
+	flashConcurrency := cc.FlashConcurrency
+	if flashConcurrency <= 0 {
+		flashConcurrency = FLASH_DEFAULT_CONCURRENCY
 	}
 	canopenUc := &CanOpenUC{
-		mu:          sync.Mutex{},
-		flashOrders: make(chan entities.FlashOrder, 20),
 		persistence: cc.Persistence,
-		network:     network,
-		nodes:       map[int]*canopen.Node{},
+		buses:       buses,
+		busNames:    busNames,
+
+		emcyHistories:         map[nodeKey][]entities.Emcy{},
+		emcyDirty:             map[nodeKey]bool{},
+		nodeEventSubscribers:  map[uuid.UUID]chan entities.NodeEvent{},
+		flashEventSubscribers: map[uuid.UUID]chan entities.FlashEvent{},
+		flashConcurrency:      flashConcurrency,
+		flashRunning:          map[uuid.UUID]context.CancelFunc{},
+		flashNodes:            map[nodeKey]bool{},
 	}
-	canopenUc.RunFlashTask()
+	canopenUc.RunEmcyTask()
+	canopenUc.RunSupervisorTask()
 	return canopenUc, nil
 }
 
 func NewCanOpenUC(canPort string) (*CanOpenUC, error) {
-	config := CanOpenUCConfig{CanPort: canPort}
+	config := CanOpenUCConfig{
+		Buses: []BusConfig{
+			{
+				Name: canPort,
+				Transport: &transports.SocketCan{
+					Interface: canPort,
+				},
+			},
+		},
+	}
 	return config.CreateCanOpenUC()
 }
@@ -0,0 +1,411 @@
+package canopenuc
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jaster-prj/canopenrest/entities"
+	canopen "github.com/jaster-prj/go-canopen"
+	"github.com/rs/zerolog/log"
+	"gopkg.in/ini.v1"
+)
+
+// configurationExcluded are commands and the program download, they are never part of a configuration
+var configurationExcluded = map[uint16]bool{
+	STORE_PARAMETERS:           true,
+	RESTORE_DEFAULT_PARAMETERS: true,
+	PROGRAM_DATA:               true,
+	PROGRAM_CONTROL:            true,
+}
+
+// configurationEntry is a writable object of a configuration file with the value to download
+type configurationEntry struct {
+	index      uint16
+	subindex   uint8
+	name       string
+	dataType   byte
+	accessType string
+	value      any
+	// data is the encoded value, set for entries restored from a backup
+	data []byte
+}
+
+// configurationObject is an entry compared against the node, data is the encoded value
+type configurationObject struct {
+	entry   configurationEntry
+	data    []byte
+	differs bool
+	result  entities.ConfigurationResult
+}
+
+// DownloadConfiguration writes the ParameterValue (or DefaultValue) of all writable objects of a dcf
+// to a node. Without dcf the defaults of the stored eds are downloaded, overrides replace single values.
+// Only objects differing from the node are written. PDOs are disabled while their parameters and
+// mapping are changed. Failed objects are reported, the download continues with the next object.
+func (c *CanOpenUC) DownloadConfiguration(ctx context.Context, bus string, id int, dcfFile []byte, overrides []entities.ConfigurationValue, store bool) (*entities.ConfigurationReport, error) {
+	canBus, err := c.getBus(bus)
+	if err != nil {
+		return nil, err
+	}
+	node, err := c.getNode(canBus.name, id)
+	if err != nil {
+		return nil, err
+	}
+	if dcfFile == nil {
+		dcfFile, err = c.persistence.GetObjDict(canBus.name, id)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		err = checkEds(dcfFile)
+		if err != nil {
+			return nil, err
+		}
+	}
+	entries, err := readConfiguration(dcfFile, id)
+	if err != nil {
+		return nil, err
+	}
+	entries, err = applyOverrides(node, entries, overrides)
+	if err != nil {
+		return nil, err
+	}
+	return c.downloadEntries(ctx, canBus.name, id, entries, store)
+}
+
+// downloadEntries writes the differing entries ordered by index, PDOs are written as a whole
+// when their first entry is reached. store saves all parameters in the node afterwards.
+// The download stops when ctx is done, the entries written so far stay in the node.
+func (c *CanOpenUC) downloadEntries(ctx context.Context, bus string, id int, entries []configurationEntry, store bool) (*entities.ConfigurationReport, error) {
+	report := &entities.ConfigurationReport{
+		Bus:     bus,
+		Id:      id,
+		Objects: []entities.ConfigurationResult{},
+	}
+	pdosDone := map[uint16]bool{}
+	for _, entry := range entries {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		communication, isPdo := pdoCommunicationIndex(entry.index)
+		if !isPdo {
+			object := c.compareConfiguration(ctx, bus, id, entry)
+			if object.differs {
+				c.writeConfiguration(ctx, bus, id, object)
+			}
+			addConfigurationResult(report, object.result)
+			continue
+		}
+		if pdosDone[communication] {
+			continue
+		}
+		pdosDone[communication] = true
+		for _, result := range c.downloadPdo(ctx, bus, id, communication, entries) {
+			addConfigurationResult(report, result)
+		}
+	}
+	for communication := range pdosDone {
+		direction, number := pdoNumber(communication)
+		if _, err := c.ReadPdo(ctx, bus, id, direction, number); err != nil {
+			log.Warn().Str("Function", "downloadEntries").Msgf("%s %d: %v", direction, number, err)
+		}
+	}
+	if store {
+		err := c.StoreParameters(ctx, bus, id, entities.ParameterGroupAll, entities.ParameterResetNone)
+		if err != nil {
+			report.StoreError = err.Error()
+		} else {
+			report.Stored = true
+		}
+	}
+	return report, nil
+}
+
+// compareConfiguration encodes the value of an entry and reads the current value from the node.
+// Write only objects are always written.
+func (c *CanOpenUC) compareConfiguration(ctx context.Context, bus string, id int, entry configurationEntry) *configurationObject {
+	object := &configurationObject{
+		entry: entry,
+		result: entities.ConfigurationResult{
+			Index:    entry.index,
+			Subindex: entry.subindex,
+			Name:     entry.name,
+			DataType: dataTypeName(entry.dataType),
+			Value:    entry.value,
+			Result:   entities.ConfigurationUnchanged,
+		},
+	}
+	data := entry.data
+	if data == nil {
+		var err error
+		data, err = encodeValue(entry.dataType, entry.value)
+		if err != nil {
+			object.result.Result = entities.ConfigurationFailed
+			object.result.Error = err.Error()
+			return object
+		}
+	}
+	object.data = data
+	object.result.Value = decodeValue(entry.dataType, data)
+	if entry.accessType != "wo" {
+		current, err := c.ReadSDO(ctx, bus, id, entry.index, entry.subindex)
+		if err == nil {
+			object.result.Previous = decodeValue(entry.dataType, current)
+			if bytes.Equal(current, data) {
+				return object
+			}
+		}
+	}
+	object.differs = true
+	return object
+}
+
+func (c *CanOpenUC) writeConfiguration(ctx context.Context, bus string, id int, object *configurationObject) {
+	err := c.WriteSDO(ctx, bus, id, object.entry.index, object.entry.subindex, object.data)
+	if err != nil {
+		object.result.Result = entities.ConfigurationFailed
+		object.result.Error = err.Error()
+		return
+	}
+	object.result.Result = entities.ConfigurationWritten
+}
+
+// downloadPdo writes the differing communication and mapping entries of a PDO. The PDO is disabled
+// first, the mapping is cleared while its entries are written and the cob id is written last.
+func (c *CanOpenUC) downloadPdo(ctx context.Context, bus string, id int, communication uint16, entries []configurationEntry) []entities.ConfigurationResult {
+	mapping := communication + RPDO_MAPPING_PARAMETER - RPDO_COMMUNICATION_PARAMETER
+	objects := []*configurationObject{}
+	var cobId, mappingCount *configurationObject
+	changed, mappingChanged := false, false
+	for _, entry := range entries {
+		if entry.index != communication && entry.index != mapping {
+			continue
+		}
+		object := c.compareConfiguration(ctx, bus, id, entry)
+		objects = append(objects, object)
+		changed = changed || object.differs
+		switch {
+		case entry.index == communication && entry.subindex == 1:
+			cobId = object
+		case entry.index == mapping && entry.subindex == 0:
+			mappingCount = object
+		case entry.index == mapping:
+			mappingChanged = mappingChanged || object.differs
+		}
+	}
+	if !changed {
+		return configurationResults(objects)
+	}
+
+	direction, number := pdoNumber(communication)
+	currentCobId, err := c.ReadSDO(ctx, bus, id, communication, 1)
+	if err == nil && len(currentCobId) != 4 {
+		err = fmt.Errorf("invalid cob id %X", currentCobId)
+	}
+	if err == nil {
+		err = c.WriteSDO(ctx, bus, id, communication, 1, binary.LittleEndian.AppendUint32(nil, binary.LittleEndian.Uint32(currentCobId)|PDO_COB_ID_INVALID))
+	}
+	if err != nil {
+		for _, object := range objects {
+			if object.differs {
+				object.result.Result = entities.ConfigurationFailed
+				object.result.Error = fmt.Sprintf("disable %s %d: %v", direction, number, err)
+			}
+		}
+		return configurationResults(objects)
+	}
+	for _, object := range objects {
+		if object.differs && object.entry.index == communication && object != cobId {
+			c.writeConfiguration(ctx, bus, id, object)
+		}
+	}
+	if mappingChanged || (mappingCount != nil && mappingCount.differs) {
+		count, err := c.ReadSDO(ctx, bus, id, mapping, 0)
+		if err != nil {
+			count = []byte{0}
+		}
+		err = c.WriteSDO(ctx, bus, id, mapping, 0, []byte{0})
+		for _, object := range objects {
+			if !object.differs || object.entry.index != mapping || object == mappingCount {
+				continue
+			}
+			if err != nil {
+				object.result.Result = entities.ConfigurationFailed
+				object.result.Error = fmt.Sprintf("clear mapping: %v", err)
+				continue
+			}
+			c.writeConfiguration(ctx, bus, id, object)
+		}
+		if mappingCount != nil && mappingCount.data != nil {
+			mappingCount.differs = true
+			c.writeConfiguration(ctx, bus, id, mappingCount)
+		} else if err := c.WriteSDO(ctx, bus, id, mapping, 0, count); err != nil {
+			log.Warn().Str("Function", "downloadPdo").Msgf("restore mapping count of %s %d: %v", direction, number, err)
+		}
+	}
+	if cobId != nil && cobId.data != nil {
+		cobId.differs = true
+		c.writeConfiguration(ctx, bus, id, cobId)
+	} else if err := c.WriteSDO(ctx, bus, id, communication, 1, currentCobId); err != nil {
+		log.Warn().Str("Function", "downloadPdo").Msgf("restore cob id of %s %d: %v", direction, number, err)
+	}
+	return configurationResults(objects)
+}
+
+func configurationResults(objects []*configurationObject) []entities.ConfigurationResult {
+	results := []entities.ConfigurationResult{}
+	for _, object := range objects {
+		results = append(results, object.result)
+	}
+	return results
+}
+
+func addConfigurationResult(report *entities.ConfigurationReport, result entities.ConfigurationResult) {
+	switch result.Result {
+	case entities.ConfigurationWritten:
+		report.Written++
+	case entities.ConfigurationUnchanged:
+		report.Unchanged++
+	case entities.ConfigurationFailed:
+		report.Failed++
+	}
+	report.Objects = append(report.Objects, result)
+}
+
+// readConfiguration returns the writable variables of a configuration file with a value ordered
+// by index and sub-index. The ParameterValue of a dcf takes precedence over the DefaultValue.
+func readConfiguration(dcfFile []byte, nodeId int) ([]configurationEntry, error) {
+	iniData, err := ini.Load(dcfFile)
+	if err != nil {
+		return nil, err
+	}
+	entries := []configurationEntry{}
+	for _, sec := range iniData.Sections() {
+		var index, subindex uint64
+		name := sec.Name()
+		switch {
+		case edsIndexRegexp.MatchString(name):
+			index, _ = strconv.ParseUint(name, 16, 16)
+			if objectType, err := strconv.ParseUint(sec.Key("ObjectType").String(), 0, 8); err == nil && byte(objectType) != canopen.DicVar {
+				continue
+			}
+		case edsSubindexRegexp.MatchString(name):
+			match := edsSubindexRegexp.FindStringSubmatch(name)
+			index, _ = strconv.ParseUint(match[1], 16, 16)
+			subindex, err = strconv.ParseUint(match[2], 16, 8)
+			if err != nil {
+				return nil, validationError("section %s: %w", name, err)
+			}
+		default:
+			continue
+		}
+		if configurationExcluded[uint16(index)] {
+			continue
+		}
+		accessType := strings.ToLower(sec.Key("AccessType").String())
+		if !writableAccessType(accessType) {
+			continue
+		}
+		value := sec.Key("ParameterValue").String()
+		if value == "" {
+			value = sec.Key("DefaultValue").String()
+		}
+		dataType, err := strconv.ParseUint(sec.Key("DataType").String(), 0, 16)
+		if value == "" || err != nil {
+			continue
+		}
+		if _, ok := dataTypeNames[byte(dataType)]; !ok || byte(dataType) == canopen.Domain {
+			continue
+		}
+		parsed, err := parseEdsValue(byte(dataType), value, nodeId)
+		if err != nil {
+			return nil, validationError("section %s: %w", name, err)
+		}
+		entries = append(entries, configurationEntry{
+			index:      uint16(index),
+			subindex:   uint8(subindex),
+			name:       sec.Key("ParameterName").String(),
+			dataType:   byte(dataType),
+			accessType: accessType,
+			value:      parsed,
+		})
+	}
+	sortConfiguration(entries)
+	return entries, nil
+}
+
+// applyOverrides replaces the values of entries, objects without entry are taken from the eds of the node
+func applyOverrides(node *canopen.Node, entries []configurationEntry, overrides []entities.ConfigurationValue) ([]configurationEntry, error) {
+	for _, override := range overrides {
+		found := false
+		for i := range entries {
+			if entries[i].index == override.Index && entries[i].subindex == override.Subindex {
+				entries[i].value = override.Value
+				found = true
+			}
+		}
+		if found {
+			continue
+		}
+		variable, err := findVariable(node, override.Index, override.Subindex)
+		if err != nil {
+			return nil, err
+		}
+		if !writableAccessType(variable.AccessType) {
+			return nil, validationError("0x%04X sub %d is not writable (%s)", override.Index, override.Subindex, variable.AccessType)
+		}
+		entries = append(entries, configurationEntry{
+			index:      override.Index,
+			subindex:   override.Subindex,
+			name:       variable.Name,
+			dataType:   variable.DataType,
+			accessType: variable.AccessType,
+			value:      override.Value,
+		})
+	}
+	sortConfiguration(entries)
+	return entries, nil
+}
+
+func sortConfiguration(entries []configurationEntry) {
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].index != entries[j].index {
+			return entries[i].index < entries[j].index
+		}
+		return entries[i].subindex < entries[j].subindex
+	})
+}
+
+func writableAccessType(accessType string) bool {
+	switch accessType {
+	case "rw", "wo", "rww", "rwr":
+		return true
+	}
+	return false
+}
+
+// pdoCommunicationIndex returns the communication parameter of a PDO communication or mapping index
+func pdoCommunicationIndex(index uint16) (uint16, bool) {
+	switch {
+	case index >= RPDO_COMMUNICATION_PARAMETER && index < RPDO_COMMUNICATION_PARAMETER+PDO_COUNT,
+		index >= TPDO_COMMUNICATION_PARAMETER && index < TPDO_COMMUNICATION_PARAMETER+PDO_COUNT:
+		return index, true
+	case index >= RPDO_MAPPING_PARAMETER && index < RPDO_MAPPING_PARAMETER+PDO_COUNT,
+		index >= TPDO_MAPPING_PARAMETER && index < TPDO_MAPPING_PARAMETER+PDO_COUNT:
+		return index - (RPDO_MAPPING_PARAMETER - RPDO_COMMUNICATION_PARAMETER), true
+	}
+	return 0, false
+}
+
+// pdoNumber returns direction and number of a PDO communication parameter index
+func pdoNumber(communication uint16) (entities.PdoDirection, int) {
+	if communication >= TPDO_COMMUNICATION_PARAMETER {
+		return entities.PdoTransmit, int(communication-TPDO_COMMUNICATION_PARAMETER) + 1
+	}
+	return entities.PdoReceive, int(communication-RPDO_COMMUNICATION_PARAMETER) + 1
+}
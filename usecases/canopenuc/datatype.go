@@ -0,0 +1,231 @@
+package canopenuc
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+
+	canopen "github.com/jaster-prj/go-canopen"
+)
+
+var dataTypeNames = map[byte]string{
+	canopen.Boolean:       "BOOLEAN",
+	canopen.Integer8:      "INTEGER8",
+	canopen.Integer16:     "INTEGER16",
+	canopen.Integer32:     "INTEGER32",
+	canopen.Integer64:     "INTEGER64",
+	canopen.Unsigned8:     "UNSIGNED8",
+	canopen.Unsigned16:    "UNSIGNED16",
+	canopen.Unsigned32:    "UNSIGNED32",
+	canopen.Unsigned64:    "UNSIGNED64",
+	canopen.Real32:        "REAL32",
+	canopen.Real64:        "REAL64",
+	canopen.VisibleString: "VISIBLE_STRING",
+	canopen.OctetString:   "OCTET_STRING",
+	canopen.UnicodeString: "UNICODE_STRING",
+	canopen.Domain:        "DOMAIN",
+}
+
+// dataTypeName returns the CiA 301 name of a data type
+func dataTypeName(dataType byte) string {
+	if name, ok := dataTypeNames[dataType]; ok {
+		return name
+	}
+	return fmt.Sprintf("0x%04X", dataType)
+}
+
+// dataTypeSize returns the encoded size of fixed size data types, 0 for strings and domains
+func dataTypeSize(dataType byte) int {
+	switch dataType {
+	case canopen.Boolean, canopen.Integer8, canopen.Unsigned8:
+		return 1
+	case canopen.Integer16, canopen.Unsigned16:
+		return 2
+	case canopen.Integer32, canopen.Unsigned32, canopen.Real32:
+		return 4
+	case canopen.Integer64, canopen.Unsigned64, canopen.Real64:
+		return 8
+	}
+	return 0
+}
+
+// decodeValue converts little endian data to a JSON compatible value of the data type
+func decodeValue(dataType byte, data []byte) any {
+	size := dataTypeSize(dataType)
+	if size != 0 {
+		padded := make([]byte, 8)
+		copy(padded, data)
+		raw := binary.LittleEndian.Uint64(padded)
+		bits := min(len(data), size) * 8
+		switch {
+		case dataType == canopen.Boolean:
+			return raw != 0
+		case canopen.IsSignedType(dataType):
+			if bits > 0 && bits < 64 && raw&(1<<(bits-1)) != 0 {
+				raw |= math.MaxUint64 << bits
+			}
+			return int64(raw)
+		case canopen.IsUnsignedType(dataType):
+			return raw
+		case dataType == canopen.Real32:
+			return float64(math.Float32frombits(uint32(raw)))
+		case dataType == canopen.Real64:
+			return math.Float64frombits(raw)
+		}
+	}
+	switch dataType {
+	case canopen.VisibleString:
+		return strings.TrimRight(string(data), "\x00")
+	case canopen.UnicodeString:
+		chars := make([]uint16, len(data)/2)
+		for i := range chars {
+			chars[i] = binary.LittleEndian.Uint16(data[i*2:])
+		}
+		return strings.TrimRight(string(utf16.Decode(chars)), "\x00")
+	}
+	return strings.ToUpper(hex.EncodeToString(data))
+}
+
+// encodeValue converts a JSON value to the little endian representation of the data type.
+// Integers are range checked against the data type.
+func encodeValue(dataType byte, value any) ([]byte, error) {
+	size := dataTypeSize(dataType)
+	data := make([]byte, 8)
+	switch {
+	case dataType == canopen.Boolean:
+		b, ok := value.(bool)
+		if !ok {
+			i, err := toInt64(value)
+			if err != nil || (i != 0 && i != 1) {
+				return nil, validationError("%v is not a BOOLEAN", value)
+			}
+			b = i == 1
+		}
+		if b {
+			data[0] = 1
+		}
+	case canopen.IsSignedType(dataType):
+		i, err := toInt64(value)
+		if err != nil {
+			return nil, err
+		}
+		bits := size * 8
+		if bits < 64 && (i < -(1<<(bits-1)) || i >= 1<<(bits-1)) {
+			return nil, validationError("%d out of range of %s", i, dataTypeName(dataType))
+		}
+		binary.LittleEndian.PutUint64(data, uint64(i))
+	case canopen.IsUnsignedType(dataType):
+		u, err := toUint64(value)
+		if err != nil {
+			return nil, err
+		}
+		bits := size * 8
+		if bits < 64 && u >= 1<<bits {
+			return nil, validationError("%d out of range of %s", u, dataTypeName(dataType))
+		}
+		binary.LittleEndian.PutUint64(data, u)
+	case dataType == canopen.Real32:
+		f, err := toFloat64(value)
+		if err != nil {
+			return nil, err
+		}
+		if math.Abs(f) > math.MaxFloat32 {
+			return nil, validationError("%g out of range of %s", f, dataTypeName(dataType))
+		}
+		binary.LittleEndian.PutUint32(data, math.Float32bits(float32(f)))
+	case dataType == canopen.Real64:
+		f, err := toFloat64(value)
+		if err != nil {
+			return nil, err
+		}
+		binary.LittleEndian.PutUint64(data, math.Float64bits(f))
+	case dataType == canopen.VisibleString:
+		s, ok := value.(string)
+		if !ok {
+			return nil, validationError("%v is not a %s", value, dataTypeName(dataType))
+		}
+		return []byte(s), nil
+	case dataType == canopen.UnicodeString:
+		s, ok := value.(string)
+		if !ok {
+			return nil, validationError("%v is not a %s", value, dataTypeName(dataType))
+		}
+		chars := utf16.Encode([]rune(s))
+		data = make([]byte, len(chars)*2)
+		for i, char := range chars {
+			binary.LittleEndian.PutUint16(data[i*2:], char)
+		}
+		return data, nil
+	default:
+		s, ok := value.(string)
+		if !ok {
+			return nil, validationError("%v is not a hex string", value)
+		}
+		return hex.DecodeString(strings.ReplaceAll(strings.TrimPrefix(s, "0x"), ":", ""))
+	}
+	return data[:size], nil
+}
+
+func toInt64(value any) (int64, error) {
+	switch v := value.(type) {
+	case json.Number:
+		return strconv.ParseInt(v.String(), 0, 64)
+	case string:
+		return strconv.ParseInt(v, 0, 64)
+	case float64:
+		if v != math.Trunc(v) {
+			return 0, validationError("%g is not an integer", v)
+		}
+		return int64(v), nil
+	case int:
+		return int64(v), nil
+	case int64:
+		return v, nil
+	case uint64:
+		if v > math.MaxInt64 {
+			return 0, validationError("%d out of range", v)
+		}
+		return int64(v), nil
+	}
+	return 0, validationError("%v is not an integer", value)
+}
+
+func toUint64(value any) (uint64, error) {
+	switch v := value.(type) {
+	case json.Number:
+		return strconv.ParseUint(v.String(), 0, 64)
+	case string:
+		return strconv.ParseUint(v, 0, 64)
+	case uint64:
+		return v, nil
+	}
+	i, err := toInt64(value)
+	if err != nil {
+		return 0, err
+	}
+	if i < 0 {
+		return 0, validationError("%d is negative", i)
+	}
+	return uint64(i), nil
+}
+
+func toFloat64(value any) (float64, error) {
+	switch v := value.(type) {
+	case json.Number:
+		return v.Float64()
+	case string:
+		return strconv.ParseFloat(v, 64)
+	case float64:
+		return v, nil
+	}
+	i, err := toInt64(value)
+	if err != nil {
+		return 0, err
+	}
+	return float64(i), nil
+}
@@ -0,0 +1,444 @@
+package canopenuc
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/jaster-prj/canopenrest/entities"
+	canopen "github.com/jaster-prj/go-canopen"
+	"github.com/rs/zerolog/log"
+	"gopkg.in/ini.v1"
+)
+
+const (
+	EDS_DATATYPE_MAX  = 0x1B
+	EDS_OBJECT_DOMAIN = 0x2
+	DCF_SECTION       = "DeviceComissioning"
+)
+
+var edsAccessTypes = map[string]bool{
+	"ro":    true,
+	"wo":    true,
+	"rw":    true,
+	"rwr":   true,
+	"rww":   true,
+	"const": true,
+}
+
+var edsObjectLists = []string{"MandatoryObjects", "OptionalObjects", "ManufacturerObjects"}
+
+var dcfBaudrates = map[int]bool{10: true, 20: true, 50: true, 125: true, 250: true, 500: true, 800: true, 1000: true}
+
+type edsObjectKey struct {
+	index    uint16
+	subindex uint8
+}
+
+// edsMandatoryTypes are the data types CiA 301 requires for the mandatory objects
+var edsMandatoryTypes = map[edsObjectKey]byte{
+	{DEVICE_TYPE, 0}:    canopen.Unsigned32,
+	{ERROR_REGISTER, 0}: canopen.Unsigned8,
+	{IDENTITY, 0}:       canopen.Unsigned8,
+	{IDENTITY, 1}:       canopen.Unsigned32,
+	{IDENTITY, 2}:       canopen.Unsigned32,
+	{IDENTITY, 3}:       canopen.Unsigned32,
+	{IDENTITY, 4}:       canopen.Unsigned32,
+}
+
+// ValidateEds checks an eds or dcf file and returns its errors and warnings
+func (c *CanOpenUC) ValidateEds(edsFile []byte) []entities.EdsIssue {
+	return validateEds(edsFile)
+}
+
+// checkEds returns an EdsValidationError if the eds has errors, warnings are logged
+func checkEds(edsFile []byte) error {
+	issues := validateEds(edsFile)
+	for _, issue := range issues {
+		if issue.Severity == entities.EdsError {
+			return &entities.EdsValidationError{Issues: issues}
+		}
+	}
+	for _, issue := range issues {
+		log.Warn().Str("Function", "checkEds").Msg(issue.String())
+	}
+	return nil
+}
+
+type edsValidator struct {
+	iniData *ini.File
+	issues  []entities.EdsIssue
+	// indexes holds the object type of all index sections
+	indexes map[uint16]byte
+	// subindexes holds the sub-index sections per index
+	subindexes map[uint16][]*ini.Section
+	dcf        bool
+}
+
+func validateEds(edsFile []byte) []entities.EdsIssue {
+	v := &edsValidator{
+		indexes:    map[uint16]byte{},
+		subindexes: map[uint16][]*ini.Section{},
+	}
+	v.checkDuplicates(edsFile)
+	iniData, err := ini.Load(edsFile)
+	if err != nil {
+		v.errorf("", "no ini file: %v", err)
+		return v.issues
+	}
+	v.iniData = iniData
+	_, err = iniData.GetSection(DCF_SECTION)
+	v.dcf = err == nil
+	for _, name := range []string{"FileInfo", "DeviceInfo"} {
+		if _, err := iniData.GetSection(name); err != nil {
+			v.warnf(name, "section missing")
+		}
+	}
+	v.checkObjects()
+	v.checkMandatoryObjects()
+	v.checkObjectLists()
+	if v.dcf {
+		v.checkDcf()
+	}
+	if !v.hasErrors() {
+		dic, err := canopen.DicEDSParse(edsFile)
+		if err != nil {
+			v.errorf("", "object dictionary not readable: %v", err)
+		} else if dic == nil {
+			v.errorf("", "object dictionary not readable")
+		}
+	}
+	return v.issues
+}
+
+func (v *edsValidator) errorf(section string, format string, args ...any) {
+	v.issues = append(v.issues, entities.EdsIssue{
+		Severity: entities.EdsError,
+		Section:  section,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+func (v *edsValidator) warnf(section string, format string, args ...any) {
+	v.issues = append(v.issues, entities.EdsIssue{
+		Severity: entities.EdsWarning,
+		Section:  section,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+func (v *edsValidator) hasErrors() bool {
+	for _, issue := range v.issues {
+		if issue.Severity == entities.EdsError {
+			return true
+		}
+	}
+	return false
+}
+
+// checkDuplicates finds sections and keys defined twice, the ini parser silently merges them
+func (v *edsValidator) checkDuplicates(edsFile []byte) {
+	sections := map[string]bool{}
+	keys := map[string]bool{}
+	section := ""
+	scanner := bufio.NewScanner(bytes.NewReader(edsFile))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case line == "" || strings.HasPrefix(line, ";") || strings.HasPrefix(line, "#"):
+		case strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]"):
+			section = strings.TrimSpace(line[1 : len(line)-1])
+			if sections[strings.ToLower(section)] {
+				v.errorf(section, "section defined twice")
+			}
+			sections[strings.ToLower(section)] = true
+			keys = map[string]bool{}
+		default:
+			key, _, ok := strings.Cut(line, "=")
+			if !ok {
+				continue
+			}
+			key = strings.ToLower(strings.TrimSpace(key))
+			if keys[key] {
+				v.warnf(section, "key %s defined twice, only one value is used", key)
+			}
+			keys[key] = true
+		}
+	}
+}
+
+func (v *edsValidator) checkObjects() {
+	for _, sec := range v.iniData.Sections() {
+		name := sec.Name()
+		switch {
+		case edsIndexRegexp.MatchString(name):
+			index, _ := strconv.ParseUint(name, 16, 16)
+			objectType := byte(canopen.DicVar)
+			if sec.HasKey("ObjectType") {
+				value, err := strconv.ParseUint(sec.Key("ObjectType").String(), 0, 8)
+				if _, ok := objectTypeNames[byte(value)]; err != nil || !ok {
+					v.errorf(name, "invalid ObjectType %s", sec.Key("ObjectType").String())
+					continue
+				}
+				objectType = byte(value)
+			}
+			v.indexes[uint16(index)] = objectType
+			if sec.Key("ParameterName").String() == "" {
+				v.warnf(name, "ParameterName missing")
+			}
+			if objectType == canopen.DicVar || objectType == EDS_OBJECT_DOMAIN {
+				v.checkVariable(sec, uint16(index), 0)
+			}
+		case edsSubindexRegexp.MatchString(name):
+			match := edsSubindexRegexp.FindStringSubmatch(name)
+			index, _ := strconv.ParseUint(match[1], 16, 16)
+			subindex, err := strconv.ParseUint(match[2], 16, 8)
+			if err != nil {
+				v.errorf(name, "invalid sub-index %s", match[2])
+				continue
+			}
+			v.subindexes[uint16(index)] = append(v.subindexes[uint16(index)], sec)
+			if sec.Key("ParameterName").String() == "" {
+				v.warnf(name, "ParameterName missing")
+			}
+			v.checkVariable(sec, uint16(index), uint8(subindex))
+		}
+	}
+	for _, index := range slices.Sorted(maps.Keys(v.subindexes)) {
+		subs := v.subindexes[index]
+		objectType, ok := v.indexes[index]
+		if !ok {
+			v.errorf(subs[0].Name(), "object %04X has no section", index)
+			continue
+		}
+		if objectType != canopen.DicArr && objectType != canopen.DicRec {
+			v.errorf(subs[0].Name(), "object %04X is a %s and can not have sub-indexes", index, objectTypeNames[objectType])
+		}
+	}
+	for _, index := range slices.Sorted(maps.Keys(v.indexes)) {
+		if objectType := v.indexes[index]; objectType == canopen.DicArr || objectType == canopen.DicRec {
+			v.checkComplexObject(index, objectType)
+		}
+	}
+}
+
+// checkComplexObject checks the sub-indexes of an array or record
+func (v *edsValidator) checkComplexObject(index uint16, objectType byte) {
+	name := fmt.Sprintf("%04X", index)
+	subs := v.subindexes[index]
+	if subNumber, err := v.iniData.Section(name).Key("SubNumber").Int(); err != nil {
+		v.warnf(name, "SubNumber missing")
+	} else if subNumber != len(subs) {
+		v.warnf(name, "SubNumber is %d but %d sub-indexes are defined", subNumber, len(subs))
+	}
+	if !v.iniData.HasSection(name + "sub0") {
+		v.errorf(name, "sub-index 0 missing")
+	}
+	if objectType != canopen.DicArr {
+		return
+	}
+	dataType := ""
+	for _, sub := range subs {
+		if strings.HasSuffix(strings.ToLower(sub.Name()), "sub0") {
+			continue
+		}
+		if dataType != "" && sub.Key("DataType").String() != dataType {
+			v.warnf(sub.Name(), "data type %s differs from %s of the other array entries", sub.Key("DataType").String(), dataType)
+		}
+		dataType = sub.Key("DataType").String()
+	}
+}
+
+// checkVariable checks data type, access type, values and limits of a variable
+func (v *edsValidator) checkVariable(sec *ini.Section, index uint16, subindex uint8) {
+	name := sec.Name()
+	if !sec.HasKey("DataType") {
+		v.errorf(name, "DataType missing")
+		return
+	}
+	value, err := strconv.ParseUint(sec.Key("DataType").String(), 0, 16)
+	if err != nil {
+		v.errorf(name, "invalid DataType %s", sec.Key("DataType").String())
+		return
+	}
+	dataType := byte(value)
+	if value > EDS_DATATYPE_MAX {
+		v.warnf(name, "data type 0x%04X is no basic data type", value)
+	} else if _, ok := dataTypeNames[dataType]; !ok {
+		v.warnf(name, "data type %s is not supported for typed access", dataTypeName(dataType))
+	}
+	if expected, ok := edsMandatoryTypes[edsObjectKey{index, subindex}]; ok && expected != dataType {
+		v.errorf(name, "data type must be %s, not %s", dataTypeName(expected), dataTypeName(dataType))
+	}
+	accessType := strings.ToLower(sec.Key("AccessType").String())
+	switch {
+	case accessType == "":
+		v.errorf(name, "AccessType missing")
+	case !edsAccessTypes[accessType]:
+		v.errorf(name, "invalid AccessType %s", accessType)
+	}
+	if sec.Key("PDOMapping").MustBool(false) {
+		if accessType == "const" {
+			v.warnf(name, "constant object is PDO mappable")
+		}
+		if dataTypeSize(dataType) == 0 && dataType != canopen.Boolean {
+			v.errorf(name, "%s can not be PDO mapped", dataTypeName(dataType))
+		}
+	}
+	for _, key := range []string{"DefaultValue", "ParameterValue", "LowLimit", "HighLimit"} {
+		if !sec.HasKey(key) || sec.Key(key).String() == "" {
+			continue
+		}
+		if err := checkEdsValue(dataType, sec.Key(key).String()); err != nil {
+			v.errorf(name, "%s: %v", key, err)
+		}
+	}
+	if sec.HasKey("ParameterValue") && !v.dcf {
+		v.warnf(name, "ParameterValue in eds without [%s] section", DCF_SECTION)
+	}
+	low, lowErr := strconv.ParseFloat(evaluateEdsInteger(sec.Key("LowLimit").String()), 64)
+	high, highErr := strconv.ParseFloat(evaluateEdsInteger(sec.Key("HighLimit").String()), 64)
+	if lowErr == nil && highErr == nil && low > high {
+		v.errorf(name, "LowLimit is above HighLimit")
+	}
+}
+
+func (v *edsValidator) checkMandatoryObjects() {
+	for _, index := range []uint16{DEVICE_TYPE, ERROR_REGISTER, IDENTITY} {
+		if _, ok := v.indexes[index]; !ok {
+			v.errorf(fmt.Sprintf("%04X", index), "mandatory object missing")
+		}
+	}
+	if _, ok := v.indexes[IDENTITY]; ok && !v.iniData.HasSection(fmt.Sprintf("%04Xsub1", IDENTITY)) {
+		v.errorf(fmt.Sprintf("%04X", IDENTITY), "vendor-ID (sub-index 1) missing")
+	}
+}
+
+// checkObjectLists compares the object lists with the object sections
+func (v *edsValidator) checkObjectLists() {
+	listed := map[uint16]bool{}
+	hasLists := false
+	for _, list := range edsObjectLists {
+		sec, err := v.iniData.GetSection(list)
+		if err != nil {
+			v.warnf(list, "section missing")
+			continue
+		}
+		hasLists = true
+		count, err := sec.Key("SupportedObjects").Int()
+		if err != nil {
+			v.errorf(list, "invalid SupportedObjects %s", sec.Key("SupportedObjects").String())
+			continue
+		}
+		for i := 1; i <= count; i++ {
+			key := strconv.Itoa(i)
+			if !sec.HasKey(key) {
+				v.errorf(list, "entry %d of %d missing", i, count)
+				continue
+			}
+			index, err := strconv.ParseUint(sec.Key(key).String(), 0, 16)
+			if err != nil {
+				v.errorf(list, "invalid index %s", sec.Key(key).String())
+				continue
+			}
+			listed[uint16(index)] = true
+			if _, ok := v.indexes[uint16(index)]; !ok {
+				v.errorf(list, "listed object %04X has no section", index)
+			}
+		}
+		if len(sec.Keys())-1 > count {
+			v.warnf(list, "more entries than SupportedObjects %d", count)
+		}
+	}
+	if !hasLists {
+		return
+	}
+	for _, index := range slices.Sorted(maps.Keys(v.indexes)) {
+		if !listed[index] {
+			v.warnf(fmt.Sprintf("%04X", index), "object is not listed in the object lists")
+		}
+	}
+}
+
+// checkDcf checks the commissioning section of a device configuration file
+func (v *edsValidator) checkDcf() {
+	sec := v.iniData.Section(DCF_SECTION)
+	if !sec.HasKey("NodeId") {
+		v.errorf(DCF_SECTION, "NodeId missing")
+	} else if nodeId, err := strconv.ParseInt(sec.Key("NodeId").String(), 0, 16); err != nil || nodeId < 1 || nodeId > 127 {
+		v.errorf(DCF_SECTION, "NodeId %s out of range 1-127", sec.Key("NodeId").String())
+	}
+	if sec.HasKey("Baudrate") {
+		baudrate, err := strconv.Atoi(sec.Key("Baudrate").String())
+		if err != nil || !dcfBaudrates[baudrate] {
+			v.warnf(DCF_SECTION, "Baudrate %s kbit/s is not in the CiA 301 bit timing table", sec.Key("Baudrate").String())
+		}
+	}
+}
+
+// checkEdsValue checks a numeric value of the eds against the data type, $NODEID is checked with node ids 1 and 127
+func checkEdsValue(dataType byte, value string) error {
+	if dataTypeSize(dataType) == 0 {
+		return nil
+	}
+	nodeIds := []int{1, 127}
+	if !strings.Contains(strings.ToUpper(value), "$NODEID") {
+		nodeIds = nodeIds[:1]
+	}
+	for _, nodeId := range nodeIds {
+		parsed, err := parseEdsValue(dataType, value, nodeId)
+		if err != nil {
+			return err
+		}
+		if _, err := encodeValue(dataType, parsed); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseEdsValue converts a DefaultValue or ParameterValue of the eds to a value for encodeValue,
+// integers may be given as sum with $NODEID
+func parseEdsValue(dataType byte, value string, nodeId int) (any, error) {
+	value = strings.TrimSpace(value)
+	switch {
+	case dataType == canopen.Boolean || canopen.IsSignedType(dataType) || canopen.IsUnsignedType(dataType):
+		expression := strings.ReplaceAll(strings.ToUpper(value), "$NODEID", strconv.Itoa(nodeId))
+		if canopen.IsSignedType(dataType) {
+			return sumEdsTerms(expression, func(term string) (int64, error) {
+				return strconv.ParseInt(term, 0, 64)
+			})
+		}
+		return sumEdsTerms(expression, func(term string) (uint64, error) {
+			return strconv.ParseUint(term, 0, 64)
+		})
+	case dataType == canopen.Real32 || dataType == canopen.Real64:
+		return strconv.ParseFloat(value, 64)
+	}
+	return value, nil
+}
+
+func sumEdsTerms[T int64 | uint64](expression string, parse func(string) (T, error)) (T, error) {
+	var sum T
+	for _, term := range strings.Split(expression, "+") {
+		number, err := parse(strings.ToLower(strings.TrimSpace(term)))
+		if err != nil {
+			return 0, fmt.Errorf("invalid number %s", expression)
+		}
+		sum += number
+	}
+	return sum, nil
+}
+
+// evaluateEdsInteger replaces $NODEID for limits that are compared numerically
+func evaluateEdsInteger(value string) string {
+	value = strings.ReplaceAll(strings.ToUpper(strings.TrimSpace(value)), "$NODEID", "0")
+	if i, err := strconv.ParseInt(strings.ToLower(value), 0, 64); err == nil {
+		return strconv.FormatInt(i, 10)
+	}
+	return value
+}
@@ -0,0 +1,127 @@
+package canopenuc
+
+import (
+	"context"
+	"encoding/binary"
+	"time"
+
+	"github.com/jaster-prj/canopenrest/entities"
+	can "github.com/jaster-prj/go-can"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	EMCY_COB_ID       = 0x80
+	EMCY_HISTORY_SIZE = 100
+	// EMCY_PERSIST_DELAY collects the messages of an error storm into one write of the histories
+	EMCY_PERSIST_DELAY = 2 * time.Second
+)
+
+// RunEmcyTask records the emergency messages of all nodes on all buses
+func (c *CanOpenUC) RunEmcyTask() {
+	for _, name := range c.busNames {
+		bus := c.buses[name]
+		filterFunc := func(frm *can.Frame) bool {
+			return frm.ArbitrationID > EMCY_COB_ID && frm.ArbitrationID <= EMCY_COB_ID+127 && frm.DLC >= 3
+		}
+		framesChan := bus.network.AcquireFramesChan(&filterFunc)
+		go func() {
+			for frm := range framesChan.C {
+				id := int(frm.ArbitrationID - EMCY_COB_ID)
+				emcy := entities.Emcy{
+					Timestamp:        time.Now(),
+					ErrorCode:        binary.LittleEndian.Uint16(frm.Data[0:2]),
+					ErrorRegister:    frm.Data[2],
+					ManufacturerData: append([]byte{}, frm.Data[3:frm.DLC]...),
+				}
+				log.Warn().Str("Function", "RunEmcyTask").Msgf("bus %s node %d: EMCY 0x%04X (%s), error register 0x%02X",
+					bus.name, id, emcy.ErrorCode, emcy.Description(), emcy.ErrorRegister)
+				err := c.addEmcy(bus.name, id, emcy)
+				if err != nil {
+					log.Error().Msg(err.Error())
+				}
+			}
+		}()
+	}
+}
+
+// GetEmcy returns the recorded emergency messages of a node, oldest first
+func (c *CanOpenUC) GetEmcy(ctx context.Context, bus string, id int) ([]entities.Emcy, error) {
+	canBus, err := c.getBus(bus)
+	if err != nil {
+		return nil, err
+	}
+	c.emcyMu.Lock()
+	defer c.emcyMu.Unlock()
+	history, err := c.emcyHistory(nodeKey{canBus.name, id})
+	if err != nil {
+		return nil, err
+	}
+	return append([]entities.Emcy{}, history...), nil
+}
+
+// ClearEmcy removes the recorded emergency messages of a node
+func (c *CanOpenUC) ClearEmcy(ctx context.Context, bus string, id int) error {
+	canBus, err := c.getBus(bus)
+	if err != nil {
+		return err
+	}
+	c.emcyMu.Lock()
+	defer c.emcyMu.Unlock()
+	key := nodeKey{canBus.name, id}
+	err = c.persistence.SetEmcyHistory(key.bus, key.id, nil)
+	if err != nil {
+		return err
+	}
+	c.emcyHistories[key] = []entities.Emcy{}
+	delete(c.emcyDirty, key)
+	return nil
+}
+
+// addEmcy appends to the history of a node, dropping the oldest messages beyond EMCY_HISTORY_SIZE.
+// The history is persisted EMCY_PERSIST_DELAY after the first message not yet persisted.
+func (c *CanOpenUC) addEmcy(bus string, id int, emcy entities.Emcy) error {
+	c.emcyMu.Lock()
+	defer c.emcyMu.Unlock()
+	key := nodeKey{bus, id}
+	history, err := c.emcyHistory(key)
+	if err != nil {
+		return err
+	}
+	history = append(history, emcy)
+	if len(history) > EMCY_HISTORY_SIZE {
+		history = history[len(history)-EMCY_HISTORY_SIZE:]
+	}
+	c.emcyHistories[key] = history
+	if len(c.emcyDirty) == 0 {
+		time.AfterFunc(EMCY_PERSIST_DELAY, c.persistEmcy)
+	}
+	c.emcyDirty[key] = true
+	return nil
+}
+
+// persistEmcy writes the histories changed since they were persisted last
+func (c *CanOpenUC) persistEmcy() {
+	c.emcyMu.Lock()
+	defer c.emcyMu.Unlock()
+	for key := range c.emcyDirty {
+		err := c.persistence.SetEmcyHistory(key.bus, key.id, c.emcyHistories[key])
+		if err != nil {
+			log.Error().Str("Function", "persistEmcy").Msgf("bus %s node %d: %v", key.bus, key.id, err)
+		}
+	}
+	c.emcyDirty = map[nodeKey]bool{}
+}
+
+// emcyHistory returns the history of a node, it is read from the persistence on first use. emcyMu must be held.
+func (c *CanOpenUC) emcyHistory(key nodeKey) ([]entities.Emcy, error) {
+	if history, ok := c.emcyHistories[key]; ok {
+		return history, nil
+	}
+	history, err := c.persistence.GetEmcyHistory(key.bus, key.id)
+	if err != nil {
+		return nil, err
+	}
+	c.emcyHistories[key] = history
+	return history, nil
+}
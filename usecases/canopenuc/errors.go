@@ -0,0 +1,141 @@
+package canopenuc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+
+	"github.com/jaster-prj/canopenrest/entities"
+)
+
+// ErrorKind classifies the errors returned by the use cases
+type ErrorKind string
+
+const (
+	ERROR_NOT_FOUND  ErrorKind = "not-found"
+	ERROR_TIMEOUT    ErrorKind = "timeout"
+	ERROR_SDO_ABORT  ErrorKind = "sdo-abort"
+	ERROR_BUS        ErrorKind = "bus-error"
+	ERROR_VALIDATION ErrorKind = "validation"
+	ERROR_CONFLICT   ErrorKind = "conflict"
+)
+
+// Error is an error of the use cases with its kind
+type Error struct {
+	Kind ErrorKind
+	Err  error
+}
+
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// SdoAbortError is an SDO transfer aborted by the node or by the gateway
+type SdoAbortError struct {
+	Index    uint16
+	Subindex uint8
+	Code     uint32
+}
+
+func (e *SdoAbortError) Error() string {
+	return fmt.Sprintf("SDO transfer of 0x%04X sub %d aborted: 0x%08X %s", e.Index, e.Subindex, e.Code, e.Text())
+}
+
+var sdoAbortTexts = map[uint32]string{
+	0x05030000: "Toggle bit not alternated",
+	0x05040000: "SDO protocol timed out",
+	0x05040001: "Client/server command specifier not valid or unknown",
+	0x05040002: "Invalid block size",
+	0x05040003: "Invalid sequence number",
+	0x05040004: "CRC error",
+	0x05040005: "Out of memory",
+	0x06010000: "Unsupported access to an object",
+	0x06010001: "Attempt to read a write only object",
+	0x06010002: "Attempt to write a read only object",
+	0x06020000: "Object does not exist in the object dictionary",
+	0x06040041: "Object cannot be mapped to the PDO",
+	0x06040042: "The number and length of the objects to be mapped would exceed PDO length",
+	0x06040043: "General parameter incompatibility reason",
+	0x06040047: "General internal incompatibility in the device",
+	0x06060000: "Access failed due to a hardware error",
+	0x06070010: "Data type does not match, length of service parameter does not match",
+	0x06070012: "Data type does not match, length of service parameter too high",
+	0x06070013: "Data type does not match, length of service parameter too low",
+	0x06090011: "Sub-index does not exist",
+	0x06090030: "Invalid value for parameter",
+	0x06090031: "Value of parameter written too high",
+	0x06090032: "Value of parameter written too low",
+	0x06090036: "Maximum value is less than minimum value",
+	0x060A0023: "Resource not available: SDO connection",
+	0x08000000: "General error",
+	0x08000020: "Data cannot be transferred or stored to the application",
+	0x08000021: "Data cannot be transferred or stored to the application because of local control",
+	0x08000022: "Data cannot be transferred or stored to the application because of the present device state",
+	0x08000023: "Object dictionary dynamic generation fails or no object dictionary is present",
+	0x08000024: "No data available",
+}
+
+// Text returns the CiA 301 meaning of the abort code
+func (e *SdoAbortError) Text() string {
+	if text, ok := sdoAbortTexts[e.Code]; ok {
+		return text
+	}
+	return "Unknown abort code"
+}
+
+// KindOf returns the kind of an error. Aborts by timeout and done contexts are timeouts, invalid eds
+// files are validation errors and missing stored files are not found. Other errors have no kind.
+func KindOf(err error) ErrorKind {
+	var sdoAbort *SdoAbortError
+	var ucErr *Error
+	var edsErr *entities.EdsValidationError
+	switch {
+	case errors.As(err, &sdoAbort):
+		if sdoAbort.Code == SDO_ABORT_TIMEOUT {
+			return ERROR_TIMEOUT
+		}
+		return ERROR_SDO_ABORT
+	case errors.As(err, &ucErr):
+		return ucErr.Kind
+	case errors.As(err, &edsErr):
+		return ERROR_VALIDATION
+	case errors.Is(err, context.DeadlineExceeded), errors.Is(err, context.Canceled):
+		return ERROR_TIMEOUT
+	case errors.Is(err, fs.ErrNotExist):
+		return ERROR_NOT_FOUND
+	}
+	return ""
+}
+
+func notFoundError(format string, a ...any) error {
+	return &Error{Kind: ERROR_NOT_FOUND, Err: fmt.Errorf(format, a...)}
+}
+
+func timeoutError(format string, a ...any) error {
+	return &Error{Kind: ERROR_TIMEOUT, Err: fmt.Errorf(format, a...)}
+}
+
+func busError(format string, a ...any) error {
+	return &Error{Kind: ERROR_BUS, Err: fmt.Errorf(format, a...)}
+}
+
+func validationError(format string, a ...any) error {
+	return &Error{Kind: ERROR_VALIDATION, Err: fmt.Errorf(format, a...)}
+}
+
+func conflictError(format string, a ...any) error {
+	return &Error{Kind: ERROR_CONFLICT, Err: fmt.Errorf(format, a...)}
+}
+
+// missing returns a not found error if a stored object does not exist, other errors unchanged
+func missing(err error, format string, a ...any) error {
+	if errors.Is(err, fs.ErrNotExist) {
+		return notFoundError(format, a...)
+	}
+	return err
+}
@@ -0,0 +1,261 @@
+package canopenuc
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jaster-prj/canopenrest/entities"
+	canopen "github.com/jaster-prj/go-canopen"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	FLASH_EVENT_CHAN_SIZE     = 50
+	FLASH_DEFAULT_CONCURRENCY = 4
+)
+
+// nodeKey identifies a node across buses
+type nodeKey struct {
+	bus string
+	id  int
+}
+
+// SubscribeFlashEvents returns a channel receiving the state transitions, progress updates and results
+// of all flash orders. Events are dropped while the channel is full. The returned function ends the subscription.
+func (c *CanOpenUC) SubscribeFlashEvents() (<-chan entities.FlashEvent, func()) {
+	c.flashEventsMu.Lock()
+	defer c.flashEventsMu.Unlock()
+	id := uuid.New()
+	events := make(chan entities.FlashEvent, FLASH_EVENT_CHAN_SIZE)
+	c.flashEventSubscribers[id] = events
+	return events, func() {
+		c.flashEventsMu.Lock()
+		defer c.flashEventsMu.Unlock()
+		if _, ok := c.flashEventSubscribers[id]; ok {
+			delete(c.flashEventSubscribers, id)
+			close(events)
+		}
+	}
+}
+
+// CancelFlash cancels a flash order. A queued order is removed from the queue at once, a running
+// order is aborted before its next step or during the program data download. The returned state is
+// the state of the order when the cancellation was requested.
+func (c *CanOpenUC) CancelFlash(ctx context.Context, id uuid.UUID) (*entities.FlashOrderState, error) {
+	c.flashMu.Lock()
+	defer c.flashMu.Unlock()
+	state, err := c.persistence.GetFlashState(id)
+	if err != nil {
+		return nil, missing(err, "flash order %s not found", id.String())
+	}
+	// a finished order is running until its worker returned
+	if state.State.Finished() {
+		return nil, conflictError("flash order %s already finished: %s", id.String(), state.State.String())
+	}
+	if cancel, ok := c.flashRunning[id]; ok {
+		cancel()
+		return state, nil
+	}
+	for i, order := range c.flashQueue {
+		if order.FlashOrderId == id {
+			c.flashQueue = append(c.flashQueue[:i], c.flashQueue[i+1:]...)
+			break
+		}
+	}
+	c.persistence.DeleteFlashFile(id)
+	err = c.setFlashState(id, entities.FlashCancelled, nil)
+	if err != nil {
+		return nil, err
+	}
+	return c.persistence.GetFlashState(id)
+}
+
+// GetFlashOrders returns the flash orders matching filter, newest first. offset and limit select a page,
+// limit 0 returns all remaining orders. total is the number of matching orders.
+func (c *CanOpenUC) GetFlashOrders(ctx context.Context, filter entities.FlashOrderFilter, offset int, limit int) ([]entities.FlashOrderState, int, error) {
+	if filter.Bus != "" {
+		canBus, err := c.getBus(filter.Bus)
+		if err != nil {
+			return nil, 0, err
+		}
+		filter.Bus = canBus.name
+	}
+	states, err := c.persistence.GetFlashStates()
+	if err != nil {
+		return nil, 0, err
+	}
+	orders := []entities.FlashOrderState{}
+	for _, state := range states {
+		if matchFlashOrder(filter, state) {
+			orders = append(orders, state)
+		}
+	}
+	sort.Slice(orders, func(i, j int) bool {
+		return orders[i].Requested.After(orders[j].Requested)
+	})
+	total := len(orders)
+	orders = orders[min(offset, total):]
+	if limit > 0 {
+		orders = orders[:min(limit, len(orders))]
+	}
+	return orders, total, nil
+}
+
+func matchFlashOrder(filter entities.FlashOrderFilter, state entities.FlashOrderState) bool {
+	switch {
+	case filter.Bus != "" && state.Order.Bus != filter.Bus:
+		return false
+	case filter.Id != nil && state.Order.Id != *filter.Id:
+		return false
+	case filter.State != nil && state.State != *filter.State:
+		return false
+	case filter.From != nil && state.Requested.Before(*filter.From):
+		return false
+	case filter.To != nil && !state.Requested.Before(*filter.To):
+		return false
+	}
+	return true
+}
+
+// queueFlashOrder records a new order and appends it to the flash queue in one step, a cancellation
+// finds the order queued. The order is started if possible.
+func (c *CanOpenUC) queueFlashOrder(flashOrder entities.FlashOrder) error {
+	c.flashMu.Lock()
+	defer c.flashMu.Unlock()
+	err := c.createFlashOrder(flashOrder)
+	if err != nil {
+		return err
+	}
+	c.flashQueue = append(c.flashQueue, flashOrder)
+	c.startFlashOrders()
+	return nil
+}
+
+// startFlashOrders starts queued orders in queue order while less than flashConcurrency orders run.
+// An order waits while another order flashes its node, orders for other nodes may pass it.
+// flashMu must be held.
+func (c *CanOpenUC) startFlashOrders() {
+	queue := c.flashQueue[:0]
+	for _, flashOrder := range c.flashQueue {
+		key := nodeKey{flashOrder.Bus, flashOrder.Id}
+		if len(c.flashRunning) >= c.flashConcurrency || c.flashNodes[key] {
+			queue = append(queue, flashOrder)
+			continue
+		}
+		ctx, cancel := context.WithCancel(context.Background())
+		c.flashRunning[flashOrder.FlashOrderId] = cancel
+		c.flashNodes[key] = true
+		go c.runFlashOrder(ctx, cancel, flashOrder)
+	}
+	c.flashQueue = queue
+}
+
+// runFlashOrder flashes a started order and starts the next queued orders when it is finished
+func (c *CanOpenUC) runFlashOrder(ctx context.Context, cancel context.CancelFunc, flashOrder entities.FlashOrder) {
+	c.flashNode(ctx, flashOrder)
+	cancel()
+	c.flashMu.Lock()
+	defer c.flashMu.Unlock()
+	delete(c.flashRunning, flashOrder.FlashOrderId)
+	delete(c.flashNodes, nodeKey{flashOrder.Bus, flashOrder.Id})
+	c.startFlashOrders()
+}
+
+// flashingNodes returns the ids of the nodes of a bus a flash order is running for
+func (c *CanOpenUC) flashingNodes(bus string) map[int]bool {
+	c.flashMu.Lock()
+	defer c.flashMu.Unlock()
+	ids := map[int]bool{}
+	for key := range c.flashNodes {
+		if key.bus == bus {
+			ids[key.id] = true
+		}
+	}
+	return ids
+}
+
+// abortFlashNode leaves the node of a flash order cancelled during state in a safe state and records
+// the cancellation. A program stopped for flashing may be incomplete, it is stopped again before the
+// node is reset to its bootloader or previous program.
+func (c *CanOpenUC) abortFlashNode(flashOrder entities.FlashOrder, node *canopen.Node, state entities.FlashState) {
+	log.Info().Str("Function", "abortFlashNode").Msgf("%s: cancelled during %s", flashOrder.FlashOrderId.String(), state.String())
+	if state >= entities.FlashProgramStopBefore {
+		if state < entities.FlashProgramStart {
+			err := writeSDO(context.Background(), node, PROGRAM_CONTROL, 1, []byte{byte(PROGRAM_CONTROL_STOP)})
+			if err != nil {
+				log.Warn().Str("Function", "abortFlashNode").Msgf("PROGRAM_CONTROL_STOP failed: %v", err)
+			}
+		}
+		node.NMTMaster.SetState("RESET")
+	}
+	c.setFlashState(flashOrder.FlashOrderId, entities.FlashCancelled, nil)
+}
+
+// sleep waits for d or until ctx is done
+func sleep(ctx context.Context, d time.Duration) {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+	}
+}
+
+// createFlashOrder records a new flash order and publishes its first state
+func (c *CanOpenUC) createFlashOrder(order entities.FlashOrder) error {
+	err := c.persistence.CreateFlashOrder(order)
+	if err != nil {
+		return err
+	}
+	c.emitFlashEvent(entities.FlashStateChanged, order.FlashOrderId)
+	return nil
+}
+
+// setFlashState records a state transition of a flash order and publishes it
+func (c *CanOpenUC) setFlashState(order uuid.UUID, state entities.FlashState, errState *error) error {
+	err := c.persistence.SetFlashState(order, state, errState)
+	if err != nil {
+		log.Error().Str("Function", "setFlashState").Msgf("%s: %v", order.String(), err)
+		return err
+	}
+	eventType := entities.FlashStateChanged
+	if state.Finished() {
+		eventType = entities.FlashFinished
+	}
+	c.emitFlashEvent(eventType, order)
+	return nil
+}
+
+// setFlashProgress records the download progress of a flash order and publishes it
+func (c *CanOpenUC) setFlashProgress(order uuid.UUID, progress entities.FlashProgress) error {
+	err := c.persistence.SetFlashProgress(order, progress)
+	if err != nil {
+		log.Error().Str("Function", "setFlashProgress").Msgf("%s: %v", order.String(), err)
+		return err
+	}
+	c.emitFlashEvent(entities.FlashProgressed, order)
+	return nil
+}
+
+// emitFlashEvent publishes the stored state of a flash order
+func (c *CanOpenUC) emitFlashEvent(eventType entities.FlashEventType, order uuid.UUID) {
+	state, err := c.persistence.GetFlashState(order)
+	if err != nil {
+		return
+	}
+	event := entities.FlashEvent{
+		Type:         eventType,
+		FlashOrderId: order,
+		State:        *state,
+	}
+	c.flashEventsMu.Lock()
+	defer c.flashEventsMu.Unlock()
+	for _, subscriber := range c.flashEventSubscribers {
+		select {
+		case subscriber <- event:
+		default:
+		}
+	}
+}
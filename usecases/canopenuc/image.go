@@ -0,0 +1,232 @@
+package canopenuc
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"io/fs"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jaster-prj/canopenrest/entities"
+	canopen "github.com/jaster-prj/go-canopen"
+	"github.com/rs/zerolog/log"
+)
+
+// UploadImage stores a firmware image read from content. A set image.Id is the expected SHA-256 of the
+// content, the upload is rejected if the content differs. Uploading an image again replaces its metadata.
+func (c *CanOpenUC) UploadImage(ctx context.Context, image entities.FirmwareImage, content io.Reader) (*entities.FirmwareImage, error) {
+	if image.Id != "" {
+		id, err := imageId(image.Id)
+		if err != nil {
+			return nil, err
+		}
+		content = &hashReader{reader: content, hash: sha256.New(), expected: id}
+	}
+	image.Uploaded = time.Now()
+	stored, err := c.persistence.StoreImage(image, content)
+	if err != nil {
+		return nil, err
+	}
+	log.Info().Str("Function", "UploadImage").Msgf("image %s stored: %d bytes", stored.Id, stored.Size)
+	return stored, nil
+}
+
+// GetImages returns the firmware images, newest first. A set productCode selects the images built for it.
+func (c *CanOpenUC) GetImages(ctx context.Context, productCode *uint32) ([]entities.FirmwareImage, error) {
+	stored, err := c.persistence.GetImages()
+	if err != nil {
+		return nil, err
+	}
+	images := []entities.FirmwareImage{}
+	for _, image := range stored {
+		if productCode == nil || (image.ProductCode != nil && *image.ProductCode == *productCode) {
+			images = append(images, image)
+		}
+	}
+	sort.Slice(images, func(i, j int) bool {
+		return images[i].Uploaded.After(images[j].Uploaded)
+	})
+	return images, nil
+}
+
+func (c *CanOpenUC) GetImage(ctx context.Context, id string) (*entities.FirmwareImage, error) {
+	id, err := imageId(id)
+	if err != nil {
+		return nil, err
+	}
+	image, err := c.persistence.GetImage(id)
+	if err != nil {
+		return nil, missing(err, "image %s not found", id)
+	}
+	return image, nil
+}
+
+// DeleteImage removes a firmware image. An image is kept while queued or running flash orders use it.
+func (c *CanOpenUC) DeleteImage(ctx context.Context, id string) error {
+	id, err := imageId(id)
+	if err != nil {
+		return err
+	}
+	c.flashMu.Lock()
+	defer c.flashMu.Unlock()
+	for _, order := range c.flashQueue {
+		if order.ImageId == id {
+			return conflictError("image %s is used by queued flash order %s", id, order.FlashOrderId.String())
+		}
+	}
+	for order := range c.flashRunning {
+		state, err := c.persistence.GetFlashState(order)
+		if err == nil && state.Order.ImageId == id {
+			return conflictError("image %s is used by running flash order %s", id, order.String())
+		}
+	}
+	err = c.persistence.DeleteImage(id)
+	if err != nil {
+		return missing(err, "image %s not found", id)
+	}
+	return nil
+}
+
+// FlashImage queues a flash order of a firmware image for each of the nodes ids and returns the orders
+// in the order of ids. No order is created if a node is unknown. requester names the client of the orders.
+// The image can not be deleted between its lookup and the queueing of the orders.
+func (c *CanOpenUC) FlashImage(ctx context.Context, imageId string, bus string, ids []int, requester string) ([]uuid.UUID, error) {
+	canBus, err := c.getBus(bus)
+	if err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return nil, validationError("no node to flash")
+	}
+	nodes := map[int]bool{}
+	for _, id := range ids {
+		if nodes[id] {
+			return nil, validationError("node %d requested twice", id)
+		}
+		nodes[id] = true
+		if _, err := c.getNode(canBus.name, id); err != nil {
+			return nil, err
+		}
+	}
+	c.flashMu.Lock()
+	defer c.flashMu.Unlock()
+	// Orders queued before a failure are started as well
+	defer c.startFlashOrders()
+	image, err := c.GetImage(ctx, imageId)
+	if err != nil {
+		return nil, err
+	}
+	orders := []uuid.UUID{}
+	for _, id := range ids {
+		order, err := uuid.NewUUID()
+		if err != nil {
+			return nil, err
+		}
+		flashOrder := entities.FlashOrder{
+			FlashOrderId: order,
+			Bus:          canBus.name,
+			Id:           id,
+			Size:         image.Size,
+			Version:      image.Version,
+			ImageHash:    image.Id,
+			ImageId:      image.Id,
+			Requester:    requester,
+		}
+		err = c.createFlashOrder(flashOrder)
+		if err != nil {
+			return nil, err
+		}
+		c.flashQueue = append(c.flashQueue, flashOrder)
+		orders = append(orders, order)
+	}
+	log.Info().Str("Function", "FlashImage").Msgf("image %s queued for %d nodes on bus %s", image.Id, len(orders), canBus.name)
+	return orders, nil
+}
+
+// checkProductCode reads the product code of the identity 0x1018 of a node and compares it with the product
+// code of the image of a flash order. Orders without image or images without product code pass.
+func (c *CanOpenUC) checkProductCode(ctx context.Context, flashOrder entities.FlashOrder, node *canopen.Node) error {
+	if flashOrder.ImageId == "" {
+		return nil
+	}
+	image, err := c.persistence.GetImage(flashOrder.ImageId)
+	if err != nil {
+		return missing(err, "image %s not found", flashOrder.ImageId)
+	}
+	if image.ProductCode == nil {
+		return nil
+	}
+	data, err := readSDO(ctx, node, IDENTITY, 2)
+	if err != nil {
+		return err
+	}
+	if len(data) < 4 {
+		return validationError("product code of node %d has %d bytes", flashOrder.Id, len(data))
+	}
+	productCode := binary.LittleEndian.Uint32(data)
+	if productCode != *image.ProductCode {
+		return validationError("product code 0x%08X of node %d does not match the product code 0x%08X of image %s", productCode, flashOrder.Id, *image.ProductCode, image.Id)
+	}
+	return nil
+}
+
+// openFlashFile opens the image of a flash order or the flash file uploaded with it
+func (c *CanOpenUC) openFlashFile(flashOrder entities.FlashOrder) (io.ReadCloser, error) {
+	var flashFile io.ReadCloser
+	var err error
+	if flashOrder.ImageId != "" {
+		flashFile, err = c.persistence.OpenImage(flashOrder.ImageId)
+	} else {
+		flashFile, err = c.persistence.OpenFlashFile(flashOrder.FlashOrderId)
+	}
+	if err != nil {
+		return nil, err
+	}
+	// A stored file shorter or longer than the order would be flashed incomplete
+	if file, ok := flashFile.(interface{ Stat() (fs.FileInfo, error) }); ok {
+		info, err := file.Stat()
+		if err == nil && info.Size() != flashOrder.Size {
+			err = fmt.Errorf("flash file has %d bytes, %d bytes expected", info.Size(), flashOrder.Size)
+		}
+		if err != nil {
+			flashFile.Close()
+			return nil, err
+		}
+	}
+	return flashFile, nil
+}
+
+// imageId returns the normalized id of an image, an id is a hex encoded SHA-256
+func imageId(id string) (string, error) {
+	id = strings.ToLower(id)
+	data, err := hex.DecodeString(id)
+	if err != nil || len(data) != sha256.Size {
+		return "", validationError("invalid image id %q: hex encoded SHA-256 expected", id)
+	}
+	return id, nil
+}
+
+// hashReader fails the last read of content whose SHA-256 differs from the expected hash
+type hashReader struct {
+	reader   io.Reader
+	hash     hash.Hash
+	expected string
+}
+
+func (r *hashReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	r.hash.Write(p[:n])
+	if err == io.EOF {
+		if actual := hex.EncodeToString(r.hash.Sum(nil)); actual != r.expected {
+			return n, validationError("content hash %s does not match the image hash %s", actual, r.expected)
+		}
+	}
+	return n, err
+}
@@ -0,0 +1,295 @@
+package canopenuc
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/jaster-prj/canopenrest/entities"
+	can "github.com/jaster-prj/go-can"
+	canopen "github.com/jaster-prj/go-canopen"
+)
+
+const (
+	LSS_MASTER_COB_ID        = 0x7E5
+	LSS_SLAVE_COB_ID         = 0x7E4
+	LSS_TIMEOUT              = 100 * time.Millisecond
+	LSS_UNCONFIGURED_NODE_ID = 0xFF
+)
+
+const (
+	lssSwitchStateGlobal     = 0x04
+	lssConfigureNodeId       = 0x11
+	lssConfigureBitTiming    = 0x13
+	lssActivateBitTiming     = 0x15
+	lssStoreConfiguration    = 0x17
+	lssSwitchSelectiveVendor = 0x40
+	lssSwitchSelectiveResult = 0x44
+	lssIdentifySlave         = 0x4F
+	lssFastscan              = 0x51
+	lssInquireVendor         = 0x5A
+	lssInquireNodeId         = 0x5E
+	lssFastscanConfirm       = 0x80
+)
+
+// lssBitTimings maps bitrates to the index of the CiA 301 bit timing table
+var lssBitTimings = map[int]byte{
+	1000000: 0,
+	800000:  1,
+	500000:  2,
+	250000:  3,
+	125000:  4,
+	50000:   6,
+	20000:   7,
+	10000:   8,
+}
+
+// LssSwitchStateGlobal switches all LSS slaves of a bus to waiting or configuration state.
+// An unconfigured node with a configured node id starts with the new node id when switched to waiting.
+func (c *CanOpenUC) LssSwitchStateGlobal(ctx context.Context, bus string, mode entities.LssMode) error {
+	canBus, err := c.getBus(bus)
+	if err != nil {
+		return err
+	}
+	request := []byte{lssSwitchStateGlobal, 0}
+	switch mode {
+	case entities.LssWaiting:
+	case entities.LssConfiguration:
+		request[1] = 1
+	default:
+		return validationError("unknown LSS mode %s", mode)
+	}
+	canBus.lssMu.Lock()
+	defer canBus.lssMu.Unlock()
+	_, err = lssRequest(ctx, canBus.network, request, 0, 0)
+	return err
+}
+
+// LssSwitchStateSelective switches the LSS slave with the identity to configuration state
+func (c *CanOpenUC) LssSwitchStateSelective(ctx context.Context, bus string, identity entities.LssIdentity) error {
+	canBus, err := c.getBus(bus)
+	if err != nil {
+		return err
+	}
+	canBus.lssMu.Lock()
+	defer canBus.lssMu.Unlock()
+	parts := []uint32{identity.VendorId, identity.ProductCode, identity.RevisionNumber, identity.SerialNumber}
+	for i, part := range parts {
+		request := binary.LittleEndian.AppendUint32([]byte{lssSwitchSelectiveVendor + byte(i)}, part)
+		expect := byte(0)
+		if i == len(parts)-1 {
+			expect = lssSwitchSelectiveResult
+		}
+		response, err := lssRequest(ctx, canBus.network, request, expect, LSS_TIMEOUT)
+		if err != nil {
+			return err
+		}
+		if expect != 0 && response == nil {
+			return notFoundError("no LSS slave with identity %08X:%08X:%08X:%08X", parts[0], parts[1], parts[2], parts[3])
+		}
+	}
+	return nil
+}
+
+// LssConfigureNodeId sets the pending node id of the LSS slave in configuration state,
+// 255 marks the node as unconfigured
+func (c *CanOpenUC) LssConfigureNodeId(ctx context.Context, bus string, id int) error {
+	if (id < 1 || id > 127) && id != LSS_UNCONFIGURED_NODE_ID {
+		return validationError("node id %d out of range 1-127", id)
+	}
+	return c.lssConfigure(ctx, bus, []byte{lssConfigureNodeId, byte(id)}, map[byte]string{
+		1: "node id out of range",
+	})
+}
+
+// LssConfigureBitTiming sets the pending bitrate of the LSS slave in configuration state
+func (c *CanOpenUC) LssConfigureBitTiming(ctx context.Context, bus string, bitrate int) error {
+	tableIndex, ok := lssBitTimings[bitrate]
+	if !ok {
+		return validationError("bitrate %d not in the CiA 301 bit timing table", bitrate)
+	}
+	return c.lssConfigure(ctx, bus, []byte{lssConfigureBitTiming, 0, tableIndex}, map[byte]string{
+		1: "bit timing not supported",
+	})
+}
+
+// LssActivateBitTiming makes all LSS slaves of a bus switch to their pending bitrate.
+// The slaves stop sending for switchDelay, switch and wait switchDelay again before sending.
+// The bitrate of the bus transport is not changed.
+func (c *CanOpenUC) LssActivateBitTiming(ctx context.Context, bus string, switchDelay time.Duration) error {
+	canBus, err := c.getBus(bus)
+	if err != nil {
+		return err
+	}
+	request := binary.LittleEndian.AppendUint16([]byte{lssActivateBitTiming}, uint16(switchDelay.Milliseconds()))
+	canBus.lssMu.Lock()
+	defer canBus.lssMu.Unlock()
+	_, err = lssRequest(ctx, canBus.network, request, 0, 0)
+	return err
+}
+
+// LssStoreConfiguration makes the LSS slave in configuration state store its pending node id and bitrate
+func (c *CanOpenUC) LssStoreConfiguration(ctx context.Context, bus string) error {
+	return c.lssConfigure(ctx, bus, []byte{lssStoreConfiguration}, map[byte]string{
+		1: "storing configuration not supported",
+		2: "storage media access error",
+	})
+}
+
+// LssInquireIdentity reads the identity of the LSS slave in configuration state
+func (c *CanOpenUC) LssInquireIdentity(ctx context.Context, bus string) (*entities.LssIdentity, error) {
+	canBus, err := c.getBus(bus)
+	if err != nil {
+		return nil, err
+	}
+	canBus.lssMu.Lock()
+	defer canBus.lssMu.Unlock()
+	parts := make([]uint32, 4)
+	for i := range parts {
+		command := lssInquireVendor + byte(i)
+		response, err := lssRequest(ctx, canBus.network, []byte{command}, command, LSS_TIMEOUT)
+		if err != nil {
+			return nil, err
+		}
+		if response == nil {
+			return nil, notFoundError("no LSS slave in configuration state on bus %s", canBus.name)
+		}
+		parts[i] = binary.LittleEndian.Uint32(response[1:5])
+	}
+	return &entities.LssIdentity{
+		VendorId:       parts[0],
+		ProductCode:    parts[1],
+		RevisionNumber: parts[2],
+		SerialNumber:   parts[3],
+	}, nil
+}
+
+// LssInquireNodeId reads the active node id of the LSS slave in configuration state
+func (c *CanOpenUC) LssInquireNodeId(ctx context.Context, bus string) (int, error) {
+	canBus, err := c.getBus(bus)
+	if err != nil {
+		return 0, err
+	}
+	canBus.lssMu.Lock()
+	defer canBus.lssMu.Unlock()
+	response, err := lssRequest(ctx, canBus.network, []byte{lssInquireNodeId}, lssInquireNodeId, LSS_TIMEOUT)
+	if err != nil {
+		return 0, err
+	}
+	if response == nil {
+		return 0, notFoundError("no LSS slave in configuration state on bus %s", canBus.name)
+	}
+	return int(response[1]), nil
+}
+
+// LssFastscan identifies one unconfigured LSS slave of a bus bit by bit and switches it to
+// configuration state. It returns nil if no unconfigured slave answers. A silent bit costs
+// one LSS timeout, so a scan takes up to 128 timeouts.
+func (c *CanOpenUC) LssFastscan(ctx context.Context, bus string) (*entities.LssIdentity, error) {
+	canBus, err := c.getBus(bus)
+	if err != nil {
+		return nil, err
+	}
+	canBus.lssMu.Lock()
+	defer canBus.lssMu.Unlock()
+	fastscan := func(idNumber uint32, bitCheck byte, lssSub byte, lssNext byte) (bool, error) {
+		request := binary.LittleEndian.AppendUint32([]byte{lssFastscan}, idNumber)
+		request = append(request, bitCheck, lssSub, lssNext)
+		response, err := lssRequest(ctx, canBus.network, request, lssIdentifySlave, LSS_TIMEOUT)
+		return response != nil, err
+	}
+	found, err := fastscan(0, lssFastscanConfirm, 0, 0)
+	if err != nil || !found {
+		return nil, err
+	}
+	parts := make([]uint32, 4)
+	for lssSub := range parts {
+		for bit := 31; bit >= 0; bit-- {
+			found, err := fastscan(parts[lssSub], byte(bit), byte(lssSub), byte(lssSub))
+			if err != nil {
+				return nil, err
+			}
+			if !found {
+				parts[lssSub] |= 1 << bit
+			}
+		}
+		lssNext := byte(lssSub+1) & 3
+		found, err := fastscan(parts[lssSub], 0, byte(lssSub), lssNext)
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			return nil, fmt.Errorf("LSS fastscan lost the slave at part %d", lssSub)
+		}
+	}
+	return &entities.LssIdentity{
+		VendorId:       parts[0],
+		ProductCode:    parts[1],
+		RevisionNumber: parts[2],
+		SerialNumber:   parts[3],
+	}, nil
+}
+
+// lssConfigure sends a configuration request and maps the error code of the answer
+func (c *CanOpenUC) lssConfigure(ctx context.Context, bus string, request []byte, errorCodes map[byte]string) error {
+	canBus, err := c.getBus(bus)
+	if err != nil {
+		return err
+	}
+	canBus.lssMu.Lock()
+	defer canBus.lssMu.Unlock()
+	response, err := lssRequest(ctx, canBus.network, request, request[0], LSS_TIMEOUT)
+	if err != nil {
+		return err
+	}
+	if response == nil {
+		return notFoundError("no LSS slave in configuration state on bus %s", canBus.name)
+	}
+	switch errorCode := response[1]; errorCode {
+	case 0:
+		return nil
+	case 0xFF:
+		return validationError("LSS manufacturer specific error 0x%02X", response[2])
+	default:
+		if description, ok := errorCodes[errorCode]; ok {
+			return validationError("LSS error: %s", description)
+		}
+		return validationError("LSS error 0x%02X", errorCode)
+	}
+}
+
+// lssRequest sends an LSS request and returns the answer with the command specifier expect,
+// nil if no answer arrived within timeout. Requests without answer use expect 0, mu must be held.
+// Waiting for the answer stops when ctx is done.
+func lssRequest(ctx context.Context, network *canopen.Network, request []byte, expect byte, timeout time.Duration) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	data := make([]byte, 8)
+	copy(data, request)
+	if expect == 0 {
+		if err := network.Send(LSS_MASTER_COB_ID, data); err != nil {
+			return nil, busError("send LSS request: %v", err)
+		}
+		return nil, nil
+	}
+	filterFunc := func(frm *can.Frame) bool {
+		return frm.ArbitrationID == LSS_SLAVE_COB_ID && frm.DLC == 8 && frm.Data[0] == expect
+	}
+	framesChan := network.AcquireFramesChan(&filterFunc)
+	defer network.ReleaseFramesChan(framesChan.ID)
+	if err := network.Send(LSS_MASTER_COB_ID, data); err != nil {
+		return nil, busError("send LSS request: %v", err)
+	}
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case frm := <-framesChan.C:
+		return frm.Data[:], nil
+	case <-timer.C:
+		return nil, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
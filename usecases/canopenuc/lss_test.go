@@ -0,0 +1,50 @@
+package canopenuc_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jaster-prj/canopenrest/entities"
+	"github.com/jaster-prj/canopenrest/external/simulator"
+	"github.com/jaster-prj/canopenrest/external/simulator/simtest"
+)
+
+func TestLssConfigureNodeId(t *testing.T) {
+	bus := simtest.NewBus(t)
+	slave := bus.AddSlave(t, simulator.SlaveConfig{NodeId: simulator.LSS_UNCONFIGURED_NODE_ID})
+	uc := simtest.NewCanOpenUC(t, bus)
+	ctx := testContext(t)
+
+	identity, err := uc.LssFastscan(ctx, simtest.BUS)
+	if err != nil {
+		t.Fatalf("fastscan: %v", err)
+	}
+	want := entities.LssIdentity{VendorId: 1, ProductCode: 1, RevisionNumber: 0x00010000}
+	if identity == nil || *identity != want {
+		t.Fatalf("fastscan found %+v, want %+v", identity, want)
+	}
+	// the fastscan leaves the found node in the configuration state
+	err = uc.LssConfigureNodeId(ctx, simtest.BUS, TEST_NODE)
+	if err != nil {
+		t.Fatalf("configure node id: %v", err)
+	}
+	err = uc.LssSwitchStateGlobal(ctx, simtest.BUS, entities.LssWaiting)
+	if err != nil {
+		t.Fatalf("switch to waiting: %v", err)
+	}
+	deadline := time.Now().Add(time.Second)
+	for slave.GetNodeId() != TEST_NODE {
+		if time.Now().After(deadline) {
+			t.Fatalf("node id %d, want %d", slave.GetNodeId(), TEST_NODE)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	identity, err = uc.LssFastscan(ctx, simtest.BUS)
+	if err != nil {
+		t.Fatalf("fastscan: %v", err)
+	}
+	if identity != nil {
+		t.Errorf("fastscan found %+v, want no unconfigured node", identity)
+	}
+}
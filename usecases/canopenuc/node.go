@@ -1,7 +1,12 @@
 package canopenuc
 
 import (
+	"context"
+	"sort"
+
+	"github.com/jaster-prj/canopenrest/entities"
 	canopen "github.com/jaster-prj/go-canopen"
+	"gopkg.in/ini.v1"
 )
 
 type NodeConfig struct {
@@ -18,6 +23,130 @@ func (nc *NodeConfig) CreateNode() (*canopen.Node, error) {
 	}
 	node := canopen.NewNode(nc.Node, nc.network, dicObj)
 	node.Init()
-	node.NMTMaster.ListenForHeartbeat()
+	// Heartbeats are tracked by the supervisor, the node needs no listener of its own
+	// and can be dropped from the cache without cleanup
 	return node, nil
 }
+
+// GetNodes returns the nodes of a bus with stored eds ordered by id
+func (c *CanOpenUC) GetNodes(ctx context.Context, bus string) ([]entities.NodeInfo, error) {
+	canBus, err := c.getBus(bus)
+	if err != nil {
+		return nil, err
+	}
+	ids, err := c.persistence.GetNodes(canBus.name)
+	if err != nil {
+		return nil, err
+	}
+	sort.Ints(ids)
+	nodes := []entities.NodeInfo{}
+	for _, id := range ids {
+		node, err := c.GetNode(ctx, canBus.name, id)
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, *node)
+	}
+	return nodes, nil
+}
+
+// GetNode returns file and device information of the stored eds of a node
+func (c *CanOpenUC) GetNode(ctx context.Context, bus string, id int) (*entities.NodeInfo, error) {
+	canBus, err := c.getBus(bus)
+	if err != nil {
+		return nil, err
+	}
+	edsFile, err := c.persistence.GetObjDict(canBus.name, id)
+	if err != nil {
+		return nil, missing(err, "no eds stored for node %d on bus %s", id, canBus.name)
+	}
+	iniData, err := ini.Load(edsFile)
+	if err != nil {
+		return nil, err
+	}
+	fileInfo := iniData.Section("FileInfo")
+	deviceInfo := iniData.Section("DeviceInfo")
+	return &entities.NodeInfo{
+		Bus:              canBus.name,
+		Id:               id,
+		FileName:         fileInfo.Key("FileName").String(),
+		FileVersion:      fileInfo.Key("FileVersion").String(),
+		Description:      fileInfo.Key("Description").String(),
+		ModificationDate: fileInfo.Key("ModificationDate").String(),
+		VendorName:       deviceInfo.Key("VendorName").String(),
+		VendorNumber:     deviceInfo.Key("VendorNumber").String(),
+		ProductName:      deviceInfo.Key("ProductName").String(),
+		ProductNumber:    deviceInfo.Key("ProductNumber").String(),
+		RevisionNumber:   deviceInfo.Key("RevisionNumber").String(),
+	}, nil
+}
+
+// ReplaceNode replaces the eds of an existing node, the cached node is rebuilt from the new eds
+func (c *CanOpenUC) ReplaceNode(ctx context.Context, bus string, id int, edsFile []byte) error {
+	canBus, err := c.getBus(bus)
+	if err != nil {
+		return err
+	}
+	_, err = c.persistence.GetObjDict(canBus.name, id)
+	if err != nil {
+		return missing(err, "no eds stored for node %d on bus %s", id, canBus.name)
+	}
+	return c.storeNode(canBus, id, edsFile)
+}
+
+// DeleteNode removes the eds of a node and drops the cached node
+func (c *CanOpenUC) DeleteNode(ctx context.Context, bus string, id int) error {
+	canBus, err := c.getBus(bus)
+	if err != nil {
+		return err
+	}
+	err = c.persistence.DeleteNode(canBus.name, id)
+	if err != nil {
+		return missing(err, "no eds stored for node %d on bus %s", id, canBus.name)
+	}
+	c.dropNode(canBus, id)
+	return nil
+}
+
+// storeNode checks and stores an eds, drops the cached node built from a previous eds
+// and rereads the heartbeat time the supervision depends on
+func (c *CanOpenUC) storeNode(bus *Bus, id int, edsFile []byte) error {
+	err := checkEds(edsFile)
+	if err != nil {
+		return err
+	}
+	err = c.persistence.SafeNode(bus.name, id, edsFile)
+	if err != nil {
+		return err
+	}
+	c.dropNode(bus, id)
+	_, err = c.getNode(bus.name, id)
+	if err != nil {
+		return err
+	}
+	c.livenessMu.Lock()
+	defer c.livenessMu.Unlock()
+	if liveness, ok := bus.liveness[id]; ok && liveness.online {
+		go c.refreshHeartbeatTime(bus, id)
+	}
+	return nil
+}
+
+// dropNode removes the cached node and stops its PDO listeners
+func (c *CanOpenUC) dropNode(bus *Bus, id int) {
+	c.nodesMu.Lock()
+	delete(bus.nodes, id)
+	c.nodesMu.Unlock()
+
+	c.pdoMu.Lock()
+	pdos, ok := bus.pdos[id]
+	delete(bus.pdos, id)
+	c.pdoMu.Unlock()
+	if ok {
+		pdos.mu.Lock()
+		for _, listener := range pdos.listeners {
+			bus.network.ReleaseFramesChan(listener.framesChanId)
+		}
+		pdos.mu.Unlock()
+	}
+}
@@ -0,0 +1,100 @@
+package canopenuc
+
+import (
+	"context"
+	"sync"
+)
+
+// nodeLock serializes the requests to a node. Waiting requests get the node in arrival order,
+// a request stops waiting when its context is done.
+type nodeLock struct {
+	mu      sync.Mutex
+	locked  bool
+	waiters []chan struct{}
+}
+
+// lock waits until the node is free or ctx is done
+func (l *nodeLock) lock(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	l.mu.Lock()
+	if !l.locked {
+		l.locked = true
+		l.mu.Unlock()
+		return nil
+	}
+	waiter := make(chan struct{})
+	l.waiters = append(l.waiters, waiter)
+	l.mu.Unlock()
+
+	select {
+	case <-waiter:
+		return nil
+	case <-ctx.Done():
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		for i, w := range l.waiters {
+			if w == waiter {
+				l.waiters = append(l.waiters[:i], l.waiters[i+1:]...)
+				return ctx.Err()
+			}
+		}
+		// The node was handed over while ctx got done, it is passed on
+		l.handOver()
+		return ctx.Err()
+	}
+}
+
+func (l *nodeLock) unlock() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.handOver()
+}
+
+// handOver passes the node to the longest waiting request or frees it, l.mu must be held
+func (l *nodeLock) handOver() {
+	if len(l.waiters) == 0 {
+		l.locked = false
+		return
+	}
+	waiter := l.waiters[0]
+	l.waiters = l.waiters[1:]
+	close(waiter)
+}
+
+// lockNode waits until no other request or flash order uses a node, locks it and returns the
+// function releasing it. Requests to other nodes are not affected.
+func (c *CanOpenUC) lockNode(ctx context.Context, bus *Bus, id int) (func(), error) {
+	c.nodesMu.Lock()
+	lock, ok := bus.nodeLocks[id]
+	if !ok {
+		lock = &nodeLock{}
+		bus.nodeLocks[id] = lock
+	}
+	c.nodesMu.Unlock()
+	if err := lock.lock(ctx); err != nil {
+		return nil, err
+	}
+	return lock.unlock, nil
+}
+
+// lockNodes locks several nodes of a bus, ids have to be ascending so that requests locking
+// several nodes cannot block each other
+func (c *CanOpenUC) lockNodes(ctx context.Context, bus *Bus, ids []int) (func(), error) {
+	unlocks := []func(){}
+	unlockAll := func() {
+		for i := len(unlocks) - 1; i >= 0; i-- {
+			unlocks[i]()
+		}
+	}
+	for _, id := range ids {
+		unlock, err := c.lockNode(ctx, bus, id)
+		if err != nil {
+			unlockAll()
+			return nil, err
+		}
+		unlocks = append(unlocks, unlock)
+	}
+	return unlockAll, nil
+}
@@ -0,0 +1,169 @@
+package canopenuc
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jaster-prj/canopenrest/entities"
+	canopen "github.com/jaster-prj/go-canopen"
+	"gopkg.in/ini.v1"
+)
+
+var (
+	edsIndexRegexp    = regexp.MustCompile(`^[0-9A-Fa-f]{4}$`)
+	edsSubindexRegexp = regexp.MustCompile(`^([0-9A-Fa-f]{4})sub([0-9A-Fa-f]+)$`)
+)
+
+var objectTypeNames = map[byte]string{
+	0x0:            "NULL",
+	0x2:            "DOMAIN",
+	0x5:            "DEFTYPE",
+	0x6:            "DEFSTRUCT",
+	canopen.DicVar: "VAR",
+	canopen.DicArr: "ARRAY",
+	canopen.DicRec: "RECORD",
+}
+
+// GetEds returns the eds file stored for a node
+func (c *CanOpenUC) GetEds(ctx context.Context, bus string, id int) ([]byte, error) {
+	canBus, err := c.getBus(bus)
+	if err != nil {
+		return nil, err
+	}
+	edsFile, err := c.persistence.GetObjDict(canBus.name, id)
+	if err != nil {
+		return nil, missing(err, "no eds stored for node %d on bus %s", id, canBus.name)
+	}
+	return edsFile, nil
+}
+
+// GetObjectDictionary returns the objects of the eds stored for a node ordered by index
+func (c *CanOpenUC) GetObjectDictionary(ctx context.Context, bus string, id int) ([]entities.OdObject, error) {
+	edsFile, err := c.GetEds(ctx, bus, id)
+	if err != nil {
+		return nil, err
+	}
+	return parseObjectDictionary(edsFile)
+}
+
+// FindObjectByName resolves a parameter name of the eds to index and subindex. Names are compared
+// without case, spaces and punctuation, so ProducerHeartbeatTime finds "Producer heartbeat time".
+// Sub-indexes of arrays and records are addressed as <object>.<sub-index>, e.g. IdentityObject.VendorId.
+func (c *CanOpenUC) FindObjectByName(ctx context.Context, bus string, id int, name string) (uint16, uint8, error) {
+	objects, err := c.GetObjectDictionary(ctx, bus, id)
+	if err != nil {
+		return 0, 0, err
+	}
+	objectName, subName, hasSub := strings.Cut(name, ".")
+	var found *entities.OdObject
+	for i := range objects {
+		if normalizeName(objects[i].Name) != normalizeName(objectName) {
+			continue
+		}
+		if found != nil {
+			return 0, 0, validationError("name %s is ambiguous", objectName)
+		}
+		found = &objects[i]
+	}
+	if found == nil {
+		return 0, 0, notFoundError("no object %s in eds of node %d", objectName, id)
+	}
+	if !hasSub {
+		if len(found.SubObjects) > 0 {
+			return 0, 0, validationError("%s is a %s object, address a sub-index as %s.<name>", objectName, found.ObjectType, objectName)
+		}
+		return found.Index, 0, nil
+	}
+	var foundSub *entities.OdObject
+	for i := range found.SubObjects {
+		if normalizeName(found.SubObjects[i].Name) != normalizeName(subName) {
+			continue
+		}
+		if foundSub != nil {
+			return 0, 0, validationError("name %s is ambiguous in %s", subName, objectName)
+		}
+		foundSub = &found.SubObjects[i]
+	}
+	if foundSub == nil {
+		return 0, 0, notFoundError("no sub-index %s in %s", subName, objectName)
+	}
+	return foundSub.Index, foundSub.Subindex, nil
+}
+
+// parseObjectDictionary reads the object sections of an eds file
+func parseObjectDictionary(edsFile []byte) ([]entities.OdObject, error) {
+	iniData, err := ini.Load(edsFile)
+	if err != nil {
+		return nil, err
+	}
+	objects := map[uint16]*entities.OdObject{}
+	subObjects := map[uint16][]entities.OdObject{}
+	for _, sec := range iniData.Sections() {
+		name := sec.Name()
+		switch {
+		case edsIndexRegexp.MatchString(name):
+			index, _ := strconv.ParseUint(name, 16, 16)
+			object := parseOdObject(sec, uint16(index), 0)
+			objects[uint16(index)] = &object
+		case edsSubindexRegexp.MatchString(name):
+			match := edsSubindexRegexp.FindStringSubmatch(name)
+			index, _ := strconv.ParseUint(match[1], 16, 16)
+			subindex, err := strconv.ParseUint(match[2], 16, 8)
+			if err != nil {
+				return nil, fmt.Errorf("section %s: %w", name, err)
+			}
+			subObjects[uint16(index)] = append(subObjects[uint16(index)], parseOdObject(sec, uint16(index), uint8(subindex)))
+		}
+	}
+	result := []entities.OdObject{}
+	for index, object := range objects {
+		subs := subObjects[index]
+		sort.Slice(subs, func(i, j int) bool {
+			return subs[i].Subindex < subs[j].Subindex
+		})
+		object.SubObjects = subs
+		result = append(result, *object)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Index < result[j].Index
+	})
+	return result, nil
+}
+
+func parseOdObject(sec *ini.Section, index uint16, subindex uint8) entities.OdObject {
+	object := entities.OdObject{
+		Index:        index,
+		Subindex:     subindex,
+		Name:         sec.Key("ParameterName").String(),
+		ObjectType:   "VAR",
+		AccessType:   strings.ToLower(sec.Key("AccessType").String()),
+		DefaultValue: sec.Key("DefaultValue").String(),
+		PdoMapping:   sec.Key("PDOMapping").MustBool(false),
+		LowLimit:     sec.Key("LowLimit").String(),
+		HighLimit:    sec.Key("HighLimit").String(),
+	}
+	if objectType, err := strconv.ParseUint(sec.Key("ObjectType").String(), 0, 8); err == nil {
+		if name, ok := objectTypeNames[byte(objectType)]; ok {
+			object.ObjectType = name
+		}
+	}
+	if dataType, err := strconv.ParseUint(sec.Key("DataType").String(), 0, 16); err == nil {
+		object.DataType = dataTypeName(byte(dataType))
+	}
+	return object
+}
+
+// normalizeName drops case, spaces and punctuation of a parameter name
+func normalizeName(name string) string {
+	var result strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			result.WriteRune(r)
+		}
+	}
+	return result.String()
+}
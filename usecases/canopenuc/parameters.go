@@ -0,0 +1,127 @@
+package canopenuc
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/jaster-prj/canopenrest/entities"
+)
+
+const (
+	STORE_PARAMETERS           = 4112       //0x1010
+	RESTORE_DEFAULT_PARAMETERS = 4113       //0x1011
+	STORE_SIGNATURE            = 0x65766173 //"save"
+	RESTORE_SIGNATURE          = 0x64616F6C //"load"
+)
+
+const (
+	PARAMETER_ON_COMMAND   = 1 << 0
+	PARAMETER_AUTONOMOUSLY = 1 << 1
+)
+
+// parameterGroups are the sub-indexes of 0x1010 and 0x1011 in order
+var parameterGroups = []entities.ParameterGroup{
+	entities.ParameterGroupAll,
+	entities.ParameterGroupCommunication,
+	entities.ParameterGroupApplication,
+	entities.ParameterGroupManufacturer,
+}
+
+var parameterResets = map[entities.ParameterReset]string{
+	entities.ParameterResetNode:          "RESET",
+	entities.ParameterResetCommunication: "RESET COMMUNICATION",
+}
+
+// GetParameterCapabilities reads from 0x1010 and 0x1011 which parameter groups the node
+// stores and restores. Groups not in the eds of the node are left out.
+func (c *CanOpenUC) GetParameterCapabilities(ctx context.Context, bus string, id int) ([]entities.ParameterCapability, error) {
+	node, err := c.getNode(bus, id)
+	if err != nil {
+		return nil, err
+	}
+	capabilities := []entities.ParameterCapability{}
+	for i, group := range parameterGroups {
+		subindex := uint8(i + 1)
+		capability := entities.ParameterCapability{
+			Group:    group,
+			Subindex: subindex,
+		}
+		if !hasObject(node, STORE_PARAMETERS, subindex) && !hasObject(node, RESTORE_DEFAULT_PARAMETERS, subindex) {
+			continue
+		}
+		if hasObject(node, STORE_PARAMETERS, subindex) {
+			flags, err := c.readParameterFlags(ctx, bus, id, STORE_PARAMETERS, subindex)
+			if err != nil {
+				return nil, err
+			}
+			capability.Store = flags&PARAMETER_ON_COMMAND != 0
+			capability.StoreAutonomously = flags&PARAMETER_AUTONOMOUSLY != 0
+		}
+		if hasObject(node, RESTORE_DEFAULT_PARAMETERS, subindex) {
+			flags, err := c.readParameterFlags(ctx, bus, id, RESTORE_DEFAULT_PARAMETERS, subindex)
+			if err != nil {
+				return nil, err
+			}
+			capability.Restore = flags&PARAMETER_ON_COMMAND != 0
+		}
+		capabilities = append(capabilities, capability)
+	}
+	return capabilities, nil
+}
+
+// StoreParameters saves a parameter group in the non-volatile memory of the node (0x1010).
+// The node has to support saving the group on command. reset resets the node afterwards.
+func (c *CanOpenUC) StoreParameters(ctx context.Context, bus string, id int, group entities.ParameterGroup, reset entities.ParameterReset) error {
+	return c.parameterCommand(ctx, bus, id, STORE_PARAMETERS, STORE_SIGNATURE, group, reset)
+}
+
+// RestoreDefaultParameters restores the defaults of a parameter group (0x1011). The node applies
+// the defaults with the next reset, reset requests it right away.
+func (c *CanOpenUC) RestoreDefaultParameters(ctx context.Context, bus string, id int, group entities.ParameterGroup, reset entities.ParameterReset) error {
+	return c.parameterCommand(ctx, bus, id, RESTORE_DEFAULT_PARAMETERS, RESTORE_SIGNATURE, group, reset)
+}
+
+// parameterCommand checks the capability flags of the group and writes the signature to it
+func (c *CanOpenUC) parameterCommand(ctx context.Context, bus string, id int, index uint16, signature uint32, group entities.ParameterGroup, reset entities.ParameterReset) error {
+	subindex, err := parameterSubindex(group)
+	if err != nil {
+		return err
+	}
+	nmtState, ok := parameterResets[reset]
+	if !ok && reset != entities.ParameterResetNone {
+		return validationError("invalid reset %q", reset)
+	}
+	flags, err := c.readParameterFlags(ctx, bus, id, index, subindex)
+	if err != nil {
+		return err
+	}
+	if flags&PARAMETER_ON_COMMAND == 0 {
+		return validationError("node %d does not support 0x%04X for %s parameters on command", id, index, group)
+	}
+	err = c.WriteSDO(ctx, bus, id, index, subindex, binary.LittleEndian.AppendUint32(nil, signature))
+	if err != nil {
+		return err
+	}
+	if reset == entities.ParameterResetNone {
+		return nil
+	}
+	return c.WriteNmt(ctx, bus, id, nmtState)
+}
+
+func (c *CanOpenUC) readParameterFlags(ctx context.Context, bus string, id int, index uint16, subindex uint8) (uint32, error) {
+	data, err := c.ReadSDO(ctx, bus, id, index, subindex)
+	if err != nil {
+		return 0, fmt.Errorf("read 0x%04X sub %d: %w", index, subindex, err)
+	}
+	return uint32(littleEndianUint(data)), nil
+}
+
+func parameterSubindex(group entities.ParameterGroup) (uint8, error) {
+	for i, known := range parameterGroups {
+		if known == group {
+			return uint8(i + 1), nil
+		}
+	}
+	return 0, validationError("invalid parameter group %q", group)
+}
@@ -0,0 +1,557 @@
+package canopenuc
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/jaster-prj/canopenrest/common"
+	"github.com/jaster-prj/canopenrest/entities"
+	can "github.com/jaster-prj/go-can"
+	canopen "github.com/jaster-prj/go-canopen"
+)
+
+const (
+	RPDO_COMMUNICATION_PARAMETER = 5120 //0x1400
+	RPDO_MAPPING_PARAMETER       = 5632 //0x1600
+	TPDO_COMMUNICATION_PARAMETER = 6144 //0x1800
+	TPDO_MAPPING_PARAMETER       = 6656 //0x1A00
+	PDO_COUNT                    = 512
+)
+
+const (
+	PDO_COB_ID_INVALID = 1 << 31
+	PDO_COB_ID_NO_RTR  = 1 << 30
+	PDO_COB_ID_MASK    = 0x7FF
+	PDO_MAX_BITS       = 64
+)
+
+// pdoListener keeps the latest frame received for a TPDO
+type pdoListener struct {
+	mu           sync.Mutex
+	config       entities.PdoConfig
+	framesChanId string
+	data         []byte
+	timestamp    *time.Time
+}
+
+// nodePdos caches the PDO configuration of a node, TPDOs are listened to once configured
+type nodePdos struct {
+	mu        sync.Mutex
+	rx        map[int]*entities.PdoConfig
+	listeners map[int]*pdoListener
+}
+
+// ReadPdos reads the configuration of all PDOs of a direction available in the eds of the node
+func (c *CanOpenUC) ReadPdos(ctx context.Context, bus string, id int, direction entities.PdoDirection) ([]entities.PdoConfig, error) {
+	node, err := c.getNode(bus, id)
+	if err != nil {
+		return nil, err
+	}
+	communication, _, err := pdoIndexes(direction, 1)
+	if err != nil {
+		return nil, err
+	}
+	if node.ObjectDic == nil {
+		return nil, errors.New("node has no object dictionary")
+	}
+	configs := []entities.PdoConfig{}
+	for number := 1; number <= PDO_COUNT; number++ {
+		if node.ObjectDic.FindIndex(communication+uint16(number-1)) == nil {
+			continue
+		}
+		config, err := c.ReadPdo(ctx, bus, id, direction, number)
+		if err != nil {
+			return nil, err
+		}
+		configs = append(configs, *config)
+	}
+	return configs, nil
+}
+
+// ReadPdo reads the communication and mapping parameters of a PDO
+func (c *CanOpenUC) ReadPdo(ctx context.Context, bus string, id int, direction entities.PdoDirection, number int) (*entities.PdoConfig, error) {
+	node, err := c.getNode(bus, id)
+	if err != nil {
+		return nil, err
+	}
+	canBus, _ := c.getBus(bus)
+	unlock, err := c.lockNode(ctx, canBus, id)
+	if err != nil {
+		return nil, err
+	}
+	config, err := readPdoConfig(ctx, node, direction, number)
+	unlock()
+	if err != nil {
+		return nil, err
+	}
+	err = c.updatePdo(bus, node, *config)
+	if err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// WritePdo configures a PDO and returns the configuration read back from the node.
+// The PDO is disabled while its parameters and mapping are written and enabled afterwards if requested.
+func (c *CanOpenUC) WritePdo(ctx context.Context, bus string, id int, config entities.PdoConfig) (*entities.PdoConfig, error) {
+	node, err := c.getNode(bus, id)
+	if err != nil {
+		return nil, err
+	}
+	canBus, _ := c.getBus(bus)
+	unlock, err := c.lockNode(ctx, canBus, id)
+	if err != nil {
+		return nil, err
+	}
+	err = writePdoConfig(ctx, node, config)
+	if err != nil {
+		unlock()
+		return nil, err
+	}
+	written, err := readPdoConfig(ctx, node, config.Direction, config.Number)
+	unlock()
+	if err != nil {
+		return nil, err
+	}
+	err = c.updatePdo(bus, node, *written)
+	if err != nil {
+		return nil, err
+	}
+	return written, nil
+}
+
+// writePdoConfig writes the communication and mapping parameters of a PDO by SDO
+func writePdoConfig(ctx context.Context, node *canopen.Node, config entities.PdoConfig) error {
+	communication, mapping, err := pdoIndexes(config.Direction, config.Number)
+	if err != nil {
+		return err
+	}
+	if !hasObject(node, communication, 1) || !hasObject(node, mapping, 0) {
+		return notFoundError("%s %d not available in object dictionary", config.Direction, config.Number)
+	}
+	if config.CobId&^PDO_COB_ID_MASK != 0 {
+		return validationError("invalid cob id 0x%X", config.CobId)
+	}
+	bits := 0
+	for _, entry := range config.Mapping {
+		bits += int(entry.BitLength)
+	}
+	if bits > PDO_MAX_BITS {
+		return validationError("mapping exceeds %d bits", PDO_MAX_BITS)
+	}
+
+	cobId := config.CobId
+	if !config.RtrAllowed {
+		cobId |= PDO_COB_ID_NO_RTR
+	}
+	err = writeSDO(ctx, node, communication, 1, binary.LittleEndian.AppendUint32(nil, cobId|PDO_COB_ID_INVALID))
+	if err != nil {
+		return fmt.Errorf("disable pdo: %w", err)
+	}
+	err = writeSDO(ctx, node, communication, 2, []byte{config.TransmissionType})
+	if err != nil {
+		return fmt.Errorf("write transmission type: %w", err)
+	}
+	if config.InhibitTime != nil {
+		err = writeSDO(ctx, node, communication, 3, binary.LittleEndian.AppendUint16(nil, *config.InhibitTime))
+		if err != nil {
+			return fmt.Errorf("write inhibit time: %w", err)
+		}
+	}
+	if config.EventTimer != nil {
+		err = writeSDO(ctx, node, communication, 5, binary.LittleEndian.AppendUint16(nil, *config.EventTimer))
+		if err != nil {
+			return fmt.Errorf("write event timer: %w", err)
+		}
+	}
+	if config.SyncStart != nil {
+		err = writeSDO(ctx, node, communication, 6, []byte{*config.SyncStart})
+		if err != nil {
+			return fmt.Errorf("write sync start value: %w", err)
+		}
+	}
+	err = writeSDO(ctx, node, mapping, 0, []byte{0})
+	if err != nil {
+		return fmt.Errorf("clear mapping: %w", err)
+	}
+	for i, entry := range config.Mapping {
+		value := uint32(entry.Index)<<16 | uint32(entry.Subindex)<<8 | uint32(entry.BitLength)
+		err = writeSDO(ctx, node, mapping, uint8(i+1), binary.LittleEndian.AppendUint32(nil, value))
+		if err != nil {
+			return fmt.Errorf("write mapping %d: %w", i+1, err)
+		}
+	}
+	err = writeSDO(ctx, node, mapping, 0, []byte{byte(len(config.Mapping))})
+	if err != nil {
+		return fmt.Errorf("write mapping count: %w", err)
+	}
+	if config.Enabled {
+		err = writeSDO(ctx, node, communication, 1, binary.LittleEndian.AppendUint32(nil, cobId))
+		if err != nil {
+			return fmt.Errorf("enable pdo: %w", err)
+		}
+	}
+	return nil
+}
+
+// GetPdoValues returns the latest received data of a TPDO decoded per mapped object.
+// The TPDO is listened to from the first call on, values are reported once a frame was received.
+func (c *CanOpenUC) GetPdoValues(ctx context.Context, bus string, id int, number int) (*entities.PdoValues, error) {
+	node, err := c.getNode(bus, id)
+	if err != nil {
+		return nil, err
+	}
+	pdos, err := c.getNodePdos(bus, id)
+	if err != nil {
+		return nil, err
+	}
+	pdos.mu.Lock()
+	listener, ok := pdos.listeners[number]
+	pdos.mu.Unlock()
+	if !ok {
+		_, err = c.ReadPdo(ctx, bus, id, entities.PdoTransmit, number)
+		if err != nil {
+			return nil, err
+		}
+		pdos.mu.Lock()
+		listener = pdos.listeners[number]
+		pdos.mu.Unlock()
+	}
+
+	listener.mu.Lock()
+	defer listener.mu.Unlock()
+	values := &entities.PdoValues{
+		Number:    number,
+		CobId:     listener.config.CobId,
+		Received:  listener.data != nil,
+		Timestamp: listener.timestamp,
+		Data:      listener.data,
+		Values:    []entities.PdoValue{},
+	}
+	offset := 0
+	for _, entry := range listener.config.Mapping {
+		value := entities.PdoValue{
+			Index:    entry.Index,
+			Subindex: entry.Subindex,
+			Name:     entry.Name,
+		}
+		if listener.data != nil {
+			dataType := objectDataType(node, entry.Index, entry.Subindex)
+			raw := extractBits(listener.data, offset, int(entry.BitLength))
+			if canopen.IsSignedType(dataType) {
+				raw = signExtend(raw, int(entry.BitLength), dataTypeSize(dataType))
+			}
+			value.Value = decodeValue(dataType, raw)
+		}
+		values.Values = append(values.Values, value)
+		offset += int(entry.BitLength)
+	}
+	return values, nil
+}
+
+// TransmitPdo sends raw data with the cob id of a RPDO
+func (c *CanOpenUC) TransmitPdo(ctx context.Context, bus string, id int, number int, data []byte) error {
+	node, err := c.getNode(bus, id)
+	if err != nil {
+		return err
+	}
+	if len(data) > 8 {
+		return validationError("pdo data exceeds 8 bytes")
+	}
+	config, err := c.getRpdo(ctx, bus, id, number)
+	if err != nil {
+		return err
+	}
+	if !config.Enabled {
+		return conflictError("rx %d is disabled", number)
+	}
+	err = node.Send(config.CobId, data)
+	if err != nil {
+		return busError("send rx %d: %v", number, err)
+	}
+	return nil
+}
+
+// TransmitPdoValues packs values according to the mapping of a RPDO and sends it.
+// Mapped objects without a value are sent as zero.
+func (c *CanOpenUC) TransmitPdoValues(ctx context.Context, bus string, id int, number int, values []entities.PdoValue) error {
+	node, err := c.getNode(bus, id)
+	if err != nil {
+		return err
+	}
+	config, err := c.getRpdo(ctx, bus, id, number)
+	if err != nil {
+		return err
+	}
+	bits := 0
+	for _, entry := range config.Mapping {
+		bits += int(entry.BitLength)
+	}
+	data := make([]byte, (bits+7)/8)
+	found := map[uint32]bool{}
+	offset := 0
+	for _, entry := range config.Mapping {
+		key := uint32(entry.Index)<<8 | uint32(entry.Subindex)
+		for _, value := range values {
+			if value.Index != entry.Index || value.Subindex != entry.Subindex {
+				continue
+			}
+			raw, err := encodeValue(objectDataType(node, entry.Index, entry.Subindex), value.Value)
+			if err != nil {
+				return fmt.Errorf("0x%04X sub %d: %w", entry.Index, entry.Subindex, err)
+			}
+			insertBits(data, offset, int(entry.BitLength), raw)
+			found[key] = true
+		}
+		offset += int(entry.BitLength)
+	}
+	for _, value := range values {
+		if !found[uint32(value.Index)<<8|uint32(value.Subindex)] {
+			return validationError("0x%04X sub %d not mapped to rx %d", value.Index, value.Subindex, number)
+		}
+	}
+	return c.TransmitPdo(ctx, bus, id, number, data)
+}
+
+func (c *CanOpenUC) getNodePdos(bus string, id int) (*nodePdos, error) {
+	canBus, err := c.getBus(bus)
+	if err != nil {
+		return nil, err
+	}
+	c.pdoMu.Lock()
+	defer c.pdoMu.Unlock()
+	pdos, ok := canBus.pdos[id]
+	if !ok {
+		pdos = &nodePdos{
+			rx:        map[int]*entities.PdoConfig{},
+			listeners: map[int]*pdoListener{},
+		}
+		canBus.pdos[id] = pdos
+	}
+	return pdos, nil
+}
+
+func (c *CanOpenUC) getRpdo(ctx context.Context, bus string, id int, number int) (*entities.PdoConfig, error) {
+	pdos, err := c.getNodePdos(bus, id)
+	if err != nil {
+		return nil, err
+	}
+	pdos.mu.Lock()
+	config, ok := pdos.rx[number]
+	pdos.mu.Unlock()
+	if ok {
+		return config, nil
+	}
+	return c.ReadPdo(ctx, bus, id, entities.PdoReceive, number)
+}
+
+// updatePdo caches a read or written configuration and (re)starts listening to TPDOs
+func (c *CanOpenUC) updatePdo(bus string, node *canopen.Node, config entities.PdoConfig) error {
+	pdos, err := c.getNodePdos(bus, node.ID)
+	if err != nil {
+		return err
+	}
+	pdos.mu.Lock()
+	defer pdos.mu.Unlock()
+	if config.Direction == entities.PdoReceive {
+		pdos.rx[config.Number] = &config
+		return nil
+	}
+	listener, ok := pdos.listeners[config.Number]
+	if ok {
+		listener.mu.Lock()
+		unchanged := listener.config.CobId == config.CobId
+		listener.config = config
+		listener.mu.Unlock()
+		if unchanged {
+			return nil
+		}
+		node.ReleaseFramesChanFromNetwork(listener.framesChanId)
+	}
+	listener = &pdoListener{
+		config: config,
+	}
+	pdos.listeners[config.Number] = listener
+	cobId := config.CobId
+	filterFunc := func(frm *can.Frame) bool {
+		return frm.ArbitrationID == cobId
+	}
+	framesChan := node.AcquireFramesChanFromNetwork(&filterFunc)
+	listener.framesChanId = framesChan.ID
+	go func() {
+		for frm := range framesChan.C {
+			now := time.Now()
+			listener.mu.Lock()
+			listener.data = append([]byte{}, frm.Data[:frm.DLC]...)
+			listener.timestamp = &now
+			listener.mu.Unlock()
+		}
+	}()
+	return nil
+}
+
+// readPdoConfig reads the communication and mapping parameters of a PDO by SDO
+func readPdoConfig(ctx context.Context, node *canopen.Node, direction entities.PdoDirection, number int) (*entities.PdoConfig, error) {
+	communication, mapping, err := pdoIndexes(direction, number)
+	if err != nil {
+		return nil, err
+	}
+	if !hasObject(node, communication, 1) || !hasObject(node, mapping, 0) {
+		return nil, notFoundError("%s %d not available in object dictionary", direction, number)
+	}
+	data, err := readSDO(ctx, node, communication, 1)
+	if err != nil {
+		return nil, fmt.Errorf("read cob id: %w", err)
+	}
+	cobId := littleEndianUint(data)
+	config := &entities.PdoConfig{
+		Direction:  direction,
+		Number:     number,
+		CobId:      uint32(cobId & PDO_COB_ID_MASK),
+		Enabled:    cobId&PDO_COB_ID_INVALID == 0,
+		RtrAllowed: cobId&PDO_COB_ID_NO_RTR == 0,
+		Mapping:    []entities.PdoMapping{},
+	}
+	data, err = readSDO(ctx, node, communication, 2)
+	if err != nil {
+		return nil, fmt.Errorf("read transmission type: %w", err)
+	}
+	config.TransmissionType = uint8(littleEndianUint(data))
+	if hasObject(node, communication, 3) {
+		data, err = readSDO(ctx, node, communication, 3)
+		if err != nil {
+			return nil, fmt.Errorf("read inhibit time: %w", err)
+		}
+		config.InhibitTime = common.POINTER(uint16(littleEndianUint(data)))
+	}
+	if hasObject(node, communication, 5) {
+		data, err = readSDO(ctx, node, communication, 5)
+		if err != nil {
+			return nil, fmt.Errorf("read event timer: %w", err)
+		}
+		config.EventTimer = common.POINTER(uint16(littleEndianUint(data)))
+	}
+	if hasObject(node, communication, 6) {
+		data, err = readSDO(ctx, node, communication, 6)
+		if err != nil {
+			return nil, fmt.Errorf("read sync start value: %w", err)
+		}
+		config.SyncStart = common.POINTER(uint8(littleEndianUint(data)))
+	}
+	data, err = readSDO(ctx, node, mapping, 0)
+	if err != nil {
+		return nil, fmt.Errorf("read mapping count: %w", err)
+	}
+	count := int(littleEndianUint(data))
+	for i := 1; i <= count; i++ {
+		data, err = readSDO(ctx, node, mapping, uint8(i))
+		if err != nil {
+			return nil, fmt.Errorf("read mapping %d: %w", i, err)
+		}
+		value := littleEndianUint(data)
+		entry := entities.PdoMapping{
+			Index:     uint16(value >> 16),
+			Subindex:  uint8(value >> 8),
+			BitLength: uint8(value),
+		}
+		if obj := findObject(node, entry.Index, entry.Subindex); obj != nil {
+			entry.Name = obj.GetName()
+			entry.DataType = dataTypeName(obj.GetDataType())
+		}
+		config.Mapping = append(config.Mapping, entry)
+	}
+	return config, nil
+}
+
+func pdoIndexes(direction entities.PdoDirection, number int) (uint16, uint16, error) {
+	if number < 1 || number > PDO_COUNT {
+		return 0, 0, validationError("pdo number %d out of range 1-%d", number, PDO_COUNT)
+	}
+	switch direction {
+	case entities.PdoReceive:
+		return RPDO_COMMUNICATION_PARAMETER + uint16(number-1), RPDO_MAPPING_PARAMETER + uint16(number-1), nil
+	case entities.PdoTransmit:
+		return TPDO_COMMUNICATION_PARAMETER + uint16(number-1), TPDO_MAPPING_PARAMETER + uint16(number-1), nil
+	}
+	return 0, 0, validationError("invalid pdo direction %q", direction)
+}
+
+// findObject returns the variable of the object dictionary at index and subindex
+func findObject(node *canopen.Node, index uint16, subindex uint8) canopen.DicObject {
+	if node.ObjectDic == nil {
+		return nil
+	}
+	obj := node.ObjectDic.FindIndex(index)
+	if obj == nil {
+		return nil
+	}
+	if obj.IsDicVariable() {
+		if subindex != 0 {
+			return nil
+		}
+		return obj
+	}
+	return obj.FindIndex(uint16(subindex))
+}
+
+func hasObject(node *canopen.Node, index uint16, subindex uint8) bool {
+	return findObject(node, index, subindex) != nil
+}
+
+// objectDataType returns the data type from the eds, unknown objects are treated as raw data
+func objectDataType(node *canopen.Node, index uint16, subindex uint8) byte {
+	if obj := findObject(node, index, subindex); obj != nil {
+		return obj.GetDataType()
+	}
+	return canopen.Domain
+}
+
+func littleEndianUint(data []byte) uint64 {
+	padded := make([]byte, 8)
+	copy(padded, data)
+	return binary.LittleEndian.Uint64(padded)
+}
+
+// extractBits returns length bits from offset of data as little endian bytes
+func extractBits(data []byte, offset int, length int) []byte {
+	result := make([]byte, (length+7)/8)
+	for i := 0; i < length; i++ {
+		bit := offset + i
+		if bit/8 >= len(data) {
+			break
+		}
+		if data[bit/8]&(1<<(bit%8)) != 0 {
+			result[i/8] |= 1 << (i % 8)
+		}
+	}
+	return result
+}
+
+// signExtend widens a signed value of bits length to size bytes
+func signExtend(data []byte, bits int, size int) []byte {
+	result := make([]byte, max(size, len(data)))
+	copy(result, data)
+	if bits == 0 || bits >= size*8 || result[(bits-1)/8]&(1<<((bits-1)%8)) == 0 {
+		return result
+	}
+	for i := bits; i < size*8; i++ {
+		result[i/8] |= 1 << (i % 8)
+	}
+	return result
+}
+
+// insertBits writes length bits of value at offset into data
+func insertBits(data []byte, offset int, length int, value []byte) {
+	for i := 0; i < length && i/8 < len(value); i++ {
+		bit := offset + i
+		if value[i/8]&(1<<(i%8)) != 0 {
+			data[bit/8] |= 1 << (bit % 8)
+		} else {
+			data[bit/8] &^= 1 << (bit % 8)
+		}
+	}
+}
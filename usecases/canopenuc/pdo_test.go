@@ -0,0 +1,53 @@
+package canopenuc_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jaster-prj/canopenrest/entities"
+	"github.com/jaster-prj/canopenrest/external/simulator/simtest"
+)
+
+func TestPdoValues(t *testing.T) {
+	bus := simtest.NewBus(t, TEST_NODE)
+	uc := simtest.NewCanOpenUC(t, bus)
+	ctx := testContext(t)
+
+	pdos, err := uc.ReadPdos(ctx, simtest.BUS, TEST_NODE, entities.PdoTransmit)
+	if err != nil {
+		t.Fatalf("read tpdos: %v", err)
+	}
+	if len(pdos) == 0 || pdos[0].CobId != 0x180+TEST_NODE || len(pdos[0].Mapping) != 2 {
+		t.Fatalf("tpdos %+v, want TPDO1 with cob id 0x%X and 2 mapped objects", pdos, 0x180+TEST_NODE)
+	}
+	mapping := pdos[0].Mapping
+	if mapping[0].Index != 0x6000 || mapping[0].BitLength != 8 || mapping[1].Index != 0x6401 || mapping[1].BitLength != 16 {
+		t.Errorf("TPDO1 mapping %+v, want 0x6000 sub 1 with 8 bit and 0x6401 sub 1 with 16 bit", mapping)
+	}
+
+	values, err := uc.GetPdoValues(ctx, simtest.BUS, TEST_NODE, pdos[0].Number)
+	if err != nil {
+		t.Fatalf("pdo values: %v", err)
+	}
+	if values.Received {
+		t.Errorf("TPDO1 received before it was sent")
+	}
+	err = bus.Slave(TEST_NODE).Send(0x180+TEST_NODE, []byte{0x5A, 0x18, 0xFC})
+	if err != nil {
+		t.Fatal(err)
+	}
+	deadline := time.Now().Add(time.Second)
+	for !values.Received {
+		if time.Now().After(deadline) {
+			t.Fatal("TPDO1 not received")
+		}
+		time.Sleep(10 * time.Millisecond)
+		values, err = uc.GetPdoValues(ctx, simtest.BUS, TEST_NODE, pdos[0].Number)
+		if err != nil {
+			t.Fatalf("pdo values: %v", err)
+		}
+	}
+	if len(values.Values) != 2 || values.Values[0].Value != uint64(0x5A) || values.Values[1].Value != int64(-1000) {
+		t.Errorf("TPDO1 values %+v, want 0x5A and -1000", values.Values)
+	}
+}
@@ -0,0 +1,181 @@
+package canopenuc
+
+import (
+	"context"
+	"encoding/binary"
+	"time"
+
+	"github.com/jaster-prj/canopenrest/common"
+	"github.com/jaster-prj/canopenrest/entities"
+	can "github.com/jaster-prj/go-can"
+	canopen "github.com/jaster-prj/go-canopen"
+)
+
+const (
+	DEVICE_TYPE          = 4096 //0x1000
+	IDENTITY             = 4120 //0x1018
+	SCAN_BATCH_SIZE      = 8
+	SCAN_DEFAULT_TIMEOUT = 100 * time.Millisecond
+	SDO_REQUEST_COB_ID   = 0x600
+	SDO_RESPONSE_COB_ID  = 0x580
+	SDO_ABORT            = 0x80
+)
+
+// ScanNetwork probes the node ids 1-127 of a bus by reading the device type 0x1000 and the
+// identity 0x1018, and adds nodes that are online by heartbeat but do not answer SDO requests,
+// e.g. because they are stopped. Requests are sent in batches, timeout is the time to wait for
+// the answers of a batch.
+func (c *CanOpenUC) ScanNetwork(ctx context.Context, bus string, timeout time.Duration) ([]entities.ScanResult, error) {
+	canBus, err := c.getBus(bus)
+	if err != nil {
+		return nil, err
+	}
+	if timeout <= 0 {
+		timeout = SCAN_DEFAULT_TIMEOUT
+	}
+	ids, err := c.persistence.GetNodes(canBus.name)
+	if err != nil {
+		return nil, err
+	}
+	withEds := map[int]bool{}
+	for _, id := range ids {
+		withEds[id] = true
+	}
+
+	// Nodes being flashed are busy with the program download, they are reported by heartbeat only
+	flashing := c.flashingNodes(canBus.name)
+	candidates := []int{}
+	for id := 1; id <= 127; id++ {
+		if !flashing[id] {
+			candidates = append(candidates, id)
+		}
+	}
+	unlock, err := c.lockNodes(ctx, canBus, candidates)
+	if err != nil {
+		return nil, err
+	}
+	deviceTypes, err := scanUpload(ctx, canBus.network, candidates, DEVICE_TYPE, 0, timeout)
+	if err != nil {
+		unlock()
+		return nil, err
+	}
+	responders := []int{}
+	for id := 1; id <= 127; id++ {
+		if _, ok := deviceTypes[id]; ok {
+			responders = append(responders, id)
+		}
+	}
+	identities := []map[int][]byte{}
+	for subindex := uint8(1); subindex <= 4; subindex++ {
+		identity, err := scanUpload(ctx, canBus.network, responders, IDENTITY, subindex, timeout)
+		if err != nil {
+			unlock()
+			return nil, err
+		}
+		identities = append(identities, identity)
+	}
+	unlock()
+
+	c.livenessMu.Lock()
+	defer c.livenessMu.Unlock()
+	results := []entities.ScanResult{}
+	for id := 1; id <= 127; id++ {
+		liveness, heartbeat := canBus.liveness[id]
+		heartbeat = heartbeat && liveness.online
+		deviceType, sdoResponse := deviceTypes[id]
+		if !heartbeat && !sdoResponse {
+			continue
+		}
+		result := entities.ScanResult{
+			Id:          id,
+			SdoResponse: sdoResponse,
+			Heartbeat:   heartbeat,
+			HasEds:      withEds[id],
+		}
+		if heartbeat {
+			result.State = nmtStateString(liveness.state)
+		}
+		if sdoResponse {
+			result.DeviceType = scanValue(deviceType)
+			result.VendorId = scanValue(identities[0][id])
+			result.ProductCode = scanValue(identities[1][id])
+			result.RevisionNumber = scanValue(identities[2][id])
+			result.SerialNumber = scanValue(identities[3][id])
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// scanUpload sends expedited SDO upload requests to the given nodes in batches and returns
+// the answers by node id. Nodes answering with an abort are contained with nil data.
+// When ctx is done the pending requests are aborted.
+func scanUpload(ctx context.Context, network *canopen.Network, ids []int, index uint16, subindex uint8, timeout time.Duration) (map[int][]byte, error) {
+	responses := map[int][]byte{}
+	filterFunc := func(frm *can.Frame) bool {
+		return frm.ArbitrationID > SDO_RESPONSE_COB_ID && frm.ArbitrationID <= SDO_RESPONSE_COB_ID+127 &&
+			frm.DLC == 8 && binary.LittleEndian.Uint16(frm.Data[1:3]) == index && frm.Data[3] == subindex
+	}
+	framesChan := network.AcquireFramesChan(&filterFunc)
+	defer network.ReleaseFramesChan(framesChan.ID)
+
+	request := make([]byte, 8)
+	request[0] = canopen.SDORequestUpload
+	binary.LittleEndian.PutUint16(request[1:], index)
+	request[3] = subindex
+	for start := 0; start < len(ids); start += SCAN_BATCH_SIZE {
+		batch := ids[start:min(start+SCAN_BATCH_SIZE, len(ids))]
+		pending := map[int]bool{}
+		for _, id := range batch {
+			if network.Send(uint32(SDO_REQUEST_COB_ID+id), request) == nil {
+				pending[id] = true
+			}
+		}
+		timer := time.NewTimer(timeout)
+		for len(pending) > 0 {
+			select {
+			case frm := <-framesChan.C:
+				id := int(frm.ArbitrationID - SDO_RESPONSE_COB_ID)
+				if !pending[id] {
+					continue
+				}
+				delete(pending, id)
+				command := frm.Data[0]
+				switch {
+				case command == SDO_ABORT:
+					responses[id] = nil
+				case command&0xE0 == canopen.SDOResponseUpload && command&canopen.SDOExpedited != 0:
+					size := 4
+					if command&canopen.SDOSizeSpecified != 0 {
+						size = 4 - int((command>>2)&0x3)
+					}
+					responses[id] = append([]byte{}, frm.Data[4:4+size]...)
+				default:
+					// Segmented answers are not expected for the identity objects
+					responses[id] = nil
+				}
+			case <-timer.C:
+				pending = map[int]bool{}
+			case <-ctx.Done():
+				timer.Stop()
+				abort := make([]byte, 8)
+				copy(abort, request[:4])
+				abort[0] = SDO_ABORT
+				binary.LittleEndian.PutUint32(abort[4:], SDO_ABORT_LOCAL_CONTROL)
+				for id := range pending {
+					network.Send(uint32(SDO_REQUEST_COB_ID+id), abort)
+				}
+				return nil, ctx.Err()
+			}
+		}
+		timer.Stop()
+	}
+	return responses, nil
+}
+
+func scanValue(data []byte) *uint32 {
+	if len(data) == 0 {
+		return nil
+	}
+	return common.POINTER(uint32(littleEndianUint(data)))
+}
@@ -0,0 +1,41 @@
+package canopenuc_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jaster-prj/canopenrest/external/simulator/simtest"
+)
+
+func TestScanNetwork(t *testing.T) {
+	bus := simtest.NewBus(t, TEST_NODE, TEST_NODE+1)
+	uc := simtest.NewCanOpenUC(t, bus)
+	ctx := testContext(t)
+	// the eds of the second node is unknown
+	err := uc.DeleteNode(ctx, simtest.BUS, TEST_NODE+1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	results, err := uc.ScanNetwork(ctx, simtest.BUS, 100*time.Millisecond)
+	if err != nil {
+		t.Fatalf("scan: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("found %d nodes, want 2: %+v", len(results), results)
+	}
+	for _, result := range results {
+		if !result.SdoResponse {
+			t.Errorf("node %d did not answer the sdo requests", result.Id)
+		}
+		if result.DeviceType == nil || *result.DeviceType != 0x191 {
+			t.Errorf("node %d device type %v, want 0x191", result.Id, result.DeviceType)
+		}
+		if result.VendorId == nil || *result.VendorId != 1 || result.RevisionNumber == nil || *result.RevisionNumber != 0x00010000 {
+			t.Errorf("node %d identity %v %v, want vendor 1 revision 0x00010000", result.Id, result.VendorId, result.RevisionNumber)
+		}
+		if result.HasEds != (result.Id == TEST_NODE) {
+			t.Errorf("node %d has eds %t", result.Id, result.HasEds)
+		}
+	}
+}
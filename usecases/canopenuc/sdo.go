@@ -0,0 +1,103 @@
+package canopenuc
+
+import (
+	"context"
+
+	"github.com/jaster-prj/canopenrest/entities"
+	canopen "github.com/jaster-prj/go-canopen"
+)
+
+// ReadSDOValue reads an object and decodes it with the data type of the eds
+func (c *CanOpenUC) ReadSDOValue(ctx context.Context, bus string, id int, index uint16, subindex uint8) (*entities.SdoValue, error) {
+	node, err := c.getNode(bus, id)
+	if err != nil {
+		return nil, err
+	}
+	variable, err := findVariable(node, index, subindex)
+	if err != nil {
+		return nil, err
+	}
+	if variable.AccessType == "wo" {
+		return nil, validationError("0x%04X sub %d is write only", index, subindex)
+	}
+	data, err := c.ReadSDO(ctx, bus, id, index, subindex)
+	if err != nil {
+		return nil, err
+	}
+	return &entities.SdoValue{
+		Index:    index,
+		Subindex: subindex,
+		Name:     variable.Name,
+		DataType: dataTypeName(variable.DataType),
+		Value:    decodeValue(variable.DataType, data),
+	}, nil
+}
+
+// WriteSDOValue encodes a value with the data type of the eds and writes it. The value is checked
+// against access type, data type range and the limits of the eds before it is sent.
+// dataType is optional, if set it has to match the eds.
+func (c *CanOpenUC) WriteSDOValue(ctx context.Context, bus string, id int, index uint16, subindex uint8, value any, dataType string) error {
+	node, err := c.getNode(bus, id)
+	if err != nil {
+		return err
+	}
+	variable, err := findVariable(node, index, subindex)
+	if err != nil {
+		return err
+	}
+	if dataType != "" && dataType != dataTypeName(variable.DataType) {
+		return validationError("0x%04X sub %d is %s, not %s", index, subindex, dataTypeName(variable.DataType), dataType)
+	}
+	switch variable.AccessType {
+	case "rw", "wo", "rww", "rwr":
+	default:
+		return validationError("0x%04X sub %d is not writable (%s)", index, subindex, variable.AccessType)
+	}
+	data, err := encodeValue(variable.DataType, value)
+	if err != nil {
+		return err
+	}
+	err = checkLimits(variable, value)
+	if err != nil {
+		return err
+	}
+	return c.WriteSDO(ctx, bus, id, index, subindex, data)
+}
+
+// findVariable returns the variable of the eds at index and subindex
+func findVariable(node *canopen.Node, index uint16, subindex uint8) (*canopen.DicVariable, error) {
+	variable, ok := findObject(node, index, subindex).(*canopen.DicVariable)
+	if !ok {
+		return nil, notFoundError("0x%04X sub %d not in eds of node %d", index, subindex, node.ID)
+	}
+	return variable, nil
+}
+
+// checkLimits checks integers against LowLimit and HighLimit of the eds. A limit of 0 can not be
+// told apart from a missing limit, it is left to the range of the data type.
+func checkLimits(variable *canopen.DicVariable, value any) error {
+	if !canopen.IsSignedType(variable.DataType) && !canopen.IsUnsignedType(variable.DataType) {
+		return nil
+	}
+	if variable.Min == 0 && variable.Max == 0 {
+		return nil
+	}
+	if canopen.IsUnsignedType(variable.DataType) {
+		u, err := toUint64(value)
+		if err != nil {
+			return err
+		}
+		if (variable.Min > 0 && u < uint64(variable.Min)) || (variable.Max > 0 && u > uint64(variable.Max)) {
+			return validationError("%d out of limits %d-%d of 0x%04X sub %d", u, variable.Min, variable.Max, variable.Index, variable.SubIndex)
+		}
+		return nil
+	}
+	i, err := toInt64(value)
+	if err != nil {
+		return err
+	}
+	if (variable.Min != 0 && i < int64(variable.Min)) || (variable.Max != 0 && i > int64(variable.Max)) {
+		return validationError("%d out of limits %d-%d of 0x%04X sub %d", i, variable.Min, variable.Max, variable.Index, variable.SubIndex)
+	}
+	return nil
+}
@@ -0,0 +1,388 @@
+package canopenuc
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+	"time"
+
+	"github.com/jaster-prj/canopenrest/entities"
+	canopen "github.com/jaster-prj/go-canopen"
+)
+
+const (
+	SDO_BLOCK_SIZE        = 127
+	SDO_BLOCK_MIN_SIZE    = 32
+	SDO_BLOCK_TIMEOUT     = time.Second
+	SDO_BLOCK_END_TIMEOUT = 10 * time.Second // the node may store large data before it confirms
+	SDO_BLOCK_RETRIES     = 3
+)
+
+// Command specifiers and flags of SDO block transfers
+const (
+	SDO_BLOCK_DOWNLOAD_REQUEST  = 0xC0
+	SDO_BLOCK_DOWNLOAD_RESPONSE = 0xA0
+	SDO_BLOCK_UPLOAD_REQUEST    = 0xA0
+	SDO_BLOCK_UPLOAD_RESPONSE   = 0xC0
+	SDO_BLOCK_CRC               = 0x04
+	SDO_BLOCK_SIZE_INDICATED    = 0x02
+	SDO_BLOCK_END               = 0x01
+	SDO_BLOCK_ACK               = 0x02
+	SDO_BLOCK_START             = 0x03
+	SDO_BLOCK_LAST_SEGMENT      = 0x80
+)
+
+// WriteSDOStream downloads size bytes of reader to an object by SDO block transfer, size is -1 if
+// not known in advance. Small objects and nodes without block transfer are written segmented.
+func (c *CanOpenUC) WriteSDOStream(ctx context.Context, bus string, id int, index uint16, subindex uint8, reader io.Reader, size int64, progress entities.SdoProgress) error {
+	canBus, err := c.getBus(bus)
+	if err != nil {
+		return err
+	}
+	node, err := c.getNode(canBus.name, id)
+	if err != nil {
+		return err
+	}
+	unlock, err := c.lockNode(ctx, canBus, id)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	return writeSDOStream(ctx, canBus.network, node, index, subindex, reader, size, progress)
+}
+
+// ReadSDOStream uploads an object by SDO block transfer to writer and returns the number of bytes
+// written. Nodes without block transfer are read segmented.
+func (c *CanOpenUC) ReadSDOStream(ctx context.Context, bus string, id int, index uint16, subindex uint8, writer io.Writer, progress entities.SdoProgress) (int64, error) {
+	canBus, err := c.getBus(bus)
+	if err != nil {
+		return 0, err
+	}
+	node, err := c.getNode(canBus.name, id)
+	if err != nil {
+		return 0, err
+	}
+	unlock, err := c.lockNode(ctx, canBus, id)
+	if err != nil {
+		return 0, err
+	}
+	defer unlock()
+	return readSDOStream(ctx, canBus.network, node, index, subindex, writer, progress)
+}
+
+// writeSDOStream is WriteSDOStream with the node locked, a block transfer is aborted when ctx is done
+func writeSDOStream(ctx context.Context, network *canopen.Network, node *canopen.Node, index uint16, subindex uint8, reader io.Reader, size int64, progress entities.SdoProgress) error {
+	if size >= 0 && size < SDO_BLOCK_MIN_SIZE {
+		return writeSegmented(ctx, node, index, subindex, reader, progress)
+	}
+	transfer := newSdoTransfer(ctx, network, node.ID, index, subindex, progress)
+	err := transfer.blockDownload(reader, size)
+	transfer.close()
+	if blockRejected(transfer, err) {
+		// The node does not know block transfers, nothing is read from reader yet
+		return writeSegmented(ctx, node, index, subindex, reader, progress)
+	}
+	return err
+}
+
+// readSDOStream is ReadSDOStream with the node locked, a block transfer is aborted when ctx is done
+func readSDOStream(ctx context.Context, network *canopen.Network, node *canopen.Node, index uint16, subindex uint8, writer io.Writer, progress entities.SdoProgress) (int64, error) {
+	transfer := newSdoTransfer(ctx, network, node.ID, index, subindex, progress)
+	err := transfer.blockUpload(writer)
+	transfer.close()
+	if blockRejected(transfer, err) {
+		// The node does not know block transfers, nothing is written to writer yet
+		data, err := readSDO(ctx, node, index, subindex)
+		if err != nil {
+			return 0, err
+		}
+		if progress != nil {
+			progress(0, int64(len(data)))
+		}
+		n, err := writer.Write(data)
+		if err == nil && progress != nil {
+			progress(int64(n), int64(len(data)))
+		}
+		return int64(n), err
+	}
+	return transfer.transferred, err
+}
+
+// blockRejected is true if the node rejected or ignored the initiate request of a block transfer.
+// Once the node accepted it, data is read from the stream and the transfer cannot be repeated.
+func blockRejected(transfer *sdoTransfer, err error) bool {
+	var abort *SdoAbortError
+	if transfer.started || !errors.As(err, &abort) {
+		return false
+	}
+	return abort.Code == SDO_ABORT_INVALID_COMMAND || abort.Code == SDO_ABORT_TIMEOUT
+}
+
+// writeSegmented reads all of reader and writes it by expedited or segmented transfer
+func writeSegmented(ctx context.Context, node *canopen.Node, index uint16, subindex uint8, reader io.Reader, progress entities.SdoProgress) error {
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return err
+	}
+	if progress != nil {
+		progress(0, int64(len(data)))
+	}
+	err = writeSDO(ctx, node, index, subindex, data)
+	if err == nil && progress != nil {
+		progress(int64(len(data)), int64(len(data)))
+	}
+	return err
+}
+
+// blockDownload sends the data of reader by block transfer, size is -1 if unknown
+func (t *sdoTransfer) blockDownload(reader io.Reader, size int64) error {
+	request := t.multiplexer(SDO_BLOCK_DOWNLOAD_REQUEST | SDO_BLOCK_CRC)
+	if size >= 0 {
+		request[0] |= SDO_BLOCK_SIZE_INDICATED
+		binary.LittleEndian.PutUint32(request[4:], uint32(size))
+	}
+	response, err := t.request(request, SDO_BLOCK_TIMEOUT)
+	if err != nil {
+		return err
+	}
+	if response[0]&0xE3 != SDO_BLOCK_DOWNLOAD_RESPONSE {
+		return t.abort(SDO_ABORT_INVALID_COMMAND)
+	}
+	crcSupported := response[0]&SDO_BLOCK_CRC != 0
+	blockSize := int(response[4])
+	if blockSize < 1 || blockSize > SDO_BLOCK_SIZE {
+		return t.abort(SDO_ABORT_INVALID_SIZE)
+	}
+	t.started = true
+	t.report(0, size, true)
+
+	segments := sdoSegmentReader{reader: reader}
+	crc := uint16(0)
+	// block holds the segments not acknowledged yet, last is set once it contains the final segment
+	block := [][]byte{}
+	last := false
+	unused := 0
+	for !last || len(block) > 0 {
+		for len(block) < blockSize && !last {
+			segment, final, err := segments.next()
+			if err != nil {
+				t.abort(SDO_ABORT_GENERAL)
+				return err
+			}
+			block = append(block, segment)
+			if final {
+				last = true
+				unused = SDO_SEGMENT_SIZE - len(segment)
+			}
+		}
+		count := min(len(block), blockSize)
+		for i, segment := range block[:count] {
+			frame := make([]byte, 8)
+			frame[0] = byte(i + 1)
+			if last && i == len(block)-1 {
+				frame[0] |= SDO_BLOCK_LAST_SEGMENT
+			}
+			copy(frame[1:], segment)
+			if err := t.send(frame); err != nil {
+				return err
+			}
+		}
+		response, err := t.receive(SDO_BLOCK_TIMEOUT)
+		if err != nil {
+			return err
+		}
+		if response[0]&0xE3 != SDO_BLOCK_DOWNLOAD_RESPONSE|SDO_BLOCK_ACK {
+			return t.abort(SDO_ABORT_INVALID_COMMAND)
+		}
+		acknowledged := int(response[1])
+		if acknowledged > count {
+			return t.abort(SDO_ABORT_INVALID_SEQUENCE)
+		}
+		for _, segment := range block[:acknowledged] {
+			crc = crc16(crc, segment)
+			t.transferred += int64(len(segment))
+		}
+		block = block[acknowledged:]
+		blockSize = int(response[2])
+		if blockSize < 1 || blockSize > SDO_BLOCK_SIZE {
+			return t.abort(SDO_ABORT_INVALID_SIZE)
+		}
+		t.report(t.transferred, size, false)
+	}
+	if size >= 0 && t.transferred != size {
+		t.abort(SDO_ABORT_LENGTH_MISMATCH)
+		return validationError("%d bytes announced, %d bytes sent", size, t.transferred)
+	}
+
+	end := []byte{SDO_BLOCK_DOWNLOAD_REQUEST | byte(unused)<<2 | SDO_BLOCK_END, 0, 0}
+	if crcSupported {
+		binary.LittleEndian.PutUint16(end[1:], crc)
+	}
+	response, err = t.request(end, SDO_BLOCK_END_TIMEOUT)
+	if err != nil {
+		return err
+	}
+	if response[0]&0xE3 != SDO_BLOCK_DOWNLOAD_RESPONSE|SDO_BLOCK_END {
+		return t.abort(SDO_ABORT_INVALID_COMMAND)
+	}
+	t.report(t.transferred, size, true)
+	return nil
+}
+
+// blockUpload receives the data of the object by block transfer and writes it to writer
+func (t *sdoTransfer) blockUpload(writer io.Writer) error {
+	request := t.multiplexer(SDO_BLOCK_UPLOAD_REQUEST | SDO_BLOCK_CRC)
+	request[4] = SDO_BLOCK_SIZE
+	response, err := t.request(request, SDO_BLOCK_TIMEOUT)
+	if err != nil {
+		return err
+	}
+	if response[0]&0xE1 != SDO_BLOCK_UPLOAD_RESPONSE {
+		return t.abort(SDO_ABORT_INVALID_COMMAND)
+	}
+	crcSupported := response[0]&SDO_BLOCK_CRC != 0
+	size := int64(-1)
+	if response[0]&SDO_BLOCK_SIZE_INDICATED != 0 {
+		size = int64(binary.LittleEndian.Uint32(response[4:]))
+	}
+	t.started = true
+	t.report(0, size, true)
+
+	crc := uint16(0)
+	// final is the last segment, it is written once the end tells its unused bytes
+	var final []byte
+	if err := t.send([]byte{SDO_BLOCK_UPLOAD_REQUEST | SDO_BLOCK_START}); err != nil {
+		return err
+	}
+	for final == nil {
+		sequence, timeouts := 0, 0
+		block := [][]byte{}
+	receive:
+		for {
+			select {
+			case frm, ok := <-t.frames.C:
+				if !ok {
+					return errors.New("frame channel closed")
+				}
+				if frm.Data[0] == SDO_ABORT {
+					return t.aborted(frm.Data)
+				}
+				received := int(frm.Data[0] &^ SDO_BLOCK_LAST_SEGMENT)
+				lastSegment := frm.Data[0]&SDO_BLOCK_LAST_SEGMENT != 0
+				if received == sequence+1 {
+					sequence = received
+					block = append(block, append([]byte{}, frm.Data[1:]...))
+					if lastSegment {
+						final = block[len(block)-1]
+					}
+				}
+				if received >= SDO_BLOCK_SIZE || lastSegment {
+					break receive
+				}
+			case <-time.After(SDO_BLOCK_TIMEOUT):
+				// Segments got lost, the acknowledge makes the node repeat them
+				timeouts++
+				if timeouts > SDO_BLOCK_RETRIES {
+					return t.abort(SDO_ABORT_TIMEOUT)
+				}
+				break receive
+			case <-t.ctx.Done():
+				t.abort(SDO_ABORT_LOCAL_CONTROL)
+				return t.ctx.Err()
+			}
+		}
+		if err := t.send([]byte{SDO_BLOCK_UPLOAD_REQUEST | SDO_BLOCK_ACK, byte(sequence), SDO_BLOCK_SIZE}); err != nil {
+			return err
+		}
+		if final != nil {
+			block = block[:len(block)-1]
+		}
+		for _, segment := range block {
+			if _, err := writer.Write(segment); err != nil {
+				t.abort(SDO_ABORT_GENERAL)
+				return err
+			}
+			crc = crc16(crc, segment)
+			t.transferred += int64(len(segment))
+		}
+		t.report(t.transferred, size, false)
+	}
+
+	response, err = t.receive(SDO_BLOCK_TIMEOUT)
+	if err != nil {
+		return err
+	}
+	if response[0]&0xE3 != SDO_BLOCK_UPLOAD_RESPONSE|SDO_BLOCK_END {
+		return t.abort(SDO_ABORT_INVALID_COMMAND)
+	}
+	final = final[:SDO_SEGMENT_SIZE-int(response[0]>>2&0x7)]
+	crc = crc16(crc, final)
+	if crcSupported && binary.LittleEndian.Uint16(response[1:]) != crc {
+		return t.abort(SDO_ABORT_CRC_ERROR)
+	}
+	if size >= 0 && t.transferred+int64(len(final)) != size {
+		return t.abort(SDO_ABORT_LENGTH_MISMATCH)
+	}
+	if _, err := writer.Write(final); err != nil {
+		t.abort(SDO_ABORT_GENERAL)
+		return err
+	}
+	t.transferred += int64(len(final))
+	if err := t.send([]byte{SDO_BLOCK_UPLOAD_REQUEST | SDO_BLOCK_END}); err != nil {
+		return err
+	}
+	t.report(t.transferred, size, true)
+	return nil
+}
+
+// sdoSegmentReader splits a stream into segments and tells which segment is the last one
+type sdoSegmentReader struct {
+	reader  io.Reader
+	pending []byte
+	eof     bool
+}
+
+// next returns the next segment and if it is the final one, the final segment may be empty
+func (r *sdoSegmentReader) next() ([]byte, bool, error) {
+	if r.pending == nil {
+		if err := r.fill(); err != nil {
+			return nil, false, err
+		}
+	}
+	segment := r.pending
+	r.pending = nil
+	if r.eof {
+		return segment, true, nil
+	}
+	if err := r.fill(); err != nil {
+		return nil, false, err
+	}
+	return segment, r.eof && len(r.pending) == 0, nil
+}
+
+func (r *sdoSegmentReader) fill() error {
+	buf := make([]byte, SDO_SEGMENT_SIZE)
+	n, err := io.ReadFull(r.reader, buf)
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		r.eof = true
+		err = nil
+	}
+	r.pending = buf[:n]
+	return err
+}
+
+// crc16 continues the CRC-16-CCITT (XMODEM) used by SDO block transfers over data
+func crc16(crc uint16, data []byte) uint16 {
+	for _, b := range data {
+		crc ^= uint16(b) << 8
+		for i := 0; i < 8; i++ {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return crc
+}
@@ -0,0 +1,63 @@
+package canopenuc_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/jaster-prj/canopenrest/external/simulator"
+	"github.com/jaster-prj/canopenrest/external/simulator/simtest"
+	"github.com/jaster-prj/canopenrest/usecases/canopenuc"
+)
+
+func TestSdoStream(t *testing.T) {
+	tests := []struct {
+		name          string
+		blockTransfer simulator.BlockTransferMode
+	}{
+		{name: "block transfer", blockTransfer: simulator.BLOCK_TRANSFER_SUPPORTED},
+		// the transfer falls back to segmented if the node rejects or ignores block transfers
+		{name: "block transfer rejected", blockTransfer: simulator.BLOCK_TRANSFER_REJECTED},
+		{name: "block transfer ignored", blockTransfer: simulator.BLOCK_TRANSFER_IGNORED},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bus := simtest.NewBus(t)
+			slave := bus.AddSlave(t, simulator.SlaveConfig{NodeId: TEST_NODE, BlockTransfer: tt.blockTransfer})
+			uc := simtest.NewCanOpenUC(t, bus)
+			ctx := testContext(t)
+
+			// the program data is writable once the program is stopped
+			err := uc.WriteSDO(ctx, simtest.BUS, TEST_NODE, canopenuc.PROGRAM_CONTROL, 1, []byte{byte(canopenuc.PROGRAM_CONTROL_STOP)})
+			if err != nil {
+				t.Fatalf("stop program: %v", err)
+			}
+			data := make([]byte, 3000)
+			for i := range data {
+				data[i] = byte(i * 13)
+			}
+			var transferred int64
+			progress := func(done int64, total int64) {
+				transferred = done
+			}
+			err = uc.WriteSDOStream(ctx, simtest.BUS, TEST_NODE, canopenuc.PROGRAM_DATA, 1, bytes.NewReader(data), int64(len(data)), progress)
+			if err != nil {
+				t.Fatalf("download: %v", err)
+			}
+			if transferred != int64(len(data)) {
+				t.Errorf("progress reported %d bytes, want %d", transferred, len(data))
+			}
+			if !bytes.Equal(slave.Program(), data) {
+				t.Errorf("simulator holds %d bytes, want the downloaded %d bytes", len(slave.Program()), len(data))
+			}
+
+			read := &bytes.Buffer{}
+			size, err := uc.ReadSDOStream(ctx, simtest.BUS, TEST_NODE, canopenuc.PROGRAM_DATA, 1, read, nil)
+			if err != nil {
+				t.Fatalf("upload: %v", err)
+			}
+			if size != int64(len(data)) || !bytes.Equal(read.Bytes(), data) {
+				t.Errorf("uploaded %d bytes differing from the downloaded %d bytes", size, len(data))
+			}
+		})
+	}
+}
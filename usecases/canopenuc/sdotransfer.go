@@ -0,0 +1,279 @@
+package canopenuc
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jaster-prj/canopenrest/entities"
+	can "github.com/jaster-prj/go-can"
+	canopen "github.com/jaster-prj/go-canopen"
+)
+
+const (
+	SDO_SEGMENT_SIZE      = 7
+	SDO_TIMEOUT           = 5 * time.Second // the node may store to flash before it answers
+	SDO_PROGRESS_INTERVAL = 500 * time.Millisecond
+)
+
+// Command specifiers and flags of SDO expedited and segmented transfers
+const (
+	SDO_UPLOAD_REQUEST            = 0x40
+	SDO_UPLOAD_RESPONSE           = 0x40
+	SDO_UPLOAD_SEGMENT_REQUEST    = 0x60
+	SDO_UPLOAD_SEGMENT_RESPONSE   = 0x00
+	SDO_DOWNLOAD_REQUEST          = 0x20
+	SDO_DOWNLOAD_RESPONSE         = 0x60
+	SDO_DOWNLOAD_SEGMENT_REQUEST  = 0x00
+	SDO_DOWNLOAD_SEGMENT_RESPONSE = 0x20
+	SDO_EXPEDITED                 = 0x02
+	SDO_SIZE_INDICATED            = 0x01
+	SDO_TOGGLE                    = 0x10
+	SDO_NO_MORE_SEGMENTS          = 0x01
+)
+
+const (
+	SDO_ABORT_TOGGLE           = 0x05030000
+	SDO_ABORT_TIMEOUT          = 0x05040000
+	SDO_ABORT_INVALID_COMMAND  = 0x05040001
+	SDO_ABORT_INVALID_SIZE     = 0x05040002
+	SDO_ABORT_INVALID_SEQUENCE = 0x05040003
+	SDO_ABORT_CRC_ERROR        = 0x05040004
+	SDO_ABORT_LENGTH_MISMATCH  = 0x06070010
+	SDO_ABORT_GENERAL          = 0x08000000
+	SDO_ABORT_LOCAL_CONTROL    = 0x08000021
+)
+
+// readSDO uploads an object by expedited or segmented transfer, the node has to be locked
+func readSDO(ctx context.Context, node *canopen.Node, index uint16, subindex uint8) ([]byte, error) {
+	transfer := newSdoTransfer(ctx, node.Network, node.ID, index, subindex, nil)
+	defer transfer.close()
+	return transfer.upload()
+}
+
+// writeSDO downloads data to an object by expedited or segmented transfer, the node has to be locked
+func writeSDO(ctx context.Context, node *canopen.Node, index uint16, subindex uint8, data []byte) error {
+	transfer := newSdoTransfer(ctx, node.Network, node.ID, index, subindex, nil)
+	defer transfer.close()
+	return transfer.download(data)
+}
+
+// sdoTransfer runs an SDO transfer as client on raw frames. The transfer is aborted with an
+// SDO abort when ctx is done.
+type sdoTransfer struct {
+	ctx         context.Context
+	network     *canopen.Network
+	id          int
+	index       uint16
+	subindex    uint8
+	frames      *canopen.NetworkFramesChan
+	progress    entities.SdoProgress
+	reported    time.Time
+	transferred int64
+	// started is set once the node accepted the initiate request of a block transfer
+	started bool
+}
+
+func newSdoTransfer(ctx context.Context, network *canopen.Network, id int, index uint16, subindex uint8, progress entities.SdoProgress) *sdoTransfer {
+	filterFunc := func(frm *can.Frame) bool {
+		return frm.ArbitrationID == uint32(SDO_RESPONSE_COB_ID+id) && frm.DLC == 8
+	}
+	// A whole block of segments may arrive at once, the channels of AcquireFramesChan are too small
+	frames := &canopen.NetworkFramesChan{
+		ID:     uuid.New().String(),
+		C:      make(chan *can.Frame, SDO_BLOCK_SIZE+1),
+		Filter: &filterFunc,
+	}
+	network.Lock()
+	network.FramesChans = append(network.FramesChans, frames)
+	network.Unlock()
+	return &sdoTransfer{
+		ctx:      ctx,
+		network:  network,
+		id:       id,
+		index:    index,
+		subindex: subindex,
+		frames:   frames,
+		progress: progress,
+	}
+}
+
+func (t *sdoTransfer) close() {
+	t.network.ReleaseFramesChan(t.frames.ID)
+}
+
+// upload receives the data of the object by expedited or segmented transfer
+func (t *sdoTransfer) upload() ([]byte, error) {
+	if err := t.ctx.Err(); err != nil {
+		return nil, err
+	}
+	response, err := t.request(t.multiplexer(SDO_UPLOAD_REQUEST), SDO_TIMEOUT)
+	if err != nil {
+		return nil, err
+	}
+	if response[0]&0xE0 != SDO_UPLOAD_RESPONSE {
+		return nil, t.abort(SDO_ABORT_INVALID_COMMAND)
+	}
+	if response[0]&SDO_EXPEDITED != 0 {
+		size := 4
+		if response[0]&SDO_SIZE_INDICATED != 0 {
+			size -= int(response[0]>>2) & 0x3
+		}
+		return append([]byte{}, response[4:4+size]...), nil
+	}
+	size := -1
+	if response[0]&SDO_SIZE_INDICATED != 0 {
+		size = int(binary.LittleEndian.Uint32(response[4:]))
+	}
+	data := []byte{}
+	toggle := byte(0)
+	for {
+		response, err := t.request([]byte{SDO_UPLOAD_SEGMENT_REQUEST | toggle}, SDO_TIMEOUT)
+		if err != nil {
+			return nil, err
+		}
+		if response[0]&0xE0 != SDO_UPLOAD_SEGMENT_RESPONSE {
+			return nil, t.abort(SDO_ABORT_INVALID_COMMAND)
+		}
+		if response[0]&SDO_TOGGLE != toggle {
+			return nil, t.abort(SDO_ABORT_TOGGLE)
+		}
+		unused := int(response[0]>>1) & 0x7
+		data = append(data, response[1:8-unused]...)
+		if response[0]&SDO_NO_MORE_SEGMENTS != 0 {
+			break
+		}
+		toggle ^= SDO_TOGGLE
+	}
+	if size >= 0 && len(data) != size {
+		return nil, fmt.Errorf("SDO upload of 0x%04X sub %d: %d bytes received, %d indicated", t.index, t.subindex, len(data), size)
+	}
+	return data, nil
+}
+
+// download sends data to the object by expedited or, for more than 4 bytes, segmented transfer
+func (t *sdoTransfer) download(data []byte) error {
+	if err := t.ctx.Err(); err != nil {
+		return err
+	}
+	if len(data) > 0 && len(data) <= 4 {
+		request := t.multiplexer(SDO_DOWNLOAD_REQUEST | SDO_EXPEDITED | SDO_SIZE_INDICATED | byte(4-len(data))<<2)
+		copy(request[4:], data)
+		response, err := t.request(request, SDO_TIMEOUT)
+		if err != nil {
+			return err
+		}
+		if response[0]&0xE0 != SDO_DOWNLOAD_RESPONSE {
+			return t.abort(SDO_ABORT_INVALID_COMMAND)
+		}
+		return nil
+	}
+	request := t.multiplexer(SDO_DOWNLOAD_REQUEST | SDO_SIZE_INDICATED)
+	binary.LittleEndian.PutUint32(request[4:], uint32(len(data)))
+	response, err := t.request(request, SDO_TIMEOUT)
+	if err != nil {
+		return err
+	}
+	if response[0]&0xE0 != SDO_DOWNLOAD_RESPONSE {
+		return t.abort(SDO_ABORT_INVALID_COMMAND)
+	}
+	toggle := byte(0)
+	for offset := 0; ; offset += SDO_SEGMENT_SIZE {
+		segment := data[offset:min(offset+SDO_SEGMENT_SIZE, len(data))]
+		last := offset+SDO_SEGMENT_SIZE >= len(data)
+		command := SDO_DOWNLOAD_SEGMENT_REQUEST | toggle | byte(SDO_SEGMENT_SIZE-len(segment))<<1
+		if last {
+			command |= SDO_NO_MORE_SEGMENTS
+		}
+		response, err := t.request(append([]byte{command}, segment...), SDO_TIMEOUT)
+		if err != nil {
+			return err
+		}
+		if response[0]&0xE0 != SDO_DOWNLOAD_SEGMENT_RESPONSE {
+			return t.abort(SDO_ABORT_INVALID_COMMAND)
+		}
+		if response[0]&SDO_TOGGLE != toggle {
+			return t.abort(SDO_ABORT_TOGGLE)
+		}
+		if last {
+			return nil
+		}
+		toggle ^= SDO_TOGGLE
+	}
+}
+
+func (t *sdoTransfer) multiplexer(command byte) []byte {
+	request := make([]byte, 8)
+	request[0] = command
+	binary.LittleEndian.PutUint16(request[1:], t.index)
+	request[3] = t.subindex
+	return request
+}
+
+func (t *sdoTransfer) send(data []byte) error {
+	frame := make([]byte, 8)
+	copy(frame, data)
+	if err := t.network.Send(uint32(SDO_REQUEST_COB_ID+t.id), frame); err != nil {
+		return busError("send SDO request to node %d: %v", t.id, err)
+	}
+	return nil
+}
+
+// request sends a request and waits for the response of the node
+func (t *sdoTransfer) request(data []byte, timeout time.Duration) ([8]byte, error) {
+	if err := t.send(data); err != nil {
+		return [8]byte{}, err
+	}
+	return t.receive(timeout)
+}
+
+// receive waits for the next frame of the node, an abort of the node is returned as error.
+// The transfer is aborted if the node does not answer in time or ctx is done.
+func (t *sdoTransfer) receive(timeout time.Duration) ([8]byte, error) {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case frm, ok := <-t.frames.C:
+		if !ok {
+			return [8]byte{}, errors.New("frame channel closed")
+		}
+		if frm.Data[0] == SDO_ABORT {
+			return frm.Data, t.aborted(frm.Data)
+		}
+		return frm.Data, nil
+	case <-timer.C:
+		return [8]byte{}, t.abort(SDO_ABORT_TIMEOUT)
+	case <-t.ctx.Done():
+		t.abort(SDO_ABORT_LOCAL_CONTROL)
+		return [8]byte{}, t.ctx.Err()
+	}
+}
+
+// abort sends an abort to the node and returns it as error
+func (t *sdoTransfer) abort(code uint32) error {
+	request := t.multiplexer(SDO_ABORT)
+	binary.LittleEndian.PutUint32(request[4:], code)
+	t.send(request)
+	return &SdoAbortError{Index: t.index, Subindex: t.subindex, Code: code}
+}
+
+// aborted returns the abort sent by the node as error
+func (t *sdoTransfer) aborted(data [8]byte) error {
+	return &SdoAbortError{
+		Index:    binary.LittleEndian.Uint16(data[1:]),
+		Subindex: data[3],
+		Code:     binary.LittleEndian.Uint32(data[4:]),
+	}
+}
+
+// report calls the progress function at most every SDO_PROGRESS_INTERVAL unless forced
+func (t *sdoTransfer) report(transferred int64, total int64, force bool) {
+	if t.progress == nil || (!force && time.Since(t.reported) < SDO_PROGRESS_INTERVAL) {
+		return
+	}
+	t.reported = time.Now()
+	t.progress(transferred, total)
+}
@@ -0,0 +1,331 @@
+package canopenuc
+
+import (
+	"context"
+	"encoding/binary"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jaster-prj/canopenrest/entities"
+	can "github.com/jaster-prj/go-can"
+	canopen "github.com/jaster-prj/go-canopen"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	HEARTBEAT_COB_ID               = 0x700
+	NMT_STATE_BOOTUP               = 0
+	NMT_STATE_PRE_OPERATIONAL      = 127
+	CONSUMER_HEARTBEAT_TIME        = 4118 //0x1016
+	PRODUCER_HEARTBEAT_TIME        = 4119 //0x1017
+	HEARTBEAT_DEFAULT_TIMEOUT      = 5 * time.Second
+	HEARTBEAT_SUPERVISION_INTERVAL = 100 * time.Millisecond
+	NODE_EVENT_CHAN_SIZE           = 20
+	// HEARTBEAT_READ_TIMEOUT limits the reading of the heartbeat times of a node seen first or booted
+	HEARTBEAT_READ_TIMEOUT = 5 * time.Second
+)
+
+// nodeLiveness is the heartbeat state of a node as seen by the supervisor
+type nodeLiveness struct {
+	state    int
+	lastSeen time.Time
+	online   bool
+	// heartbeatTime is the producer heartbeat time of the node, 0 if unknown or disabled
+	heartbeatTime time.Duration
+	// heartbeatRead is set once the heartbeat time was requested from the node
+	heartbeatRead bool
+	// heartbeatChanged is the time the heartbeat time was last changed
+	heartbeatChanged time.Time
+	// consumerTimes are the consumer heartbeat times (0x1016) configured for the node by other nodes, by consumer id
+	consumerTimes map[int]time.Duration
+	// waiters are released with the next heartbeat
+	waiters []chan bool
+}
+
+// timeout returns the time after the last heartbeat a node is considered offline. It is the shortest
+// consumer heartbeat time configured for the node on the bus, without consumer it allows half a period
+// of jitter on the producer time.
+func (l *nodeLiveness) timeout() time.Duration {
+	var timeout time.Duration
+	for _, consumerTime := range l.consumerTimes {
+		if timeout == 0 || consumerTime < timeout {
+			timeout = consumerTime
+		}
+	}
+	switch {
+	case timeout != 0:
+		return timeout
+	case l.heartbeatTime == 0:
+		return HEARTBEAT_DEFAULT_TIMEOUT
+	}
+	return l.heartbeatTime * 3 / 2
+}
+
+// RunSupervisorTask tracks the heartbeats of all nodes on all buses and reports nodes
+// as offline when no heartbeat arrived within their heartbeat time
+func (c *CanOpenUC) RunSupervisorTask() {
+	for _, name := range c.busNames {
+		bus := c.buses[name]
+		filterFunc := func(frm *can.Frame) bool {
+			return frm.ArbitrationID > HEARTBEAT_COB_ID && frm.ArbitrationID <= HEARTBEAT_COB_ID+127 && frm.DLC >= 1
+		}
+		framesChan := bus.network.AcquireFramesChan(&filterFunc)
+		go func() {
+			for frm := range framesChan.C {
+				c.handleHeartbeat(bus, int(frm.ArbitrationID-HEARTBEAT_COB_ID), int(frm.Data[0]))
+			}
+		}()
+	}
+	go func() {
+		ticker := time.NewTicker(HEARTBEAT_SUPERVISION_INTERVAL)
+		for range ticker.C {
+			c.superviseHeartbeats()
+		}
+	}()
+}
+
+// GetNodesStatus returns the liveness of all nodes of a bus known by eds or heartbeat
+func (c *CanOpenUC) GetNodesStatus(ctx context.Context, bus string) ([]entities.NodeStatus, error) {
+	canBus, err := c.getBus(bus)
+	if err != nil {
+		return nil, err
+	}
+	ids, err := c.persistence.GetNodes(canBus.name)
+	if err != nil {
+		return nil, err
+	}
+	c.livenessMu.Lock()
+	defer c.livenessMu.Unlock()
+	for id := range canBus.liveness {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	statuses := []entities.NodeStatus{}
+	for i, id := range ids {
+		if i > 0 && ids[i-1] == id {
+			continue
+		}
+		status := entities.NodeStatus{
+			Bus: canBus.name,
+			Id:  id,
+		}
+		if liveness, ok := canBus.liveness[id]; ok && !liveness.lastSeen.IsZero() {
+			lastSeen := liveness.lastSeen
+			status.Online = liveness.online
+			status.State = nmtStateString(liveness.state)
+			status.LastSeen = &lastSeen
+			status.HeartbeatTime = liveness.heartbeatTime
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// SubscribeNodeEvents returns a channel receiving online, offline and state change events of all buses.
+// Events are dropped while the channel is full. The returned function ends the subscription.
+func (c *CanOpenUC) SubscribeNodeEvents() (<-chan entities.NodeEvent, func()) {
+	c.livenessMu.Lock()
+	defer c.livenessMu.Unlock()
+	id := uuid.New()
+	events := make(chan entities.NodeEvent, NODE_EVENT_CHAN_SIZE)
+	c.nodeEventSubscribers[id] = events
+	return events, func() {
+		c.livenessMu.Lock()
+		defer c.livenessMu.Unlock()
+		if _, ok := c.nodeEventSubscribers[id]; ok {
+			delete(c.nodeEventSubscribers, id)
+			close(events)
+		}
+	}
+}
+
+// waitForHeartbeat returns true if a heartbeat arrives after the call before the timeout,
+// it returns false when ctx is done
+func (c *CanOpenUC) waitForHeartbeat(ctx context.Context, bus *Bus, id int, timeout time.Duration) bool {
+	c.livenessMu.Lock()
+	liveness := c.getLiveness(bus, id)
+	waiter := make(chan bool, 1)
+	liveness.waiters = append(liveness.waiters, waiter)
+	c.livenessMu.Unlock()
+	// A waiter left by a timeout or cancellation is removed, a silent node would collect them otherwise
+	defer func() {
+		c.livenessMu.Lock()
+		defer c.livenessMu.Unlock()
+		for i, w := range liveness.waiters {
+			if w == waiter {
+				liveness.waiters = append(liveness.waiters[:i], liveness.waiters[i+1:]...)
+				break
+			}
+		}
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case <-waiter:
+		return true
+	case <-timer.C:
+		return false
+	case <-ctx.Done():
+		return false
+	}
+}
+
+func (c *CanOpenUC) handleHeartbeat(bus *Bus, id int, state int) {
+	now := time.Now()
+	c.livenessMu.Lock()
+	defer c.livenessMu.Unlock()
+	liveness := c.getLiveness(bus, id)
+	wasOnline := liveness.online
+	previousState := liveness.state
+	liveness.online = true
+	liveness.lastSeen = now
+	liveness.state = state
+	for _, waiter := range liveness.waiters {
+		waiter <- true
+	}
+	liveness.waiters = nil
+	switch {
+	case !wasOnline:
+		c.emitNodeEvent(entities.NodeOnline, bus.name, id, state, now)
+	case previousState != state:
+		c.emitNodeEvent(entities.NodeStateChanged, bus.name, id, state, now)
+	}
+	// The heartbeat time is (re)read after boot-up, the node may have restored its defaults
+	if state == NMT_STATE_BOOTUP || !liveness.heartbeatRead {
+		liveness.heartbeatRead = true
+		go c.refreshHeartbeatTime(bus, id)
+	}
+}
+
+func (c *CanOpenUC) superviseHeartbeats() {
+	now := time.Now()
+	c.livenessMu.Lock()
+	defer c.livenessMu.Unlock()
+	for _, name := range c.busNames {
+		bus := c.buses[name]
+		for id, liveness := range bus.liveness {
+			// A changed heartbeat time applies from the change on
+			since := liveness.lastSeen
+			if liveness.heartbeatChanged.After(since) {
+				since = liveness.heartbeatChanged
+			}
+			if liveness.online && now.Sub(since) > liveness.timeout() {
+				liveness.online = false
+				c.emitNodeEvent(entities.NodeOffline, bus.name, id, liveness.state, now)
+			}
+		}
+	}
+}
+
+// refreshHeartbeatTime reads the heartbeat times of a node in the background of the supervisor,
+// limited by HEARTBEAT_READ_TIMEOUT
+func (c *CanOpenUC) refreshHeartbeatTime(bus *Bus, id int) {
+	ctx, cancel := context.WithTimeout(context.Background(), HEARTBEAT_READ_TIMEOUT)
+	defer cancel()
+	c.readHeartbeatTime(ctx, bus, id)
+}
+
+// readHeartbeatTime requests the producer heartbeat time of a node with known eds, the eds default
+// is used if the node does not answer. The consumer heartbeat times of the node are read as well.
+func (c *CanOpenUC) readHeartbeatTime(ctx context.Context, bus *Bus, id int) {
+	node, err := c.getNode(bus.name, id)
+	if err != nil {
+		return
+	}
+	c.readConsumerTimes(ctx, bus, id)
+	var heartbeatTime time.Duration
+	data, err := c.ReadSDO(ctx, bus.name, id, PRODUCER_HEARTBEAT_TIME, 0)
+	if err == nil && len(data) >= 2 {
+		heartbeatTime = time.Duration(binary.LittleEndian.Uint16(data)) * time.Millisecond
+	} else if obj, ok := findObject(node, PRODUCER_HEARTBEAT_TIME, 0).(*canopen.DicVariable); ok {
+		value, err := strconv.ParseUint(string(obj.Default), 0, 16)
+		if err != nil {
+			return
+		}
+		heartbeatTime = time.Duration(value) * time.Millisecond
+	}
+	c.setHeartbeatTime(bus, id, heartbeatTime)
+}
+
+// readConsumerTimes requests the consumer heartbeat times (0x1016) of a node and records them for the
+// nodes it consumes. The times of a node without 0x1016 in its eds or not answering are removed.
+func (c *CanOpenUC) readConsumerTimes(ctx context.Context, bus *Bus, id int) {
+	consumerTimes := map[int]time.Duration{}
+	node, err := c.getNode(bus.name, id)
+	if err == nil && findObject(node, CONSUMER_HEARTBEAT_TIME, 0) != nil {
+		data, err := c.ReadSDO(ctx, bus.name, id, CONSUMER_HEARTBEAT_TIME, 0)
+		for subindex := 1; err == nil && len(data) >= 1 && subindex <= int(data[0]); subindex++ {
+			entry, err := c.ReadSDO(ctx, bus.name, id, CONSUMER_HEARTBEAT_TIME, uint8(subindex))
+			if err != nil || len(entry) < 4 {
+				continue
+			}
+			value := binary.LittleEndian.Uint32(entry)
+			producer := int(value>>16) & 0xFF
+			consumerTime := time.Duration(value&0xFFFF) * time.Millisecond
+			if producer >= 1 && producer <= 127 && consumerTime != 0 {
+				consumerTimes[producer] = consumerTime
+			}
+		}
+	}
+	c.livenessMu.Lock()
+	defer c.livenessMu.Unlock()
+	for _, liveness := range bus.liveness {
+		delete(liveness.consumerTimes, id)
+	}
+	for producer, consumerTime := range consumerTimes {
+		liveness := c.getLiveness(bus, producer)
+		if liveness.consumerTimes == nil {
+			liveness.consumerTimes = map[int]time.Duration{}
+		}
+		liveness.consumerTimes[id] = consumerTime
+		liveness.heartbeatChanged = time.Now()
+	}
+}
+
+func (c *CanOpenUC) setHeartbeatTime(bus *Bus, id int, heartbeatTime time.Duration) {
+	c.livenessMu.Lock()
+	defer c.livenessMu.Unlock()
+	liveness := c.getLiveness(bus, id)
+	liveness.heartbeatTime = heartbeatTime
+	liveness.heartbeatRead = true
+	liveness.heartbeatChanged = time.Now()
+}
+
+// getLiveness returns the liveness record of a node, livenessMu must be held
+func (c *CanOpenUC) getLiveness(bus *Bus, id int) *nodeLiveness {
+	liveness, ok := bus.liveness[id]
+	if !ok {
+		liveness = &nodeLiveness{}
+		bus.liveness[id] = liveness
+	}
+	return liveness
+}
+
+// emitNodeEvent logs and publishes an event, livenessMu must be held
+func (c *CanOpenUC) emitNodeEvent(eventType entities.NodeEventType, bus string, id int, state int, timestamp time.Time) {
+	event := entities.NodeEvent{
+		Type:      eventType,
+		Bus:       bus,
+		Id:        id,
+		State:     nmtStateString(state),
+		Timestamp: timestamp,
+	}
+	log.Info().Str("Function", "Supervisor").Msgf("bus %s node %d: %s (%s)", bus, id, event.Type, event.State)
+	for _, subscriber := range c.nodeEventSubscribers {
+		select {
+		case subscriber <- event:
+		default:
+		}
+	}
+}
+
+// nmtStateString names the state of a heartbeat, boot-up is reported as INITIALISING
+func nmtStateString(state int) string {
+	if name, ok := canopen.NMTStates[state]; ok {
+		return name
+	}
+	return strconv.Itoa(state)
+}